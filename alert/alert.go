@@ -52,7 +52,9 @@ type Alert interface {
 	// GetKvdbInstance.
 	GetKvdbInstance() kvdb.Kvdb
 
-	// Raise raises an Alert.
+	// Raise raises an Alert. A typed alert is validated against its
+	// registered type and ErrUnknownAlertType is returned if the type is
+	// not registered.
 	Raise(alert *api.Alert) error
 
 	// Raise raises an Alert only if another alert with given resource type,
@@ -130,3 +132,13 @@ func Register(name string, initFunc InitFunc) error {
 	drivers[name] = initFunc
 	return nil
 }
+
+// Get returns the alert instance created with name.
+func Get(name string) (Alert, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+	if a, exists := instances[name]; exists {
+		return a, nil
+	}
+	return nil, ErrNotInitialized
+}
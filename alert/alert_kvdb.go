@@ -89,6 +89,9 @@ func Init(kv kvdb.Kvdb, clusterID string) (Alert, error) {
 
 // Raise raises an Alert.
 func (kva *KvAlert) Raise(a *api.Alert) error {
+	if err := applyType(a); err != nil {
+		return err
+	}
 	var subscriptions []api.Alert
 	kv := kva.GetKvdbInstance()
 	if _, err := kv.GetVal(getSubscriptionsKey(a.AlertType), &subscriptions); err != nil {
@@ -111,6 +114,9 @@ func (kva *KvAlert) RaiseIfNotExist(a *api.Alert) error {
 		strings.TrimSpace(a.UniqueTag) == "" {
 		return ErrIllegal
 	}
+	if err := applyType(a); err != nil {
+		return err
+	}
 	var subscriptions []api.Alert
 	kv := kva.GetKvdbInstance()
 	if _, err := kv.GetVal(getSubscriptionsKey(a.AlertType), &subscriptions); err != nil {
@@ -269,9 +275,40 @@ func (kva *KvAlert) raise(a *api.Alert) error {
 	a.Id = alertID
 	a.Timestamp = prototime.Now()
 	a.Cleared = false
-	_, err = kv.Create(getResourceKey(a.Resource)+strconv.FormatInt(a.Id, 10), a, a.Ttl)
-	return err
+	if _, err = kv.Create(getResourceKey(a.Resource)+strconv.FormatInt(a.Id, 10), a, a.Ttl); err != nil {
+		return err
+	}
+	return kva.supersede(a)
+}
 
+// supersede removes the older alerts of a registered type replaced by a.
+// They are cleared if the type auto clears and erased otherwise.
+func (kva *KvAlert) supersede(a *api.Alert) error {
+	t, err := GetType(a.AlertType)
+	if err != nil {
+		return nil
+	}
+	alerts, err := kva.getResourceSpecificAlerts(a.Resource, kva.GetKvdbInstance())
+	if err != nil {
+		return err
+	}
+	for _, alert := range alerts {
+		if alert.Id == a.Id || alert.Cleared ||
+			alert.AlertType != a.AlertType ||
+			alert.ResourceId != a.ResourceId ||
+			alert.UniqueTag != a.UniqueTag {
+			continue
+		}
+		if t.AutoClear {
+			err = kva.clear(a.Resource, alert.Id, alert.Ttl)
+		} else {
+			err = kva.Erase(a.Resource, alert.Id)
+		}
+		if err != nil && err != kvdb.ErrNotFound {
+			return err
+		}
+	}
+	return nil
 }
 
 func (kva *KvAlert) raiseIfNotExist(a *api.Alert) error {
@@ -40,6 +40,7 @@ func TestAll(t *testing.T) {
 	clearWithTTL(t)
 	enumerate(t)
 	watch(t)
+	types(t)
 }
 
 func setup(t *testing.T) {
@@ -187,6 +188,14 @@ func raiseWithTTL(t *testing.T) {
 
 func subscribe(t *testing.T) {
 	parentAlertType := int64(1)
+	for _, at := range []*api.AlertType{
+		{Type: 1, Name: "parent", Resource: api.ResourceType_RESOURCE_TYPE_NODE},
+		{Type: 2, Name: "child1", Resource: api.ResourceType_RESOURCE_TYPE_DRIVE},
+		{Type: 3, Name: "child2", Resource: api.ResourceType_RESOURCE_TYPE_VOLUME},
+		{Type: 4, Name: "child3", Resource: api.ResourceType_RESOURCE_TYPE_VOLUME},
+	} {
+		require.NoError(t, RegisterType(at), "Failed to register alert type")
+	}
 	child1Alert := api.Alert{
 		AlertType: 2,
 		Message:   "child 1",
@@ -448,3 +457,79 @@ func watch(t *testing.T) {
 
 	err = kva.Erase(api.ResourceType_RESOURCE_TYPE_NODE, raiseAlertNew.Id)
 }
+
+func types(t *testing.T) {
+	degraded := &api.AlertType{
+		Type:      10,
+		Name:      "VolumeDegraded",
+		Severity:  api.SeverityType_SEVERITY_TYPE_WARNING,
+		Resource:  api.ResourceType_RESOURCE_TYPE_VOLUME,
+		Message:   "Volume {{.ResourceId}} is degraded",
+		AutoClear: true,
+	}
+	require.NoError(t, RegisterType(degraded), "Failed to register alert type")
+	require.Equal(t, ErrExist, RegisterType(degraded), "Duplicate type registered")
+	require.Equal(t, ErrIllegal, RegisterType(&api.AlertType{Type: 11}), "Invalid type registered")
+
+	at, err := GetType(10)
+	require.NoError(t, err, "Failed to get alert type")
+	require.Equal(t, degraded, at, "Alert type mismatch")
+	enumerated := EnumerateTypes()
+	require.Equal(t, int64(10), enumerated[len(enumerated)-1].Type, "Alert types not ordered")
+
+	// Untyped alerts are raised unchanged, unregistered types are rejected.
+	untyped := &api.Alert{Resource: api.ResourceType_RESOURCE_TYPE_VOLUME, ResourceId: "vol1"}
+	require.NoError(t, kva.Raise(untyped), "Failed to raise untyped alert")
+	require.Equal(t, api.SeverityType_SEVERITY_TYPE_NONE, untyped.Severity, "Severity mismatch")
+	require.NoError(t, kva.Erase(untyped.Resource, untyped.Id), "Failed to erase alert")
+	adhoc := &api.Alert{AlertType: 99, Resource: api.ResourceType_RESOURCE_TYPE_VOLUME, ResourceId: "vol1"}
+	require.Equal(t, ErrUnknownAlertType, kva.Raise(adhoc), "Unregistered type raised")
+	require.Equal(t, ErrUnknownAlertType, kva.RaiseIfNotExist(&api.Alert{
+		AlertType: 99, ResourceId: "vol1", UniqueTag: "adhoc"}), "Unregistered type raised")
+
+	// Resource mismatches are rejected.
+	err = kva.Raise(&api.Alert{AlertType: 10, Resource: api.ResourceType_RESOURCE_TYPE_NODE})
+	require.Equal(t, ErrAlertTypeMismatch, err, "Error mismatch")
+
+	// Defaults come from the type.
+	first := &api.Alert{AlertType: 10, ResourceId: "vol1", UniqueTag: "replica"}
+	require.NoError(t, kva.Raise(first), "Failed to raise typed alert")
+	alert, err := kva.Retrieve(api.ResourceType_RESOURCE_TYPE_VOLUME, first.Id)
+	require.NoError(t, err, "Failed to retrieve alert")
+	require.Equal(t, api.SeverityType_SEVERITY_TYPE_WARNING, alert.Severity, "Severity mismatch")
+	require.Equal(t, "Volume vol1 is degraded", alert.Message, "Message mismatch")
+
+	// A newer alert for the same resource and tag clears the older one.
+	other := &api.Alert{AlertType: 10, ResourceId: "vol2", UniqueTag: "replica"}
+	require.NoError(t, kva.Raise(other), "Failed to raise typed alert")
+	second := &api.Alert{AlertType: 10, ResourceId: "vol1", UniqueTag: "replica"}
+	require.NoError(t, kva.Raise(second), "Failed to raise typed alert")
+
+	alert, err = kva.Retrieve(api.ResourceType_RESOURCE_TYPE_VOLUME, first.Id)
+	require.NoError(t, err, "Failed to retrieve alert")
+	require.True(t, alert.Cleared, "Superseded alert not cleared")
+	alert, err = kva.Retrieve(api.ResourceType_RESOURCE_TYPE_VOLUME, other.Id)
+	require.NoError(t, err, "Failed to retrieve alert")
+	require.False(t, alert.Cleared, "Alert for another resource cleared")
+	alert, err = kva.Retrieve(api.ResourceType_RESOURCE_TYPE_VOLUME, second.Id)
+	require.NoError(t, err, "Failed to retrieve alert")
+	require.False(t, alert.Cleared, "Newest alert cleared")
+
+	for _, a := range []*api.Alert{first, other, second} {
+		require.NoError(t, kva.Erase(a.Resource, a.Id), "Failed to erase alert")
+	}
+
+	// Types that do not auto clear erase the alerts they supersede.
+	require.NoError(t, RegisterType(&api.AlertType{
+		Type:     12,
+		Name:     "NodeDown",
+		Resource: api.ResourceType_RESOURCE_TYPE_NODE,
+	}), "Failed to register alert type")
+	first = &api.Alert{AlertType: 12, ResourceId: "node1"}
+	require.NoError(t, kva.Raise(first), "Failed to raise typed alert")
+	second = &api.Alert{AlertType: 12, ResourceId: "node1"}
+	require.NoError(t, kva.Raise(second), "Failed to raise typed alert")
+	_, err = kva.Retrieve(api.ResourceType_RESOURCE_TYPE_NODE, first.Id)
+	require.Error(t, err, "Superseded alert not erased")
+	require.NoError(t, kva.Erase(second.Resource, second.Id), "Failed to erase alert")
+}
@@ -0,0 +1,111 @@
+package alert
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"sync"
+	"text/template"
+
+	"github.com/libopenstorage/openstorage/api"
+)
+
+var (
+	// ErrUnknownAlertType raised if an alert type is not registered.
+	ErrUnknownAlertType = errors.New("Alert type not registered")
+	// ErrAlertTypeMismatch raised if an alert does not match its alert type.
+	ErrAlertTypeMismatch = errors.New("Alert does not match its alert type")
+
+	alertTypes     = make(map[int64]*alertType)
+	alertTypesLock sync.RWMutex
+)
+
+type alertType struct {
+	api.AlertType
+	message *template.Template
+}
+
+// RegisterType adds an alert type to the catalogue. Types are identified
+// by a positive number and a unique name.
+func RegisterType(t *api.AlertType) error {
+	if t.Type <= 0 || t.Name == "" ||
+		t.Resource == api.ResourceType_RESOURCE_TYPE_NONE {
+		return ErrIllegal
+	}
+	message, err := template.New(t.Name).Parse(t.Message)
+	if err != nil {
+		return err
+	}
+
+	alertTypesLock.Lock()
+	defer alertTypesLock.Unlock()
+	for _, at := range alertTypes {
+		if at.Type == t.Type || at.Name == t.Name {
+			return ErrExist
+		}
+	}
+	alertTypes[t.Type] = &alertType{AlertType: *t, message: message}
+	return nil
+}
+
+// GetType returns the registered alert type.
+func GetType(alertType int64) (*api.AlertType, error) {
+	alertTypesLock.RLock()
+	defer alertTypesLock.RUnlock()
+	at, ok := alertTypes[alertType]
+	if !ok {
+		return nil, ErrUnknownAlertType
+	}
+	t := at.AlertType
+	return &t, nil
+}
+
+// EnumerateTypes returns the registered alert types ordered by type.
+func EnumerateTypes() []*api.AlertType {
+	alertTypesLock.RLock()
+	defer alertTypesLock.RUnlock()
+	types := make([]*api.AlertType, 0, len(alertTypes))
+	for _, at := range alertTypes {
+		t := at.AlertType
+		types = append(types, &t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].Type < types[j].Type
+	})
+	return types
+}
+
+// applyType validates a against its registered type and fills in the
+// defaults of the type. Untyped alerts, whose AlertType is 0, are raised
+// unchanged; alerts of any other unregistered type are rejected.
+func applyType(a *api.Alert) error {
+	if a.AlertType == 0 {
+		return nil
+	}
+	alertTypesLock.RLock()
+	at, ok := alertTypes[a.AlertType]
+	alertTypesLock.RUnlock()
+	if !ok {
+		return ErrUnknownAlertType
+	}
+
+	if a.Resource == api.ResourceType_RESOURCE_TYPE_NONE {
+		a.Resource = at.Resource
+	} else if a.Resource != at.Resource {
+		return ErrAlertTypeMismatch
+	}
+	if a.Severity == api.SeverityType_SEVERITY_TYPE_NONE {
+		a.Severity = at.Severity
+	}
+	if a.Ttl == 0 {
+		a.Ttl = at.Ttl
+	}
+	if a.Message == "" {
+		var b bytes.Buffer
+		if err := at.message.Execute(&b, a); err != nil {
+			return err
+		}
+		a.Message = b.String()
+	}
+	return nil
+}
@@ -0,0 +1,434 @@
+// Package notifier delivers alerts raised through the alert package to
+// external sinks such as webhooks and syslog servers.
+package notifier
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/portworx/kvdb"
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/alert"
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/osdconfig"
+)
+
+const (
+	// ActionCreate is sent when an alert is raised.
+	ActionCreate = "create"
+	// ActionUpdate is sent when an existing alert is modified.
+	ActionUpdate = "update"
+	// ActionClear is sent when an alert is cleared.
+	ActionClear = "clear"
+	// ActionDelete is sent when an alert is erased or expires.
+	ActionDelete = "delete"
+
+	// claimKey is where nodes record which events they have delivered.
+	// It lives outside the alert tree so claims do not trigger the watch.
+	claimKey = "alert_notify/"
+	// claimTTL is how long, in seconds, a delivered event is remembered.
+	claimTTL = 3600
+	// queueSize is the number of events buffered between the watch and
+	// the delivery worker.
+	queueSize = 1024
+)
+
+// Event is an alert change delivered to a sink.
+type Event struct {
+	ClusterID  string    `json:"cluster_id"`
+	Action     string    `json:"action"`
+	Id         int64     `json:"id"`
+	Resource   string    `json:"resource"`
+	ResourceId string    `json:"resource_id,omitempty"`
+	Severity   string    `json:"severity,omitempty"`
+	AlertType  int64     `json:"alert_type,omitempty"`
+	Message    string    `json:"message,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+	Cleared    bool      `json:"cleared,omitempty"`
+
+	resource api.ResourceType
+	severity api.SeverityType
+}
+
+// Sink delivers events to an external system.
+type Sink interface {
+	fmt.Stringer
+
+	// Send delivers the event, returning once it has been accepted or
+	// delivery has been abandoned.
+	Send(e *Event) error
+}
+
+// Filter selects the events delivered to a sink.
+type Filter struct {
+	// Severity is the least severe level delivered. SEVERITY_TYPE_NONE
+	// delivers every severity.
+	Severity api.SeverityType
+	// Resources lists the resource types delivered. An empty list
+	// delivers every resource type.
+	Resources []api.ResourceType
+}
+
+// Match returns true if the event passes the filter. Deleted alerts carry
+// no severity and are filtered on their resource type only.
+func (f *Filter) Match(e *Event) bool {
+	if f.Severity != api.SeverityType_SEVERITY_TYPE_NONE &&
+		e.severity != api.SeverityType_SEVERITY_TYPE_NONE &&
+		e.severity > f.Severity {
+		return false
+	}
+	if len(f.Resources) == 0 {
+		return true
+	}
+	for _, r := range f.Resources {
+		if r == e.resource {
+			return true
+		}
+	}
+	return false
+}
+
+type filteredSink struct {
+	sink   Sink
+	filter Filter
+}
+
+// Notifier watches the alerts of a cluster and delivers every change to the
+// configured sinks. Each node in the cluster may run a notifier; an event is
+// delivered by the first node to claim it in kvdb.
+type Notifier struct {
+	alerts    alert.Alert
+	kv        kvdb.Kvdb
+	clusterID string
+	nodeID    string
+
+	lock  sync.Mutex
+	sinks []*filteredSink
+
+	events  chan *Event
+	stop    chan struct{}
+	running sync.WaitGroup
+}
+
+// New returns a notifier for the alerts of clusterID. kv is used to
+// coordinate delivery between the nodes identified by nodeID.
+func New(alerts alert.Alert, kv kvdb.Kvdb, clusterID, nodeID string) *Notifier {
+	return &Notifier{
+		alerts:    alerts,
+		kv:        kv,
+		clusterID: clusterID,
+		nodeID:    nodeID,
+		events:    make(chan *Event, queueSize),
+		stop:      make(chan struct{}),
+	}
+}
+
+// AddSink delivers events matching filter to sink.
+func (n *Notifier) AddSink(sink Sink, filter Filter) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.sinks = append(n.sinks, &filteredSink{sink: sink, filter: filter})
+}
+
+// Configure replaces the sinks with those described by the cluster
+// configuration. The alerting URL is treated as an unfiltered webhook.
+func (n *Notifier) Configure(config *osdconfig.ClusterConfig) error {
+	sinks, err := sinksFromConfig(config)
+	if err != nil {
+		return err
+	}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.sinks = sinks
+	return nil
+}
+
+// WatchConfig configures the sinks from the current cluster configuration
+// and reconfigures them whenever it changes. No alerts are delivered until
+// the cluster configuration is set.
+func (n *Notifier) WatchConfig(manager osdconfig.ConfigManager) error {
+	config, err := manager.GetClusterConf()
+	if err == nil {
+		if err := n.Configure(config); err != nil {
+			dlog.Warnf("Invalid alerting configuration, alerts will not "+
+				"be delivered: %v", err)
+		}
+	} else if err != kvdb.ErrNotFound {
+		return err
+	}
+	return manager.WatchCluster("alert-notifier", n.Configure)
+}
+
+// Start returns a running notifier for the alerts of clusterID, delivering
+// them to the sinks of the cluster configuration in manager.
+func Start(
+	alerts alert.Alert,
+	kv kvdb.Kvdb,
+	manager osdconfig.ConfigManager,
+	clusterID string,
+	nodeID string,
+) (*Notifier, error) {
+	n := New(alerts, kv, clusterID, nodeID)
+	if err := n.WatchConfig(manager); err != nil {
+		return nil, err
+	}
+	if err := n.Start(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// Start watches the alerts and begins delivering them.
+func (n *Notifier) Start() error {
+	if err := n.alerts.Watch(n.clusterID, n.watch); err != nil {
+		return err
+	}
+	n.running.Add(1)
+	go n.run()
+	return nil
+}
+
+// Stop stops delivering alerts and waits for deliveries in progress.
+func (n *Notifier) Stop() {
+	close(n.stop)
+	n.running.Wait()
+}
+
+// watch is called by the alert watch with the alert lock held, so it only
+// queues the event for the delivery worker.
+func (n *Notifier) watch(
+	a *api.Alert,
+	action api.AlertActionType,
+	prefix string,
+	key string,
+) error {
+	if action == api.AlertActionType_ALERT_ACTION_TYPE_NONE {
+		dlog.Warnf("Alert watch for cluster %v stopped, alerts will "+
+			"no longer be delivered", n.clusterID)
+		return nil
+	}
+	e, err := newEvent(n.clusterID, a, action, key)
+	if err != nil {
+		dlog.Warnf("Ignoring alert change for %v: %v", key, err)
+		return nil
+	}
+	select {
+	case n.events <- e:
+	case <-n.stop:
+	}
+	return nil
+}
+
+func (n *Notifier) run() {
+	defer n.running.Done()
+	for {
+		select {
+		case e := <-n.events:
+			n.deliver(e)
+		case <-n.stop:
+			return
+		}
+	}
+}
+
+// deliver sends e to every matching sink if this node claims it.
+func (n *Notifier) deliver(e *Event) {
+	n.lock.Lock()
+	var sinks []*filteredSink
+	for _, s := range n.sinks {
+		if s.filter.Match(e) {
+			sinks = append(sinks, s)
+		}
+	}
+	n.lock.Unlock()
+	if len(sinks) == 0 || !n.claim(e) {
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, s := range sinks {
+		wg.Add(1)
+		go func(s Sink) {
+			defer wg.Done()
+			if err := s.Send(e); err != nil {
+				dlog.Errorf("Failed to deliver alert %v (%v) to %v: %v",
+					e.Id, e.Action, s, err)
+			}
+		}(s.sink)
+	}
+	wg.Wait()
+}
+
+// claim returns true if this node should deliver e. Every node watching the
+// cluster sees the same kvdb value for an event, so the claim is keyed by a
+// hash of its contents and only the first node to create it delivers.
+func (n *Notifier) claim(e *Event) bool {
+	id := *e
+	if id.Action == ActionDelete {
+		// Deleted alerts are stamped when seen, which differs between nodes.
+		id.Timestamp = time.Time{}
+	}
+	b, err := json.Marshal(&id)
+	if err != nil {
+		dlog.Errorf("Failed to encode alert %v: %v", e.Id, err)
+		return false
+	}
+	sum := sha256.Sum256(b)
+	key := claimKey + n.clusterID + "/" + hex.EncodeToString(sum[:])
+	if _, err := n.kv.Create(key, n.nodeID, claimTTL); err != nil {
+		if err != kvdb.ErrExist {
+			dlog.Errorf("Failed to claim alert %v for delivery: %v", e.Id, err)
+		}
+		return false
+	}
+	return true
+}
+
+func newEvent(
+	clusterID string,
+	a *api.Alert,
+	action api.AlertActionType,
+	key string,
+) (*Event, error) {
+	e := &Event{ClusterID: clusterID}
+	switch action {
+	case api.AlertActionType_ALERT_ACTION_TYPE_CREATE:
+		e.Action = ActionCreate
+	case api.AlertActionType_ALERT_ACTION_TYPE_UPDATE:
+		e.Action = ActionUpdate
+		if a.Cleared {
+			e.Action = ActionClear
+		}
+	case api.AlertActionType_ALERT_ACTION_TYPE_DELETE:
+		// Erased alerts are only known by their key, <resource>/<id>.
+		id, err := strconv.ParseInt(path.Base(key), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid alert key")
+		}
+		resource, ok := resourceTypes[path.Base(path.Dir(key))]
+		if !ok {
+			return nil, fmt.Errorf("invalid alert key")
+		}
+		e.Action = ActionDelete
+		e.Id = id
+		e.resource = resource
+		e.Resource = resourceName(resource)
+		e.Timestamp = time.Now().UTC()
+		return e, nil
+	default:
+		return nil, fmt.Errorf("unknown action %v", action)
+	}
+
+	e.Id = a.Id
+	e.resource = a.Resource
+	e.Resource = resourceName(a.Resource)
+	e.ResourceId = a.ResourceId
+	e.severity = a.Severity
+	e.Severity = severityName(a.Severity)
+	e.AlertType = a.AlertType
+	e.Message = a.Message
+	e.Cleared = a.Cleared
+	if a.Timestamp != nil {
+		e.Timestamp = time.Unix(a.Timestamp.Seconds, int64(a.Timestamp.Nanos)).UTC()
+	}
+	return e, nil
+}
+
+var (
+	severityTypes = map[string]api.SeverityType{
+		"alarm":   api.SeverityType_SEVERITY_TYPE_ALARM,
+		"warning": api.SeverityType_SEVERITY_TYPE_WARNING,
+		"notify":  api.SeverityType_SEVERITY_TYPE_NOTIFY,
+	}
+	resourceTypes = map[string]api.ResourceType{
+		"volume":  api.ResourceType_RESOURCE_TYPE_VOLUME,
+		"node":    api.ResourceType_RESOURCE_TYPE_NODE,
+		"cluster": api.ResourceType_RESOURCE_TYPE_CLUSTER,
+		"drive":   api.ResourceType_RESOURCE_TYPE_DRIVE,
+	}
+)
+
+func severityName(s api.SeverityType) string {
+	for name, t := range severityTypes {
+		if t == s {
+			return name
+		}
+	}
+	return ""
+}
+
+func resourceName(r api.ResourceType) string {
+	for name, t := range resourceTypes {
+		if t == r {
+			return name
+		}
+	}
+	return ""
+}
+
+func filterFromConfig(config *osdconfig.AlertFilterConfig) (Filter, error) {
+	var f Filter
+	if config == nil {
+		return f, nil
+	}
+	if config.Severity != "" {
+		s, ok := severityTypes[strings.ToLower(config.Severity)]
+		if !ok {
+			return f, fmt.Errorf("Unknown alert severity %q", config.Severity)
+		}
+		f.Severity = s
+	}
+	for _, name := range config.Resources {
+		r, ok := resourceTypes[strings.ToLower(name)]
+		if !ok {
+			return f, fmt.Errorf("Unknown alert resource type %q", name)
+		}
+		f.Resources = append(f.Resources, r)
+	}
+	return f, nil
+}
+
+func sinksFromConfig(config *osdconfig.ClusterConfig) ([]*filteredSink, error) {
+	var sinks []*filteredSink
+	if config == nil {
+		return sinks, nil
+	}
+	if config.AlertingUrl != "" {
+		sinks = append(sinks, &filteredSink{
+			sink: NewWebhook(&osdconfig.WebhookConfig{Url: config.AlertingUrl}),
+		})
+	}
+	if config.Alerting == nil {
+		return sinks, nil
+	}
+	for _, c := range config.Alerting.Webhooks {
+		if c.Url == "" {
+			return nil, fmt.Errorf("Webhook url must be provided")
+		}
+		f, err := filterFromConfig(c.Filter)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, &filteredSink{sink: NewWebhook(c), filter: f})
+	}
+	for _, c := range config.Alerting.Syslog {
+		f, err := filterFromConfig(c.Filter)
+		if err != nil {
+			return nil, err
+		}
+		s, err := NewSyslog(c)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, &filteredSink{sink: s, filter: f})
+	}
+	return sinks, nil
+}
@@ -0,0 +1,310 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/portworx/kvdb"
+	"github.com/portworx/kvdb/mem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/alert"
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/osdconfig"
+)
+
+const testClusterID = "notify"
+
+type recordingSink struct {
+	lock   sync.Mutex
+	events []*Event
+}
+
+func (s *recordingSink) String() string {
+	return "recording"
+}
+
+func (s *recordingSink) Send(e *Event) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.events = append(s.events, e)
+	return nil
+}
+
+func (s *recordingSink) actions() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var actions []string
+	for _, e := range s.events {
+		actions = append(actions, e.Action)
+	}
+	return actions
+}
+
+func newKv(t *testing.T) kvdb.Kvdb {
+	kv, err := kvdb.New(mem.Name, "openstorage/"+testClusterID, nil, nil, dlog.Panicf)
+	require.NoError(t, err)
+	return kv
+}
+
+func TestWatchDelivery(t *testing.T) {
+	kv := newKv(t)
+	alerts, err := alert.New(alert.Name, testClusterID, kv)
+	require.NoError(t, err)
+
+	n := New(alerts, kv, testClusterID, "node1")
+	sink := &recordingSink{}
+	n.AddSink(sink, Filter{})
+	require.NoError(t, n.Start())
+	defer n.Stop()
+
+	a := &api.Alert{
+		Resource:   api.ResourceType_RESOURCE_TYPE_VOLUME,
+		ResourceId: "vol1",
+		Severity:   api.SeverityType_SEVERITY_TYPE_ALARM,
+		Message:    "Volume is down",
+	}
+	// The mem kvdb fires watches concurrently, so wait for each change.
+	waitFor := func(expected ...string) {
+		for i := 0; i < 50 && len(sink.actions()) < len(expected); i++ {
+			time.Sleep(100 * time.Millisecond)
+		}
+		require.Equal(t, expected, sink.actions())
+	}
+	require.NoError(t, alerts.Raise(a))
+	waitFor(ActionCreate)
+	require.NoError(t, alerts.Clear(a.Resource, a.Id, 0))
+	waitFor(ActionCreate, ActionClear)
+	require.NoError(t, alerts.Erase(a.Resource, a.Id))
+	waitFor(ActionCreate, ActionClear, ActionDelete)
+	e := sink.events[0]
+	assert.Equal(t, a.Id, e.Id)
+	assert.Equal(t, "volume", e.Resource)
+	assert.Equal(t, "vol1", e.ResourceId)
+	assert.Equal(t, "alarm", e.Severity)
+	assert.Equal(t, testClusterID, e.ClusterID)
+	assert.Equal(t, a.Id, sink.events[2].Id)
+	assert.Equal(t, "volume", sink.events[2].Resource)
+}
+
+func TestSingleDelivery(t *testing.T) {
+	kv := newKv(t)
+	sink1, sink2 := &recordingSink{}, &recordingSink{}
+	n1 := New(nil, kv, testClusterID, "node1")
+	n1.AddSink(sink1, Filter{})
+	n2 := New(nil, kv, testClusterID, "node2")
+	n2.AddSink(sink2, Filter{})
+
+	a := &api.Alert{Id: 7, Resource: api.ResourceType_RESOURCE_TYPE_NODE}
+	e1, err := newEvent(testClusterID, a, api.AlertActionType_ALERT_ACTION_TYPE_CREATE, "")
+	require.NoError(t, err)
+	e2, err := newEvent(testClusterID, a, api.AlertActionType_ALERT_ACTION_TYPE_CREATE, "")
+	require.NoError(t, err)
+	n1.deliver(e1)
+	n2.deliver(e2)
+	assert.Len(t, sink1.events, 1)
+	assert.Len(t, sink2.events, 0)
+
+	// Deletes are stamped when seen but are still delivered once.
+	d1, err := newEvent(testClusterID, nil, api.AlertActionType_ALERT_ACTION_TYPE_DELETE, "alert/node/7")
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	d2, err := newEvent(testClusterID, nil, api.AlertActionType_ALERT_ACTION_TYPE_DELETE, "alert/node/7")
+	require.NoError(t, err)
+	n2.deliver(d1)
+	n1.deliver(d2)
+	assert.Len(t, sink1.events, 1)
+	assert.Len(t, sink2.events, 1)
+}
+
+func TestFilter(t *testing.T) {
+	warning := &Event{
+		resource: api.ResourceType_RESOURCE_TYPE_VOLUME,
+		severity: api.SeverityType_SEVERITY_TYPE_WARNING,
+	}
+	deleted := &Event{resource: api.ResourceType_RESOURCE_TYPE_NODE}
+
+	f := Filter{}
+	assert.True(t, f.Match(warning))
+
+	f = Filter{Severity: api.SeverityType_SEVERITY_TYPE_ALARM}
+	assert.False(t, f.Match(warning))
+	assert.True(t, f.Match(deleted))
+
+	f = Filter{Severity: api.SeverityType_SEVERITY_TYPE_NOTIFY}
+	assert.True(t, f.Match(warning))
+
+	f = Filter{Resources: []api.ResourceType{api.ResourceType_RESOURCE_TYPE_NODE}}
+	assert.False(t, f.Match(warning))
+	assert.True(t, f.Match(deleted))
+}
+
+func TestConfigure(t *testing.T) {
+	n := New(nil, nil, testClusterID, "node1")
+	require.NoError(t, n.Configure(&osdconfig.ClusterConfig{
+		AlertingUrl: "http://localhost/alerts",
+		Alerting: &osdconfig.AlertingConfig{
+			Webhooks: []*osdconfig.WebhookConfig{{
+				Url: "http://localhost/volumes",
+				Filter: &osdconfig.AlertFilterConfig{
+					Severity:  "Warning",
+					Resources: []string{"volume"},
+				},
+			}},
+			Syslog: []*osdconfig.SyslogConfig{{Address: "localhost:514"}},
+		},
+	}))
+	require.Len(t, n.sinks, 3)
+	assert.Equal(t, "webhook http://localhost/alerts", n.sinks[0].sink.String())
+	assert.Equal(t, Filter{
+		Severity:  api.SeverityType_SEVERITY_TYPE_WARNING,
+		Resources: []api.ResourceType{api.ResourceType_RESOURCE_TYPE_VOLUME},
+	}, n.sinks[1].filter)
+	assert.Equal(t, "syslog udp://localhost:514", n.sinks[2].sink.String())
+
+	assert.Error(t, n.Configure(&osdconfig.ClusterConfig{
+		Alerting: &osdconfig.AlertingConfig{
+			Webhooks: []*osdconfig.WebhookConfig{{
+				Url:    "http://localhost",
+				Filter: &osdconfig.AlertFilterConfig{Severity: "urgent"},
+			}},
+		},
+	}))
+	assert.Len(t, n.sinks, 3)
+
+	require.NoError(t, n.Configure(&osdconfig.ClusterConfig{}))
+	assert.Len(t, n.sinks, 0)
+}
+
+func TestStart(t *testing.T) {
+	// Alert watches are per cluster, so use a cluster of its own.
+	clusterID := testClusterID + "-start"
+	kv, err := kvdb.New(mem.Name, "openstorage/"+clusterID, nil, nil, dlog.Panicf)
+	require.NoError(t, err)
+	alerts, err := alert.New(alert.Name, clusterID, kv)
+	require.NoError(t, err)
+	manager, err := osdconfig.NewManager(context.Background(), kv)
+	require.NoError(t, err)
+	defer manager.Close()
+
+	received := make(chan *Event, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e Event
+		if err := json.NewDecoder(r.Body).Decode(&e); err == nil {
+			received <- &e
+		}
+	}))
+	defer server.Close()
+
+	// No cluster configuration is set yet.
+	n, err := Start(alerts, kv, manager, clusterID, "node1")
+	require.NoError(t, err)
+	defer n.Stop()
+
+	require.NoError(t, manager.SetClusterConf(&osdconfig.ClusterConfig{
+		ClusterId:   clusterID,
+		AlertingUrl: server.URL,
+	}))
+	for i := 0; i < 50; i++ {
+		n.lock.Lock()
+		configured := len(n.sinks) == 1
+		n.lock.Unlock()
+		if configured {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	a := &api.Alert{
+		Resource:   api.ResourceType_RESOURCE_TYPE_VOLUME,
+		ResourceId: "vol1",
+		Severity:   api.SeverityType_SEVERITY_TYPE_ALARM,
+	}
+	require.NoError(t, alerts.Raise(a))
+	select {
+	case e := <-received:
+		assert.Equal(t, a.Id, e.Id)
+		assert.Equal(t, ActionCreate, e.Action)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Alert was not delivered to the alerting URL")
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	var (
+		lock     sync.Mutex
+		requests int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		requests++
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.Header.Get(SignatureHeader) != "sha256="+Sign([]byte("secret"), body) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var e Event
+		if err := json.Unmarshal(body, &e); err != nil || e.Id != 3 {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	sink := NewWebhook(&osdconfig.WebhookConfig{Url: server.URL, Secret: "secret"})
+	sink.(*webhook).backoff = time.Millisecond
+	require.NoError(t, sink.Send(&Event{Id: 3, Action: ActionCreate}))
+	assert.Equal(t, 2, requests)
+
+	// Client errors are not retried.
+	requests = 0
+	sink = NewWebhook(&osdconfig.WebhookConfig{Url: server.URL, Secret: "wrong"})
+	assert.Error(t, sink.Send(&Event{Id: 3}))
+	assert.Equal(t, 1, requests)
+}
+
+func TestSyslog(t *testing.T) {
+	e := &Event{
+		ClusterID:  "c1",
+		Action:     ActionCreate,
+		Id:         5,
+		Resource:   "volume",
+		ResourceId: `vol"1]`,
+		Severity:   "warning",
+		Message:    "Volume is degraded",
+		Timestamp:  time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	msg := formatRFC5424(e, "host", "osd")
+	assert.True(t, strings.HasPrefix(msg, "<28>1 2018-01-02T03:04:05Z host osd "), msg)
+	assert.True(t, strings.HasSuffix(msg, ` create [alert@32473 id="5" cluster="c1" `+
+		`resource="volume" resourceId="vol\"1\]" severity="warning"] Volume is degraded`), msg)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+	sink, err := NewSyslog(&osdconfig.SyslogConfig{Address: conn.LocalAddr().String()})
+	require.NoError(t, err)
+	require.NoError(t, sink.Send(e))
+	buf := make([]byte, 1024)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	l, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Contains(t, string(buf[:l]), "Volume is degraded")
+
+	_, err = NewSyslog(&osdconfig.SyslogConfig{Network: "unix", Address: "/dev/log"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,158 @@
+package notifier
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/libopenstorage/openstorage/osdconfig"
+)
+
+const (
+	defaultAppName = "osd"
+	// facilityDaemon is the syslog facility alerts are logged under.
+	facilityDaemon = 3
+	// sdID is the RFC 5424 structured data id of the alert fields. 32473 is
+	// the private enterprise number reserved for documentation.
+	sdID        = "alert@32473"
+	dialTimeout = 10 * time.Second
+)
+
+type syslog struct {
+	network  string
+	address  string
+	appName  string
+	hostname string
+
+	lock sync.Mutex
+	conn net.Conn
+}
+
+// NewSyslog returns a sink that sends events as RFC 5424 messages to a
+// syslog server over udp (the default) or tcp.
+func NewSyslog(config *osdconfig.SyslogConfig) (Sink, error) {
+	network := config.Network
+	if network == "" {
+		network = "udp"
+	}
+	if network != "udp" && network != "tcp" {
+		return nil, fmt.Errorf("Unsupported syslog network %q", network)
+	}
+	if config.Address == "" {
+		return nil, fmt.Errorf("Syslog address must be provided")
+	}
+	appName := config.AppName
+	if appName == "" {
+		appName = defaultAppName
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "-"
+	}
+	return &syslog{
+		network:  network,
+		address:  config.Address,
+		appName:  appName,
+		hostname: hostname,
+	}, nil
+}
+
+func (s *syslog) String() string {
+	return "syslog " + s.network + "://" + s.address
+}
+
+func (s *syslog) Send(e *Event) error {
+	msg := formatRFC5424(e, s.hostname, s.appName)
+	if s.network == "tcp" {
+		// Octet counting framing, RFC 6587.
+		msg = strconv.Itoa(len(msg)) + " " + msg
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	// Reconnect once if the server dropped a previous connection.
+	for attempt := 0; ; attempt++ {
+		if s.conn == nil {
+			conn, err := net.DialTimeout(s.network, s.address, dialTimeout)
+			if err != nil {
+				return err
+			}
+			s.conn = conn
+		}
+		_, err := s.conn.Write([]byte(msg))
+		if err == nil {
+			return nil
+		}
+		s.conn.Close()
+		s.conn = nil
+		if attempt == 1 {
+			return err
+		}
+	}
+}
+
+// formatRFC5424 formats e as an RFC 5424 syslog message. The message id is
+// the event action and the alert fields are carried as structured data.
+func formatRFC5424(e *Event, hostname, appName string) string {
+	timestamp := "-"
+	if !e.Timestamp.IsZero() {
+		timestamp = e.Timestamp.UTC().Format(time.RFC3339Nano)
+	}
+	params := []string{
+		sdParam("id", strconv.FormatInt(e.Id, 10)),
+		sdParam("cluster", e.ClusterID),
+		sdParam("resource", e.Resource),
+	}
+	if e.ResourceId != "" {
+		params = append(params, sdParam("resourceId", e.ResourceId))
+	}
+	if e.AlertType != 0 {
+		params = append(params, sdParam("alertType", strconv.FormatInt(e.AlertType, 10)))
+	}
+	if e.Severity != "" {
+		params = append(params, sdParam("severity", e.Severity))
+	}
+	msg := e.Message
+	if msg == "" {
+		msg = "Alert " + e.Action
+	}
+	return fmt.Sprintf("<%d>1 %s %s %s %d %s [%s %s] %s",
+		facilityDaemon*8+syslogSeverity(e),
+		timestamp,
+		hostname,
+		appName,
+		os.Getpid(),
+		e.Action,
+		sdID,
+		strings.Join(params, " "),
+		msg,
+	)
+}
+
+// syslogSeverity maps the alert severity to a syslog severity level.
+func syslogSeverity(e *Event) int {
+	if e.Action == ActionClear || e.Action == ActionDelete {
+		return 6 // informational
+	}
+	switch e.Severity {
+	case "alarm":
+		return 3 // error
+	case "warning":
+		return 4 // warning
+	case "notify":
+		return 5 // notice
+	default:
+		return 6
+	}
+}
+
+// sdParam formats an RFC 5424 structured data parameter, escaping '"', '\'
+// and ']' in the value.
+func sdParam(name, value string) string {
+	value = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+	return name + `="` + value + `"`
+}
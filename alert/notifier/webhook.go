@@ -0,0 +1,108 @@
+package notifier
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/libopenstorage/openstorage/osdconfig"
+)
+
+const (
+	// SignatureHeader carries the HMAC-SHA256 of the request body, keyed by
+	// the webhook secret, as "sha256=<hex>".
+	SignatureHeader = "X-Openstorage-Signature"
+
+	defaultMaxRetries = 3
+	defaultBackoff    = time.Second
+	webhookTimeout    = 10 * time.Second
+)
+
+type webhook struct {
+	url        string
+	secret     []byte
+	maxRetries int
+	backoff    time.Duration
+	client     *http.Client
+}
+
+// NewWebhook returns a sink that POSTs events as JSON to the configured URL.
+// Failed deliveries are retried with exponential backoff.
+func NewWebhook(config *osdconfig.WebhookConfig) Sink {
+	maxRetries := int(config.MaxRetries)
+	if maxRetries <= 0 {
+		maxRetries = defaultMaxRetries
+	}
+	return &webhook{
+		url:        config.Url,
+		secret:     []byte(config.Secret),
+		maxRetries: maxRetries,
+		backoff:    defaultBackoff,
+		client:     &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+func (w *webhook) String() string {
+	return "webhook " + w.url
+}
+
+func (w *webhook) Send(e *Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	backoff := w.backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := w.post(body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt == w.maxRetries {
+			return err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// post sends one request and reports whether a failure may be retried.
+func (w *webhook) post(body []byte) (bool, error) {
+	req, err := http.NewRequest("POST", w.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(w.secret) > 0 {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(w.secret, body))
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("webhook returned %v", resp.Status)
+	default:
+		return false, fmt.Errorf("webhook returned %v", resp.Status)
+	}
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of body keyed by secret, as sent
+// in the SignatureHeader of webhook requests.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
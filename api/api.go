@@ -99,6 +99,18 @@ const (
 	OptBkupOpState = "OpState"
 	// OptBackupSchedUUID is the UUID of the backup-schedule
 	OptBackupSchedUUID = "BkupSchedUUID"
+	// OptNamePrefix query parameter used to lookup volumes by name prefix.
+	OptNamePrefix = "NamePrefix"
+	// OptState query parameter used to lookup volumes by state.
+	OptState = "State"
+	// OptNode query parameter used to lookup volumes by node.
+	OptNode = "Node"
+	// OptDriver query parameter used to lookup volumes by driver.
+	OptDriver = "Driver"
+	// OptPageSize query parameter used to limit the volumes returned.
+	OptPageSize = "PageSize"
+	// OptPageToken query parameter used to continue an enumeration.
+	OptPageToken = "PageToken"
 )
 
 // Api clientserver Constants
@@ -302,6 +314,87 @@ type BackupEnumerateResponse struct {
 	EnumerateErr string
 }
 
+// VolumeFilter selects the volumes returned by a paged enumeration.
+// Empty fields match all volumes.
+type VolumeFilter struct {
+	// NamePrefix matches volumes whose name starts with the prefix.
+	NamePrefix string
+	// Labels matches volumes with all of the locator labels. An empty
+	// label value matches any value.
+	Labels map[string]string
+	// State matches volumes in the state. VOLUME_STATE_NONE matches all.
+	State VolumeState
+	// Node matches volumes attached on or replicated to the node.
+	Node string
+	// Driver matches volumes of the driver.
+	Driver string
+}
+
+// Match returns true if v is selected by the filter. The driver is not
+// part of a volume and must be checked by the caller.
+func (f *VolumeFilter) Match(v *Volume) bool {
+	if f == nil {
+		return true
+	}
+	if f.NamePrefix != "" && !strings.HasPrefix(v.GetLocator().GetName(), f.NamePrefix) {
+		return false
+	}
+	labels := v.GetLocator().GetVolumeLabels()
+	for k, val := range f.Labels {
+		if l, ok := labels[k]; !ok || (val != "" && l != val) {
+			return false
+		}
+	}
+	if f.State != VolumeState_VOLUME_STATE_NONE && v.State != f.State {
+		return false
+	}
+	if f.Node != "" && v.AttachedOn != f.Node {
+		found := false
+		for _, rs := range v.ReplicaSets {
+			for _, n := range rs.Nodes {
+				if n == f.Node {
+					found = true
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// VolumeEnumerateResponse is a page of volumes.
+type VolumeEnumerateResponse struct {
+	// Volumes in the page.
+	Volumes []*Volume
+	// NextToken continues the enumeration after the page. It is empty
+	// after the last page.
+	NextToken string
+}
+
+// AlertType describes a kind of alert raised by a component. Alerts carry
+// the type in Alert.AlertType.
+type AlertType struct {
+	// Type is the identifier stored in raised alerts.
+	Type int64
+	// Name is a short unique name for the type.
+	Name string
+	// Severity is the default severity of raised alerts.
+	Severity SeverityType
+	// Resource is the resource type alerts are raised against.
+	Resource ResourceType
+	// Message is a text/template rendered with the alert when it is raised
+	// without a message.
+	Message string
+	// Ttl is the default time, in seconds, raised alerts are kept.
+	Ttl uint64
+	// AutoClear keeps the older alerts of this type superseded by a newer
+	// one for the same resource and unique tag as cleared alerts. They are
+	// erased if it is not set.
+	AutoClear bool
+}
+
 type BackupStsRequest struct {
 	// SrcVolumeID optional volumeID to list status of backup/restore
 	SrcVolumeID string
@@ -1146,6 +1146,8 @@ type Volume struct {
 	RuntimeState []*RuntimeStateMap `protobuf:"bytes,20,rep,name=runtime_state,json=runtimeState" json:"runtime_state,omitempty"`
 	// Error is the Last recorded error.
 	Error string `protobuf:"bytes,21,opt,name=error" json:"error,omitempty"`
+	// PurgeTime is the time at which a volume in the trash is deleted.
+	PurgeTime *google_protobuf.Timestamp `protobuf:"bytes,22,opt,name=purge_time,json=purgeTime" json:"purge_time,omitempty"`
 }
 
 func (m *Volume) Reset()                    { *m = Volume{} }
@@ -1300,6 +1302,13 @@ func (m *Volume) GetError() string {
 	return ""
 }
 
+func (m *Volume) GetPurgeTime() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.PurgeTime
+	}
+	return nil
+}
+
 // Stats is a structure that represents last collected stats for a volume
 // swagger:model
 type Stats struct {
@@ -1936,194 +1945,194 @@ func init() {
 func init() { proto.RegisterFile("api/api.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 3012 bytes of a gzipped FileDescriptorProto
+	// 3023 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0x4b, 0x73, 0xe3, 0xc6,
-	0xb5, 0x1e, 0xf0, 0xcd, 0x43, 0x91, 0x82, 0x7a, 0x34, 0x12, 0x46, 0xf3, 0x92, 0x59, 0xd7, 0xb6,
-	0x8a, 0xd7, 0x57, 0xe3, 0xd2, 0xb5, 0x7d, 0xc7, 0x73, 0xf3, 0xa2, 0x48, 0x50, 0x62, 0xcc, 0x87,
-	0xdc, 0x80, 0x34, 0x33, 0x4e, 0xa5, 0x50, 0x18, 0xb2, 0x25, 0xc1, 0x43, 0x01, 0x18, 0x00, 0x54,
-	0x4a, 0x5e, 0x64, 0x9b, 0x4d, 0x2a, 0x59, 0x25, 0x29, 0xaf, 0xf2, 0x03, 0xbc, 0xca, 0x3a, 0x8b,
-	0x2c, 0xb2, 0xf2, 0x26, 0xbf, 0x24, 0x9b, 0xfc, 0x83, 0xd4, 0xe9, 0x6e, 0x50, 0x00, 0x29, 0xcd,
-	0x8c, 0x2a, 0x5e, 0xa9, 0xfb, 0x3b, 0x8f, 0x3e, 0x7d, 0xfa, 0xbc, 0x40, 0x41, 0xd5, 0xf6, 0x9d,
-	0xc7, 0xb6, 0xef, 0x6c, 0xfb, 0x81, 0x17, 0x79, 0x64, 0xd9, 0xf3, 0x99, 0x1b, 0x46, 0x5e, 0x60,
-	0x9f, 0xb0, 0x6d, 0xdb, 0x77, 0x36, 0x1e, 0x9d, 0x78, 0xde, 0xc9, 0x84, 0x3d, 0xe6, 0xe4, 0x97,
-	0xd3, 0xe3, 0xc7, 0x91, 0x73, 0xc6, 0xc2, 0xc8, 0x3e, 0xf3, 0x85, 0x44, 0xfd, 0x5f, 0x19, 0x58,
-	0x36, 0x84, 0x00, 0x65, 0xa1, 0x37, 0x0d, 0x46, 0x8c, 0xd4, 0x20, 0xe3, 0x8c, 0x35, 0x65, 0x53,
-	0xd9, 0x2a, 0xd3, 0x8c, 0x33, 0x26, 0x04, 0x72, 0xbe, 0x1d, 0x9d, 0x6a, 0x19, 0x8e, 0xf0, 0x35,
-	0xf9, 0x0c, 0x0a, 0x67, 0x6c, 0xec, 0x4c, 0xcf, 0xb4, 0xec, 0xa6, 0xb2, 0x55, 0xdb, 0x79, 0xb8,
-	0x3d, 0x77, 0xf4, 0xb6, 0xd4, 0xda, 0xe7, 0x5c, 0x54, 0x72, 0x93, 0x35, 0x28, 0x78, 0xee, 0xc4,
-	0x71, 0x99, 0x96, 0xdb, 0x54, 0xb6, 0x4a, 0x54, 0xee, 0xf0, 0x0c, 0xc7, 0xf3, 0x43, 0x2d, 0xbf,
-	0xa9, 0x6c, 0xe5, 0x28, 0x5f, 0x93, 0x7b, 0x50, 0x0e, 0xd9, 0x6b, 0xeb, 0x57, 0x81, 0x13, 0x31,
-	0xad, 0xb0, 0xa9, 0x6c, 0x29, 0xb4, 0x14, 0xb2, 0xd7, 0xcf, 0x70, 0x4f, 0xee, 0x02, 0xae, 0xad,
-	0x80, 0xd9, 0x63, 0xad, 0xc8, 0x69, 0xc5, 0x90, 0xbd, 0xa6, 0xcc, 0x1e, 0xe3, 0x19, 0x81, 0xed,
-	0x8e, 0xe9, 0x33, 0xad, 0xc4, 0x09, 0x72, 0x87, 0x67, 0x84, 0xce, 0x37, 0x4c, 0x2b, 0x8b, 0x33,
-	0x70, 0x8d, 0xd8, 0x34, 0x64, 0x63, 0x0d, 0x04, 0x86, 0x6b, 0xf2, 0x3e, 0xd4, 0x02, 0x2f, 0xb2,
-	0x23, 0xc7, 0x73, 0xad, 0xd0, 0x67, 0x6c, 0xac, 0x55, 0xf8, 0xcd, 0xab, 0x31, 0x6a, 0x20, 0x48,
-	0xfe, 0x0f, 0xca, 0x13, 0x3b, 0x8c, 0xac, 0x70, 0x64, 0xbb, 0xda, 0xd2, 0xa6, 0xb2, 0x55, 0xd9,
-	0xd9, 0xd8, 0x16, 0xfe, 0xde, 0x8e, 0xfd, 0xbd, 0x6d, 0xc6, 0xfe, 0xa6, 0x25, 0x64, 0x36, 0x46,
-	0xb6, 0x5b, 0xff, 0x47, 0x06, 0x2a, 0xd2, 0x3b, 0x07, 0x9e, 0x37, 0x41, 0x7f, 0x77, 0xdb, 0xdc,
-	0xdf, 0x79, 0x9a, 0xe9, 0xb6, 0x49, 0x03, 0xb2, 0x2d, 0x2f, 0xe4, 0xee, 0xae, 0xed, 0x68, 0x0b,
-	0x8e, 0x6d, 0x79, 0xa1, 0x79, 0xe1, 0x33, 0x8a, 0x4c, 0xf8, 0x0e, 0xfd, 0x1b, 0xbd, 0x83, 0xf8,
-	0x4b, 0xee, 0x43, 0x99, 0xda, 0xce, 0xb8, 0xc7, 0xce, 0xd9, 0x84, 0x3f, 0x45, 0x99, 0x5e, 0x02,
-	0x48, 0x35, 0xbd, 0xc8, 0x9e, 0x18, 0xe8, 0xae, 0x22, 0x77, 0xcd, 0x25, 0x80, 0x3e, 0x3b, 0x44,
-	0x9f, 0x95, 0x84, 0xcf, 0x70, 0x4d, 0x7e, 0x06, 0x85, 0x89, 0xfd, 0x92, 0x4d, 0x42, 0xad, 0xbc,
-	0x99, 0xdd, 0xaa, 0xec, 0x6c, 0x5d, 0x67, 0x07, 0xde, 0x78, 0xbb, 0xc7, 0x59, 0x75, 0x37, 0x0a,
-	0x2e, 0xa8, 0x94, 0xdb, 0xf8, 0x1c, 0x2a, 0x09, 0x98, 0xa8, 0x90, 0x7d, 0xc5, 0x2e, 0x64, 0x14,
-	0xe2, 0x92, 0xac, 0x42, 0xfe, 0xdc, 0x9e, 0x4c, 0x99, 0x8c, 0x43, 0xb1, 0x79, 0x9a, 0x79, 0xa2,
-	0xd4, 0xff, 0xaa, 0x40, 0xf5, 0xc8, 0x9b, 0x4c, 0xcf, 0x58, 0xcf, 0x1b, 0xd9, 0x91, 0x17, 0xa0,
-	0x89, 0xae, 0x7d, 0xc6, 0xa4, 0x38, 0x5f, 0x93, 0x43, 0xa8, 0x9e, 0x73, 0x26, 0x4b, 0x5a, 0x9a,
-	0xe1, 0x96, 0x7e, 0xbc, 0x60, 0x69, 0x4a, 0x55, 0xbc, 0x4b, 0x58, 0xbc, 0x74, 0x9e, 0x80, 0x36,
-	0x7e, 0x0a, 0x2b, 0x0b, 0x2c, 0x37, 0xb2, 0xfe, 0x13, 0x28, 0x18, 0x22, 0xf1, 0xd6, 0xa0, 0xe0,
-	0xdb, 0x01, 0x73, 0x23, 0x29, 0x28, 0x77, 0x3c, 0x70, 0x31, 0x0c, 0x65, 0x02, 0xe2, 0xba, 0xbe,
-	0x0e, 0xf9, 0xbd, 0xc0, 0x9b, 0xfa, 0xf3, 0xd9, 0x5a, 0xff, 0x7b, 0x11, 0x40, 0x18, 0x64, 0xf8,
-	0x6c, 0x84, 0x4f, 0xc9, 0xfc, 0x53, 0x76, 0xc6, 0x02, 0x7b, 0xc2, 0xb9, 0x4a, 0xf4, 0x12, 0x98,
-	0xa5, 0x44, 0x26, 0x91, 0x12, 0x8f, 0xa1, 0x70, 0xec, 0x05, 0x67, 0x76, 0x24, 0x43, 0x6a, 0x7d,
-	0xc1, 0x41, 0x1d, 0x83, 0x07, 0xa0, 0x64, 0x23, 0x0f, 0x00, 0x5e, 0x4e, 0xbc, 0xd1, 0x2b, 0x8b,
-	0xab, 0xc2, 0x60, 0xca, 0xd2, 0x32, 0x47, 0x78, 0xb8, 0xdc, 0x85, 0xd2, 0xa9, 0x6d, 0x4d, 0x78,
-	0xa4, 0xe5, 0x39, 0xb1, 0x78, 0x6a, 0x8b, 0x38, 0x6b, 0x40, 0x76, 0xe4, 0x85, 0x3c, 0xb7, 0xdf,
-	0x18, 0xe9, 0x23, 0x2f, 0x24, 0x9f, 0x03, 0x38, 0x9e, 0xe5, 0x07, 0xde, 0xb1, 0x33, 0x11, 0x41,
-	0x59, 0xdb, 0xd9, 0x58, 0x10, 0xe9, 0x7a, 0x07, 0x82, 0x83, 0x96, 0x9d, 0x78, 0x89, 0x7e, 0x1d,
-	0xb3, 0xf1, 0xd4, 0x67, 0x3c, 0x64, 0x4b, 0x54, 0xee, 0xc8, 0x7f, 0xc3, 0x4a, 0xe8, 0xda, 0x7e,
-	0x78, 0xea, 0x45, 0x96, 0xe3, 0x46, 0x2c, 0x38, 0xb7, 0x27, 0xbc, 0x3a, 0x54, 0xa9, 0x1a, 0x13,
-	0xba, 0x12, 0x27, 0x74, 0x3e, 0x7c, 0x80, 0x87, 0xcf, 0xff, 0x5c, 0x13, 0x3e, 0xe8, 0xfc, 0xb7,
-	0xc5, 0x0e, 0x1a, 0x16, 0x9e, 0xda, 0x81, 0xac, 0x30, 0x25, 0x2a, 0x77, 0xe4, 0x47, 0x50, 0x09,
-	0x98, 0x3f, 0x71, 0x46, 0xb6, 0x15, 0xb2, 0x48, 0x16, 0x97, 0x7b, 0x0b, 0x27, 0x51, 0xc1, 0x63,
-	0xb0, 0x88, 0x42, 0x30, 0x5b, 0xe3, 0xb5, 0xec, 0x93, 0x93, 0x80, 0x9d, 0x88, 0x12, 0x26, 0x3c,
-	0x5f, 0x15, 0xd7, 0x4a, 0x10, 0x66, 0xa9, 0xce, 0xdc, 0x51, 0x70, 0xe1, 0x47, 0x6c, 0xac, 0xd5,
-	0x64, 0x7c, 0xc4, 0x00, 0x79, 0x08, 0xe0, 0xdb, 0x61, 0xe8, 0x9f, 0x06, 0x76, 0xc8, 0xb4, 0x65,
-	0x1e, 0x64, 0x09, 0x24, 0xe5, 0xc1, 0x70, 0x74, 0xca, 0xc6, 0xd3, 0x09, 0xd3, 0x54, 0xce, 0x36,
-	0xf3, 0xa0, 0x21, 0x71, 0x4c, 0x81, 0x70, 0x64, 0x4f, 0x98, 0xb6, 0xc2, 0x6d, 0x11, 0x1b, 0xee,
-	0x83, 0xc8, 0x19, 0xbd, 0xba, 0xd0, 0x88, 0xf4, 0x01, 0xdf, 0x91, 0x8f, 0x20, 0x7f, 0x82, 0x01,
-	0xae, 0xdd, 0xe1, 0xb7, 0x5f, 0x5b, 0xb8, 0x3d, 0x0f, 0x7f, 0x2a, 0x98, 0xb0, 0x66, 0xf3, 0x85,
-	0xc5, 0xdc, 0x63, 0x2f, 0x18, 0xb1, 0xb1, 0xb6, 0xc6, 0xb5, 0x55, 0x39, 0xaa, 0x4b, 0x10, 0xef,
-	0x33, 0xf2, 0xce, 0xfc, 0x80, 0x85, 0x58, 0xc0, 0xd6, 0x39, 0x4b, 0x02, 0x21, 0x1b, 0x50, 0x1a,
-	0xd9, 0xe1, 0xc8, 0x1e, 0xb3, 0xb1, 0xa6, 0x71, 0xea, 0x6c, 0x4f, 0x34, 0x28, 0x7e, 0xed, 0x4d,
-	0x03, 0xd7, 0x9e, 0x68, 0x77, 0x39, 0x29, 0xde, 0x62, 0xb6, 0xbb, 0xc7, 0xa1, 0xb6, 0xc1, 0x51,
-	0x5c, 0xfe, 0xe7, 0x45, 0xa1, 0x0e, 0x70, 0xf9, 0xba, 0xc8, 0xe7, 0x7a, 0x63, 0x16, 0x6a, 0xca,
-	0x66, 0x16, 0xf9, 0xf8, 0xa6, 0xfe, 0x9d, 0x02, 0xcb, 0x74, 0xea, 0x62, 0x4b, 0x37, 0x22, 0x3b,
-	0x62, 0x7d, 0xdb, 0x27, 0xcf, 0xa0, 0x1a, 0x08, 0xc8, 0x0a, 0x11, 0xe3, 0x12, 0x95, 0x9d, 0x9d,
-	0xc5, 0xd8, 0x49, 0x0b, 0xa6, 0xf6, 0x32, 0x54, 0x83, 0x04, 0x84, 0x37, 0x5a, 0x60, 0xb9, 0xd1,
-	0x8d, 0xfe, 0x54, 0x82, 0x82, 0xf0, 0xc9, 0xc2, 0x80, 0xf1, 0x18, 0x0a, 0x62, 0xf4, 0xe0, 0x52,
-	0x95, 0x2b, 0x2a, 0x8e, 0x28, 0x90, 0x54, 0xb2, 0x5d, 0xc6, 0x46, 0xf6, 0x5d, 0x62, 0x63, 0x03,
-	0x4a, 0x38, 0x26, 0x78, 0xee, 0xe4, 0x42, 0x4e, 0x1d, 0xb3, 0x3d, 0x79, 0x02, 0xc5, 0x89, 0x28,
-	0xf4, 0xbc, 0x36, 0x55, 0xae, 0x68, 0xa0, 0xa9, 0x76, 0x40, 0x63, 0x76, 0xf2, 0x31, 0xe4, 0x47,
-	0xe8, 0x0e, 0x5e, 0xbd, 0xde, 0xdc, 0xfa, 0x05, 0x23, 0x79, 0x0c, 0xb9, 0xd0, 0x67, 0x23, 0x5e,
-	0xbb, 0xae, 0x4a, 0xe7, 0xcb, 0xc2, 0x41, 0x39, 0x23, 0x3a, 0x73, 0x1a, 0xda, 0x27, 0x4c, 0x76,
-	0x5a, 0xb1, 0x49, 0xcf, 0x1d, 0xe5, 0x77, 0x9f, 0x3b, 0x12, 0x85, 0x1d, 0xde, 0xad, 0xb0, 0x7f,
-	0x8a, 0xa9, 0x69, 0x47, 0xd3, 0x90, 0x97, 0xa7, 0xda, 0xce, 0x83, 0xeb, 0x4c, 0xe6, 0x4c, 0x54,
-	0x32, 0x93, 0x1d, 0xc8, 0x8b, 0xd8, 0x5b, 0xe2, 0x52, 0xf7, 0xdf, 0x20, 0xc5, 0xa8, 0x60, 0x25,
-	0x8f, 0xa0, 0x62, 0x47, 0x91, 0x8d, 0xa5, 0xc2, 0xf2, 0x5c, 0x5e, 0xad, 0xca, 0x14, 0x62, 0x68,
-	0xe8, 0x92, 0x16, 0xd4, 0x66, 0x0c, 0x42, 0x7b, 0xed, 0x1a, 0xed, 0x4d, 0xce, 0x26, 0xb4, 0x57,
-	0x63, 0x19, 0x23, 0x3e, 0x65, 0xcc, 0xce, 0x9d, 0x11, 0xb3, 0xf8, 0x40, 0x2b, 0xeb, 0x99, 0x80,
-	0x0e, 0x70, 0xac, 0xfd, 0x08, 0x48, 0xc8, 0x46, 0xd3, 0x80, 0x59, 0x49, 0xbe, 0xb8, 0xa0, 0x71,
-	0x4a, 0xfb, 0x92, 0x7b, 0x66, 0xb4, 0x60, 0x5b, 0xe1, 0xc9, 0x29, 0x8d, 0xe6, 0x0c, 0xfb, 0x33,
-	0x06, 0xc7, 0x3d, 0xf6, 0x34, 0xc2, 0x73, 0xf1, 0xc3, 0x6b, 0xfc, 0x21, 0x0d, 0xef, 0xba, 0xc7,
-	0x9e, 0x48, 0x40, 0xa9, 0x09, 0x01, 0xf2, 0x13, 0x58, 0x4a, 0x74, 0x84, 0x50, 0xbb, 0xcd, 0x55,
-	0xbd, 0xb1, 0x25, 0x54, 0x2e, 0x5b, 0x42, 0x48, 0xf4, 0xf9, 0xba, 0xb0, 0xca, 0x15, 0x6c, 0xbe,
-	0xad, 0x2e, 0xa4, 0xab, 0x00, 0x46, 0x24, 0x0b, 0x02, 0x2f, 0xe0, 0x45, 0xb9, 0x4c, 0xc5, 0x66,
-	0xe3, 0xc7, 0xb0, 0x3c, 0x67, 0xfb, 0x8d, 0x2a, 0xc3, 0x9f, 0x33, 0x90, 0x47, 0xf5, 0x21, 0xf2,
-	0x60, 0x66, 0x86, 0x5c, 0x2e, 0x47, 0xc5, 0x86, 0xac, 0x43, 0x11, 0x17, 0xd6, 0x59, 0x28, 0xe7,
-	0x94, 0x02, 0x6e, 0xfb, 0x21, 0x0e, 0x1e, 0x9c, 0xf0, 0xf2, 0x22, 0x62, 0x21, 0xaf, 0x05, 0x39,
-	0x5a, 0x46, 0x64, 0x17, 0x01, 0xec, 0x2c, 0xfc, 0xdb, 0x21, 0xe4, 0x59, 0x9f, 0xa3, 0x72, 0x87,
-	0x03, 0x09, 0x5f, 0xa1, 0x42, 0xf1, 0xbd, 0x51, 0xe4, 0xfb, 0x7e, 0x88, 0x2f, 0x2a, 0x48, 0x42,
-	0x65, 0x81, 0x53, 0x81, 0x43, 0x42, 0xe7, 0x23, 0xa8, 0x88, 0x29, 0xe4, 0x04, 0x3b, 0x86, 0x9c,
-	0x8d, 0x81, 0x8f, 0x1a, 0x1c, 0x21, 0xb7, 0x21, 0xef, 0x78, 0xa8, 0xb9, 0x14, 0x7f, 0xc9, 0x08,
-	0x43, 0xb9, 0x42, 0x8b, 0x7f, 0x6b, 0x88, 0xef, 0x8f, 0x32, 0x47, 0xf8, 0xf0, 0x8c, 0x4a, 0xe5,
-	0x98, 0x81, 0x92, 0x20, 0x95, 0x4a, 0xa8, 0x1f, 0xd6, 0xff, 0x99, 0x81, 0x7c, 0x73, 0xc2, 0x82,
-	0x28, 0x51, 0x3a, 0xb3, 0xbc, 0x74, 0x7e, 0x8e, 0x9f, 0x41, 0xe7, 0x2c, 0x70, 0xa2, 0x0b, 0xf9,
-	0xc1, 0xb0, 0x98, 0xa4, 0x86, 0x64, 0xe0, 0xb9, 0x3d, 0x63, 0x47, 0xa3, 0x6c, 0xd4, 0x69, 0x45,
-	0x17, 0x3e, 0xe3, 0xde, 0xcb, 0xd2, 0x32, 0x47, 0x90, 0x11, 0xdb, 0xdd, 0x19, 0x0b, 0x79, 0xf9,
-	0x11, 0xdf, 0x07, 0xf1, 0x96, 0x3c, 0x81, 0xf2, 0xec, 0x33, 0x52, 0x56, 0xcd, 0x37, 0x15, 0xa0,
-	0x4b, 0x66, 0xbc, 0x68, 0x20, 0xbf, 0x32, 0x2d, 0x67, 0xcc, 0xdd, 0x5b, 0xc6, 0xd1, 0x45, 0x40,
-	0x5d, 0x7e, 0x9d, 0x78, 0x27, 0x47, 0xbc, 0x07, 0x57, 0x84, 0xb8, 0x60, 0x10, 0xd7, 0x89, 0xd9,
-	0xd1, 0xde, 0xd1, 0x84, 0xf1, 0x61, 0x4a, 0x4c, 0x79, 0xf1, 0x16, 0x63, 0x31, 0x8a, 0x26, 0xd2,
-	0xed, 0xb8, 0xc4, 0xab, 0x4f, 0x5d, 0xe7, 0xf5, 0x94, 0x59, 0x91, 0x7d, 0xc2, 0xfd, 0x5d, 0xa6,
-	0x65, 0x81, 0x98, 0xf6, 0x49, 0xfd, 0x33, 0x28, 0x70, 0x6f, 0x87, 0xd8, 0x68, 0xb8, 0x47, 0x64,
-	0x1b, 0x5d, 0x6c, 0x34, 0x9c, 0x8f, 0x0a, 0xa6, 0xfa, 0x5f, 0x14, 0xb8, 0x2d, 0x72, 0xb9, 0x15,
-	0x30, 0x2c, 0x3f, 0xec, 0xf5, 0x94, 0x85, 0x51, 0xb2, 0xc9, 0x28, 0x37, 0x6b, 0x32, 0x37, 0xee,
-	0x8c, 0x71, 0x8f, 0xc9, 0xbe, 0x63, 0x8f, 0xa9, 0x7f, 0x00, 0x35, 0x81, 0x51, 0x16, 0xfa, 0x9e,
-	0x1b, 0x26, 0x72, 0x5c, 0x49, 0xe4, 0x78, 0xdd, 0x87, 0xd5, 0xf4, 0xd5, 0x24, 0xf7, 0x7c, 0x2f,
-	0xdf, 0x87, 0x65, 0x39, 0x26, 0x07, 0x92, 0x45, 0x9a, 0xfe, 0xe8, 0x1a, 0x5b, 0x62, 0x4d, 0xb4,
-	0x76, 0x9e, 0xda, 0xd7, 0xbf, 0x57, 0xe2, 0x21, 0x8a, 0xd7, 0x9e, 0xe6, 0x08, 0x87, 0x56, 0xf2,
-	0x14, 0x0a, 0xa2, 0x2c, 0xf2, 0x33, 0x6b, 0x3b, 0xf5, 0x6b, 0xd4, 0x0a, 0xf6, 0x03, 0x3b, 0xb0,
-	0xcf, 0xa8, 0x94, 0x20, 0x4f, 0x20, 0x7f, 0xe6, 0x4d, 0xdd, 0x48, 0x66, 0xca, 0xbb, 0x88, 0x0a,
-	0x01, 0x0c, 0x18, 0xbe, 0x10, 0x85, 0x3e, 0x2b, 0x02, 0x86, 0x23, 0x71, 0x23, 0x48, 0xf6, 0x8b,
-	0xdc, 0x7c, 0x5f, 0xa9, 0xff, 0x2d, 0x03, 0xaa, 0xbc, 0x0b, 0x8b, 0x7e, 0x88, 0xb0, 0x10, 0xaf,
-	0x9c, 0x79, 0xd7, 0x49, 0x02, 0xbd, 0xc6, 0x6f, 0x25, 0x03, 0xa3, 0xfe, 0xa6, 0x9e, 0x2c, 0xee,
-	0x4f, 0xa5, 0x04, 0xd9, 0x87, 0xa2, 0xe7, 0xe3, 0x0a, 0xeb, 0x28, 0x66, 0xc1, 0xf6, 0x75, 0xc2,
-	0xb3, 0xab, 0x6d, 0x0f, 0x85, 0x80, 0xe8, 0x63, 0xb1, 0xf8, 0xc6, 0x53, 0x58, 0x4a, 0x12, 0x6e,
-	0xd4, 0x24, 0x7e, 0x77, 0x19, 0x0d, 0x78, 0x8c, 0x8c, 0xbe, 0xc7, 0x50, 0x10, 0x51, 0x23, 0x3d,
-	0xb8, 0x7e, 0x5d, 0x90, 0x49, 0xb6, 0x1f, 0x30, 0x3c, 0x2f, 0x60, 0xc5, 0x70, 0x6d, 0x3f, 0x9d,
-	0xe9, 0xf3, 0xd9, 0x90, 0x78, 0xe2, 0xcc, 0xcd, 0x9e, 0x38, 0x39, 0xb4, 0x66, 0xd3, 0x43, 0x6b,
-	0xfd, 0x35, 0x90, 0xe4, 0xd1, 0xd2, 0x17, 0xbf, 0x80, 0x35, 0x79, 0xb5, 0x11, 0x27, 0x5c, 0xde,
-	0x50, 0xf8, 0xe6, 0xfd, 0x6b, 0x8e, 0x4e, 0xab, 0xa1, 0xab, 0xe7, 0x57, 0xa0, 0xf5, 0x28, 0xfe,
-	0x51, 0x81, 0x4f, 0x23, 0xf7, 0xa0, 0x2c, 0x8f, 0x9a, 0xdd, 0xb6, 0x24, 0x80, 0xee, 0xd5, 0x3f,
-	0x17, 0x7e, 0x0a, 0x45, 0x79, 0xf0, 0xbb, 0x54, 0xa6, 0x98, 0xb7, 0x3e, 0x06, 0xb2, 0x17, 0xd8,
-	0xfe, 0x69, 0x3b, 0x70, 0xce, 0x59, 0xd0, 0x3a, 0xb5, 0xdd, 0x13, 0x16, 0xce, 0x0e, 0x50, 0x12,
-	0x07, 0x3c, 0x85, 0xdc, 0x2b, 0xc7, 0x1d, 0xcb, 0xcc, 0xfe, 0xe0, 0x8a, 0x0f, 0x82, 0x39, 0x35,
-	0xbc, 0x7b, 0x70, 0x99, 0xfa, 0x87, 0xb0, 0xdc, 0x9a, 0x4c, 0xc3, 0x88, 0x05, 0x6f, 0xa9, 0x81,
-	0x7f, 0x54, 0xa0, 0x8a, 0xc9, 0x71, 0x3e, 0x7b, 0xef, 0x7d, 0x28, 0x51, 0xf6, 0x9a, 0x85, 0xd1,
-	0x17, 0x47, 0xb2, 0x45, 0x7c, 0xb4, 0xd8, 0x22, 0x92, 0x12, 0xdb, 0x31, 0xbb, 0x48, 0x8d, 0x99,
-	0xf4, 0xc6, 0xff, 0x43, 0x35, 0x45, 0x4a, 0x26, 0x47, 0xf6, 0x6d, 0xc9, 0xf1, 0x0d, 0xd4, 0x52,
-	0xa7, 0x84, 0xa4, 0x0e, 0x4b, 0x72, 0xdd, 0xe2, 0x15, 0x4f, 0xa8, 0x49, 0x61, 0xa4, 0x3d, 0x77,
-	0x1b, 0xf9, 0x83, 0xd8, 0xc3, 0x37, 0xdf, 0x80, 0xa6, 0x85, 0x1a, 0xdf, 0x67, 0xa0, 0x20, 0x3e,
-	0x00, 0xc8, 0x32, 0x54, 0x0c, 0xb3, 0x69, 0x1e, 0x1a, 0xd6, 0x60, 0x38, 0xd0, 0xd5, 0x5b, 0x09,
-	0xa0, 0x3b, 0xe8, 0x9a, 0xaa, 0x42, 0xaa, 0x50, 0x96, 0xc0, 0xf0, 0x0b, 0x35, 0x43, 0x08, 0xd4,
-	0xe2, 0x6d, 0xa7, 0xd3, 0xeb, 0x0e, 0x74, 0x35, 0x4b, 0x54, 0x58, 0x92, 0x98, 0x4e, 0xe9, 0x90,
-	0xaa, 0x39, 0xa2, 0xc1, 0xea, 0x4c, 0xad, 0x69, 0x75, 0x07, 0xd6, 0x97, 0x87, 0x43, 0x7a, 0xd8,
-	0x57, 0xf3, 0x64, 0x1d, 0x6e, 0x4b, 0x4a, 0x5b, 0x6f, 0x0d, 0xfb, 0xfd, 0xae, 0x61, 0x74, 0x87,
-	0x03, 0xb5, 0x40, 0xd6, 0x80, 0x48, 0x42, 0xbf, 0xd9, 0x1d, 0x98, 0xfa, 0xa0, 0x39, 0x68, 0xe9,
-	0x6a, 0x31, 0x21, 0x60, 0x98, 0x43, 0xda, 0xdc, 0xd3, 0xad, 0xf6, 0xf0, 0xd9, 0x40, 0x2d, 0x91,
-	0x7b, 0xb0, 0x3e, 0x4f, 0xd0, 0xf7, 0x68, 0xb3, 0xad, 0xb7, 0xd5, 0x72, 0x42, 0x6a, 0xa0, 0xeb,
-	0x6d, 0xc3, 0xa2, 0xfa, 0xee, 0x70, 0x68, 0xaa, 0x40, 0xee, 0x83, 0x36, 0x27, 0x45, 0xf5, 0xdd,
-	0x66, 0x8f, 0x1f, 0x56, 0x21, 0x9b, 0x70, 0x7f, 0x5e, 0x27, 0xed, 0x1e, 0x21, 0xcf, 0x41, 0xaf,
-	0xd9, 0xd2, 0xd5, 0x25, 0x52, 0x03, 0x98, 0x99, 0xf9, 0x5c, 0xad, 0x36, 0xbe, 0x55, 0x00, 0x44,
-	0x90, 0xf2, 0x11, 0x6c, 0x15, 0x54, 0x2e, 0x41, 0x2d, 0xf3, 0xc5, 0x81, 0x1e, 0x3b, 0x75, 0x0e,
-	0xed, 0x74, 0x7b, 0xba, 0xaa, 0x90, 0x3b, 0xb0, 0x92, 0x44, 0x77, 0x7b, 0xc3, 0x16, 0x7a, 0x78,
-	0x0d, 0x48, 0x12, 0x1e, 0xee, 0xfe, 0x5c, 0x6f, 0x99, 0x6a, 0x96, 0xdc, 0x85, 0x3b, 0x49, 0xbc,
-	0xd5, 0x3b, 0x34, 0x4c, 0x9d, 0xea, 0x6d, 0x35, 0x37, 0xaf, 0x69, 0x8f, 0x36, 0x0f, 0xf6, 0xd5,
-	0x7c, 0xe3, 0x0f, 0x0a, 0x14, 0xc4, 0xf7, 0x21, 0x3e, 0x51, 0xc7, 0x48, 0xd9, 0xb4, 0x02, 0xd5,
-	0x18, 0xd9, 0x35, 0x69, 0xc7, 0x50, 0x95, 0x24, 0x93, 0xfe, 0xdc, 0xfc, 0x44, 0xcd, 0x24, 0x91,
-	0xce, 0xa1, 0x81, 0x6f, 0xbd, 0x0c, 0x95, 0x99, 0xa2, 0x8e, 0xa1, 0xe6, 0x92, 0xc0, 0x51, 0xc7,
-	0x50, 0xf3, 0x49, 0xe0, 0x79, 0xc7, 0x50, 0x0b, 0x49, 0xe0, 0xab, 0x8e, 0xa1, 0x16, 0x1b, 0xdf,
-	0x29, 0x70, 0xe7, 0xca, 0xec, 0x26, 0xef, 0xc1, 0x03, 0x6e, 0xbc, 0x25, 0xaf, 0xd3, 0xda, 0x6f,
-	0x0e, 0xf6, 0xf4, 0x94, 0xdd, 0xef, 0xc3, 0x7b, 0xd7, 0xb2, 0xf4, 0x87, 0xed, 0x6e, 0xa7, 0xab,
-	0xb7, 0x55, 0x85, 0xd4, 0xe1, 0xe1, 0xb5, 0x6c, 0xcd, 0x36, 0x06, 0x49, 0x86, 0xfc, 0x17, 0x6c,
-	0x5e, 0xcb, 0xd3, 0xd6, 0x7b, 0xba, 0xa9, 0xb7, 0xd5, 0x6c, 0x23, 0x82, 0xa5, 0xe4, 0x38, 0xce,
-	0x03, 0x55, 0x3f, 0xd2, 0x69, 0xd7, 0x7c, 0x91, 0x32, 0x0c, 0x43, 0x2e, 0x85, 0x37, 0x7b, 0x4d,
-	0xda, 0x57, 0x15, 0x7c, 0xb8, 0x34, 0xe1, 0x59, 0x93, 0x0e, 0xba, 0x83, 0x3d, 0x35, 0xc3, 0xf3,
-	0x64, 0x4e, 0x97, 0xd9, 0xed, 0xbc, 0x50, 0xb3, 0x8d, 0xdf, 0x2a, 0x58, 0x0e, 0x2e, 0xc7, 0x66,
-	0x3c, 0x96, 0xea, 0xc6, 0xf0, 0x90, 0xb6, 0xd2, 0xfe, 0xd0, 0x60, 0x35, 0x8d, 0x1f, 0x0d, 0x7b,
-	0x87, 0x7d, 0x8c, 0xaf, 0x2b, 0x24, 0xda, 0xba, 0x9a, 0x41, 0x7b, 0xd2, 0xb8, 0x0c, 0x25, 0x35,
-	0x8b, 0x77, 0x48, 0x93, 0xb8, 0x67, 0xd4, 0x5c, 0xe3, 0x37, 0x0a, 0x2c, 0xf3, 0xc1, 0x59, 0x4c,
-	0x1a, 0xdc, 0xa2, 0x0d, 0x58, 0x6b, 0xf6, 0x74, 0x6a, 0x5a, 0xcd, 0x96, 0xd9, 0x1d, 0x0e, 0x52,
-	0x56, 0xdd, 0x07, 0x6d, 0x91, 0x26, 0x7c, 0xaa, 0x2a, 0x57, 0x53, 0x5b, 0x54, 0x6f, 0x9a, 0x68,
-	0xdf, 0x95, 0xd4, 0xc3, 0x83, 0x36, 0x52, 0xb3, 0x8d, 0xaf, 0xe3, 0xa1, 0x22, 0x31, 0xf3, 0xa1,
-	0x88, 0xb8, 0x76, 0x2c, 0x73, 0xd0, 0xa4, 0xcd, 0x7e, 0x6c, 0xcc, 0x3d, 0x58, 0xbf, 0x8a, 0x3a,
-	0xec, 0x74, 0x54, 0x05, 0x6f, 0x71, 0x25, 0x71, 0xa0, 0x66, 0x1a, 0x3b, 0x50, 0x94, 0x3f, 0x68,
-	0x93, 0x12, 0xe4, 0xa4, 0xb6, 0x22, 0x64, 0x7b, 0xc3, 0x67, 0xaa, 0x42, 0x00, 0x0a, 0x7d, 0xbd,
-	0xdd, 0x3d, 0xec, 0xab, 0x19, 0x24, 0xef, 0x77, 0xf7, 0xf6, 0xd5, 0x6c, 0xe3, 0xd7, 0x50, 0x9e,
-	0xfd, 0xa2, 0x8d, 0xae, 0xee, 0x0e, 0xad, 0x03, 0x3a, 0xc4, 0x94, 0xb7, 0x0c, 0xfd, 0xcb, 0x43,
-	0x7d, 0x60, 0x76, 0x9b, 0x3d, 0xf5, 0x16, 0xe6, 0x6c, 0x82, 0x44, 0x9b, 0x83, 0xf6, 0x10, 0x83,
-	0x65, 0x05, 0xaa, 0x09, 0xb8, 0xbd, 0x2b, 0x82, 0x24, 0x05, 0x59, 0x54, 0xef, 0x0f, 0xd1, 0x17,
-	0x58, 0x8c, 0x13, 0x94, 0x56, 0xdf, 0x50, 0x73, 0x8d, 0x6f, 0x33, 0x50, 0x49, 0x4c, 0x86, 0x78,
-	0x8e, 0xbc, 0x1f, 0xd6, 0xad, 0x64, 0xd8, 0xa4, 0xe0, 0x03, 0x7d, 0xd0, 0xc6, 0x98, 0x4c, 0x3a,
-	0x44, 0x50, 0x9a, 0x47, 0xcd, 0x6e, 0xaf, 0xb9, 0xdb, 0x93, 0xa1, 0x93, 0xa6, 0x99, 0x66, 0xb3,
-	0xb5, 0x8f, 0x69, 0xb2, 0x40, 0x6a, 0xeb, 0x92, 0x94, 0x4b, 0xf8, 0xff, 0x92, 0x64, 0xb6, 0xf6,
-	0xf1, 0xb8, 0x3c, 0x46, 0x69, 0x8a, 0x28, 0x5a, 0x48, 0x61, 0xc1, 0xc0, 0x38, 0x21, 0x8b, 0xe4,
-	0x21, 0x6c, 0xa4, 0x28, 0x26, 0x7d, 0x21, 0x4f, 0x43, 0x8d, 0xa5, 0x05, 0x49, 0xaa, 0x63, 0x31,
-	0xd7, 0xd5, 0x72, 0xe3, 0xf7, 0x0a, 0x2c, 0x25, 0x7f, 0xff, 0x9a, 0x3b, 0xfc, 0xb2, 0x0b, 0x3e,
-	0x80, 0xbb, 0xf3, 0xb8, 0x69, 0x1d, 0x50, 0xdd, 0xd0, 0x07, 0xd8, 0x13, 0x57, 0x41, 0x4d, 0x93,
-	0x0f, 0x0f, 0x44, 0xe1, 0x4e, 0xa3, 0xbc, 0x51, 0x65, 0xe7, 0x1c, 0xca, 0x3b, 0x9f, 0xec, 0x53,
-	0xb9, 0xc6, 0x2f, 0xa1, 0x9a, 0xfa, 0x6f, 0x9f, 0xe8, 0x6a, 0xa2, 0xf5, 0x88, 0xe0, 0xb2, 0xfa,
-	0xcd, 0xbd, 0x81, 0x6e, 0x76, 0x5b, 0xea, 0x2d, 0xd1, 0x23, 0x53, 0x44, 0xc3, 0xc0, 0x62, 0xc7,
-	0xbb, 0x5d, 0x0a, 0x1f, 0x1c, 0xf5, 0x75, 0x35, 0xd3, 0xd8, 0x82, 0xaa, 0x9c, 0x93, 0x06, 0x5e,
-	0xe4, 0x1c, 0x5f, 0x20, 0xa7, 0xcc, 0x76, 0x59, 0x6a, 0x84, 0x91, 0xb7, 0x1a, 0x0c, 0x2a, 0x89,
-	0x5f, 0xe1, 0xf0, 0x35, 0xc5, 0xdb, 0xc6, 0xaf, 0xf2, 0xdc, 0xd4, 0xe9, 0x80, 0x07, 0xee, 0x3c,
-	0x09, 0x9b, 0x35, 0x27, 0x29, 0xd8, 0x3e, 0xaf, 0x24, 0x59, 0xc6, 0xb3, 0xae, 0xd9, 0xda, 0x57,
-	0x33, 0x0d, 0x13, 0x6a, 0x43, 0x9f, 0x05, 0xfc, 0xbf, 0x19, 0x9d, 0x89, 0x7d, 0x12, 0xa2, 0x2f,
-	0x87, 0x07, 0x56, 0xa7, 0xd7, 0xdc, 0x33, 0xac, 0xc3, 0xc1, 0x17, 0x03, 0x6e, 0x0e, 0xa6, 0xc1,
-	0x0c, 0xe5, 0x6f, 0xc2, 0xcb, 0xe8, 0x0c, 0x12, 0xcf, 0x6d, 0x75, 0x86, 0xb4, 0xa5, 0xab, 0x99,
-	0xdd, 0xfb, 0x70, 0x7b, 0xe4, 0x9d, 0xcd, 0x0f, 0x41, 0x07, 0xca, 0x57, 0x59, 0xdb, 0x77, 0x5e,
-	0x16, 0xf8, 0x2f, 0x1c, 0xff, 0xfb, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0x50, 0xea, 0xd0, 0xb2,
-	0x7a, 0x1f, 0x00, 0x00,
+	0xf1, 0x5f, 0xf0, 0xcd, 0xa6, 0x48, 0x41, 0xb3, 0x5a, 0x2d, 0x56, 0xfb, 0x92, 0x59, 0x7f, 0xdb,
+	0x2a, 0xfe, 0x1d, 0xad, 0x4b, 0xb1, 0x9d, 0xf5, 0xe6, 0x49, 0x91, 0xa0, 0x84, 0x98, 0x0f, 0x79,
+	0x00, 0x69, 0x77, 0x9d, 0x4a, 0xa1, 0xb0, 0xe4, 0x48, 0xa2, 0x97, 0x22, 0xb0, 0x00, 0xa8, 0x94,
+	0x7c, 0xc8, 0x35, 0x97, 0x54, 0x72, 0x4a, 0xaa, 0x7c, 0xca, 0x07, 0xf0, 0x29, 0xe7, 0x1c, 0x72,
+	0xc8, 0xc9, 0x97, 0x1c, 0xf2, 0x39, 0x72, 0xc9, 0x37, 0x48, 0xf5, 0xcc, 0x80, 0x04, 0x48, 0xd1,
+	0x5a, 0x55, 0x7c, 0xd2, 0xcc, 0xaf, 0xbb, 0x67, 0xba, 0x7b, 0xfa, 0x05, 0x0a, 0xca, 0x8e, 0x37,
+	0x7c, 0xe2, 0x78, 0xc3, 0x1d, 0xcf, 0x77, 0x43, 0x97, 0xac, 0xba, 0x1e, 0x1b, 0x07, 0xa1, 0xeb,
+	0x3b, 0xa7, 0x6c, 0xc7, 0xf1, 0x86, 0x9b, 0x8f, 0x4f, 0x5d, 0xf7, 0x74, 0xc4, 0x9e, 0x70, 0xf2,
+	0xab, 0xc9, 0xc9, 0x93, 0x70, 0x78, 0xce, 0x82, 0xd0, 0x39, 0xf7, 0x84, 0x44, 0xf5, 0x3f, 0x29,
+	0x58, 0x35, 0x85, 0x00, 0x65, 0x81, 0x3b, 0xf1, 0xfb, 0x8c, 0x54, 0x20, 0x35, 0x1c, 0x68, 0xca,
+	0x96, 0xb2, 0x5d, 0xa4, 0xa9, 0xe1, 0x80, 0x10, 0xc8, 0x78, 0x4e, 0x78, 0xa6, 0xa5, 0x38, 0xc2,
+	0xd7, 0xe4, 0x13, 0xc8, 0x9d, 0xb3, 0xc1, 0x70, 0x72, 0xae, 0xa5, 0xb7, 0x94, 0xed, 0xca, 0xee,
+	0xa3, 0x9d, 0xb9, 0xab, 0x77, 0xe4, 0xa9, 0x1d, 0xce, 0x45, 0x25, 0x37, 0xd9, 0x80, 0x9c, 0x3b,
+	0x1e, 0x0d, 0xc7, 0x4c, 0xcb, 0x6c, 0x29, 0xdb, 0x05, 0x2a, 0x77, 0x78, 0xc7, 0xd0, 0xf5, 0x02,
+	0x2d, 0xbb, 0xa5, 0x6c, 0x67, 0x28, 0x5f, 0x93, 0xfb, 0x50, 0x0c, 0xd8, 0x1b, 0xfb, 0x37, 0xfe,
+	0x30, 0x64, 0x5a, 0x6e, 0x4b, 0xd9, 0x56, 0x68, 0x21, 0x60, 0x6f, 0x9e, 0xe3, 0x9e, 0xdc, 0x03,
+	0x5c, 0xdb, 0x3e, 0x73, 0x06, 0x5a, 0x9e, 0xd3, 0xf2, 0x01, 0x7b, 0x43, 0x99, 0x33, 0xc0, 0x3b,
+	0x7c, 0x67, 0x3c, 0xa0, 0xcf, 0xb5, 0x02, 0x27, 0xc8, 0x1d, 0xde, 0x11, 0x0c, 0xbf, 0x62, 0x5a,
+	0x51, 0xdc, 0x81, 0x6b, 0xc4, 0x26, 0x01, 0x1b, 0x68, 0x20, 0x30, 0x5c, 0x93, 0x77, 0xa1, 0xe2,
+	0xbb, 0xa1, 0x13, 0x0e, 0xdd, 0xb1, 0x1d, 0x78, 0x8c, 0x0d, 0xb4, 0x12, 0xb7, 0xbc, 0x1c, 0xa1,
+	0x26, 0x82, 0xe4, 0x47, 0x50, 0x1c, 0x39, 0x41, 0x68, 0x07, 0x7d, 0x67, 0xac, 0xad, 0x6c, 0x29,
+	0xdb, 0xa5, 0xdd, 0xcd, 0x1d, 0xe1, 0xef, 0x9d, 0xc8, 0xdf, 0x3b, 0x56, 0xe4, 0x6f, 0x5a, 0x40,
+	0x66, 0xb3, 0xef, 0x8c, 0xab, 0xff, 0x4c, 0x41, 0x49, 0x7a, 0xe7, 0xd0, 0x75, 0x47, 0xe8, 0x6f,
+	0xa3, 0xc9, 0xfd, 0x9d, 0xa5, 0x29, 0xa3, 0x49, 0x6a, 0x90, 0x6e, 0xb8, 0x01, 0x77, 0x77, 0x65,
+	0x57, 0x5b, 0x70, 0x6c, 0xc3, 0x0d, 0xac, 0x4b, 0x8f, 0x51, 0x64, 0xc2, 0x77, 0xe8, 0xdc, 0xe8,
+	0x1d, 0xc4, 0x5f, 0xf2, 0x00, 0x8a, 0xd4, 0x19, 0x0e, 0xda, 0xec, 0x82, 0x8d, 0xf8, 0x53, 0x14,
+	0xe9, 0x0c, 0x40, 0xaa, 0xe5, 0x86, 0xce, 0xc8, 0x44, 0x77, 0xe5, 0xb9, 0x6b, 0x66, 0x00, 0xfa,
+	0xec, 0x08, 0x7d, 0x56, 0x10, 0x3e, 0xc3, 0x35, 0xf9, 0x05, 0xe4, 0x46, 0xce, 0x2b, 0x36, 0x0a,
+	0xb4, 0xe2, 0x56, 0x7a, 0xbb, 0xb4, 0xbb, 0xbd, 0x4c, 0x0f, 0xb4, 0x78, 0xa7, 0xcd, 0x59, 0xf5,
+	0x71, 0xe8, 0x5f, 0x52, 0x29, 0xb7, 0xf9, 0x29, 0x94, 0x62, 0x30, 0x51, 0x21, 0xfd, 0x9a, 0x5d,
+	0xca, 0x28, 0xc4, 0x25, 0x59, 0x87, 0xec, 0x85, 0x33, 0x9a, 0x30, 0x19, 0x87, 0x62, 0xf3, 0x2c,
+	0xf5, 0x54, 0xa9, 0xfe, 0x4d, 0x81, 0xf2, 0xb1, 0x3b, 0x9a, 0x9c, 0xb3, 0xb6, 0xdb, 0x77, 0x42,
+	0xd7, 0x47, 0x15, 0xc7, 0xce, 0x39, 0x93, 0xe2, 0x7c, 0x4d, 0x8e, 0xa0, 0x7c, 0xc1, 0x99, 0x6c,
+	0xa9, 0x69, 0x8a, 0x6b, 0xfa, 0xe1, 0x82, 0xa6, 0x89, 0xa3, 0xa2, 0x5d, 0x4c, 0xe3, 0x95, 0x8b,
+	0x18, 0xb4, 0xf9, 0x73, 0x58, 0x5b, 0x60, 0xb9, 0x91, 0xf6, 0x1f, 0x41, 0xce, 0x14, 0x89, 0xb7,
+	0x01, 0x39, 0xcf, 0xf1, 0xd9, 0x38, 0x94, 0x82, 0x72, 0xc7, 0x03, 0x17, 0xc3, 0x50, 0x26, 0x20,
+	0xae, 0xab, 0x77, 0x21, 0xbb, 0xef, 0xbb, 0x13, 0x6f, 0x3e, 0x5b, 0xab, 0xff, 0xc8, 0x03, 0x08,
+	0x85, 0x4c, 0x8f, 0xf5, 0xf1, 0x29, 0x99, 0x77, 0xc6, 0xce, 0x99, 0xef, 0x8c, 0x38, 0x57, 0x81,
+	0xce, 0x80, 0x69, 0x4a, 0xa4, 0x62, 0x29, 0xf1, 0x04, 0x72, 0x27, 0xae, 0x7f, 0xee, 0x84, 0x32,
+	0xa4, 0xee, 0x2e, 0x38, 0xa8, 0x65, 0xf2, 0x00, 0x94, 0x6c, 0xe4, 0x21, 0xc0, 0xab, 0x91, 0xdb,
+	0x7f, 0x6d, 0xf3, 0xa3, 0x30, 0x98, 0xd2, 0xb4, 0xc8, 0x11, 0x1e, 0x2e, 0xf7, 0xa0, 0x70, 0xe6,
+	0xd8, 0x23, 0x1e, 0x69, 0x59, 0x4e, 0xcc, 0x9f, 0x39, 0x22, 0xce, 0x6a, 0x90, 0xee, 0xbb, 0x81,
+	0x96, 0xbb, 0x2e, 0xd2, 0xfb, 0x6e, 0x40, 0x3e, 0x05, 0x18, 0xba, 0xb6, 0xe7, 0xbb, 0x27, 0xc3,
+	0x91, 0x08, 0xca, 0xca, 0xee, 0xe6, 0x82, 0x88, 0xe1, 0x1e, 0x0a, 0x0e, 0x5a, 0x1c, 0x46, 0x4b,
+	0xf4, 0xeb, 0x80, 0x0d, 0x26, 0x1e, 0xe3, 0x21, 0x5b, 0xa0, 0x72, 0x47, 0xfe, 0x1f, 0xd6, 0x82,
+	0xb1, 0xe3, 0x05, 0x67, 0x6e, 0x68, 0x0f, 0xc7, 0x21, 0xf3, 0x2f, 0x9c, 0x11, 0xaf, 0x0e, 0x65,
+	0xaa, 0x46, 0x04, 0x43, 0xe2, 0x84, 0xce, 0x87, 0x0f, 0xf0, 0xf0, 0xf9, 0xc1, 0x92, 0xf0, 0x41,
+	0xe7, 0x5f, 0x17, 0x3b, 0xa8, 0x58, 0x70, 0xe6, 0xf8, 0xb2, 0xc2, 0x14, 0xa8, 0xdc, 0x91, 0x9f,
+	0x40, 0xc9, 0x67, 0xde, 0x68, 0xd8, 0x77, 0xec, 0x80, 0x85, 0xb2, 0xb8, 0xdc, 0x5f, 0xb8, 0x89,
+	0x0a, 0x1e, 0x93, 0x85, 0x14, 0xfc, 0xe9, 0x1a, 0xcd, 0x72, 0x4e, 0x4f, 0x7d, 0x76, 0x2a, 0x4a,
+	0x98, 0xf0, 0x7c, 0x59, 0x98, 0x15, 0x23, 0x4c, 0x53, 0x9d, 0x8d, 0xfb, 0xfe, 0xa5, 0x17, 0xb2,
+	0x81, 0x56, 0x91, 0xf1, 0x11, 0x01, 0xe4, 0x11, 0x80, 0xe7, 0x04, 0x81, 0x77, 0xe6, 0x3b, 0x01,
+	0xd3, 0x56, 0x79, 0x90, 0xc5, 0x90, 0x84, 0x07, 0x83, 0xfe, 0x19, 0x1b, 0x4c, 0x46, 0x4c, 0x53,
+	0x39, 0xdb, 0xd4, 0x83, 0xa6, 0xc4, 0x31, 0x05, 0x82, 0xbe, 0x33, 0x62, 0xda, 0x1a, 0xd7, 0x45,
+	0x6c, 0xb8, 0x0f, 0xc2, 0x61, 0xff, 0xf5, 0xa5, 0x46, 0xa4, 0x0f, 0xf8, 0x8e, 0x7c, 0x00, 0xd9,
+	0x53, 0x0c, 0x70, 0xed, 0x0e, 0xb7, 0x7e, 0x63, 0xc1, 0x7a, 0x1e, 0xfe, 0x54, 0x30, 0x61, 0xcd,
+	0xe6, 0x0b, 0x9b, 0x8d, 0x4f, 0x5c, 0xbf, 0xcf, 0x06, 0xda, 0x06, 0x3f, 0xad, 0xcc, 0x51, 0x5d,
+	0x82, 0x68, 0x4f, 0xdf, 0x3d, 0xf7, 0x7c, 0x16, 0x60, 0x01, 0xbb, 0xcb, 0x59, 0x62, 0x08, 0xd9,
+	0x84, 0x42, 0xdf, 0x09, 0xfa, 0xce, 0x80, 0x0d, 0x34, 0x8d, 0x53, 0xa7, 0x7b, 0xa2, 0x41, 0xfe,
+	0x4b, 0x77, 0xe2, 0x8f, 0x9d, 0x91, 0x76, 0x8f, 0x93, 0xa2, 0x2d, 0x66, 0xfb, 0xf8, 0x24, 0xd0,
+	0x36, 0x39, 0x8a, 0xcb, 0xff, 0xbd, 0x28, 0x54, 0x01, 0x66, 0xaf, 0x8b, 0x7c, 0x63, 0x77, 0xc0,
+	0x02, 0x4d, 0xd9, 0x4a, 0x23, 0x1f, 0xdf, 0x54, 0xbf, 0x51, 0x60, 0x95, 0x4e, 0xc6, 0xd8, 0xd2,
+	0xcd, 0xd0, 0x09, 0x59, 0xc7, 0xf1, 0xc8, 0x73, 0x28, 0xfb, 0x02, 0xb2, 0x03, 0xc4, 0xb8, 0x44,
+	0x69, 0x77, 0x77, 0x31, 0x76, 0x92, 0x82, 0x89, 0xbd, 0x0c, 0x55, 0x3f, 0x06, 0xa1, 0x45, 0x0b,
+	0x2c, 0x37, 0xb2, 0xe8, 0x5f, 0x05, 0xc8, 0x09, 0x9f, 0x2c, 0x0c, 0x18, 0x4f, 0x20, 0x27, 0x46,
+	0x0f, 0x2e, 0x55, 0xba, 0xa2, 0xe2, 0x88, 0x02, 0x49, 0x25, 0xdb, 0x2c, 0x36, 0xd2, 0x6f, 0x13,
+	0x1b, 0x9b, 0x50, 0xc0, 0x31, 0xc1, 0x1d, 0x8f, 0x2e, 0xe5, 0xd4, 0x31, 0xdd, 0x93, 0xa7, 0x90,
+	0x1f, 0x89, 0x42, 0xcf, 0x6b, 0x53, 0xe9, 0x8a, 0x06, 0x9a, 0x68, 0x07, 0x34, 0x62, 0x27, 0x1f,
+	0x42, 0xb6, 0x8f, 0xee, 0xd0, 0x72, 0xd7, 0xb6, 0x7e, 0xc1, 0x48, 0x9e, 0x40, 0x26, 0xf0, 0x58,
+	0x5f, 0xcb, 0x2f, 0x49, 0xe7, 0x59, 0xe1, 0xa0, 0x9c, 0x11, 0x9d, 0x39, 0x09, 0x9c, 0x53, 0x26,
+	0x3b, 0xad, 0xd8, 0x24, 0xe7, 0x8e, 0xe2, 0xdb, 0xcf, 0x1d, 0xb1, 0xc2, 0x0e, 0x6f, 0x57, 0xd8,
+	0x3f, 0xc6, 0xd4, 0x74, 0xc2, 0x49, 0xc0, 0xcb, 0x53, 0x65, 0xf7, 0xe1, 0x32, 0x95, 0x39, 0x13,
+	0x95, 0xcc, 0x64, 0x17, 0xb2, 0x22, 0xf6, 0x56, 0xb8, 0xd4, 0x83, 0xef, 0x90, 0x62, 0x54, 0xb0,
+	0x92, 0xc7, 0x50, 0x72, 0xc2, 0xd0, 0xc1, 0x52, 0x61, 0xbb, 0x63, 0x5e, 0xad, 0x8a, 0x14, 0x22,
+	0xa8, 0x37, 0x26, 0x0d, 0xa8, 0x4c, 0x19, 0xc4, 0xe9, 0x95, 0x25, 0xa7, 0xd7, 0x39, 0x9b, 0x38,
+	0xbd, 0x1c, 0xc9, 0x98, 0xd1, 0x2d, 0x03, 0x76, 0x31, 0xec, 0x33, 0x9b, 0x0f, 0xb4, 0xb2, 0x9e,
+	0x09, 0xe8, 0x10, 0xc7, 0xda, 0x0f, 0x80, 0x04, 0xac, 0x3f, 0xf1, 0x99, 0x1d, 0xe7, 0x8b, 0x0a,
+	0x1a, 0xa7, 0x34, 0x67, 0xdc, 0x53, 0xa5, 0x05, 0xdb, 0xda, 0x56, 0x7a, 0xa6, 0x34, 0x67, 0x38,
+	0x98, 0x32, 0x0c, 0xc7, 0x27, 0xae, 0x46, 0x78, 0x2e, 0xbe, 0xbf, 0xc4, 0x1f, 0x52, 0x71, 0x63,
+	0x7c, 0xe2, 0x8a, 0x04, 0x04, 0x67, 0x0a, 0x90, 0x9f, 0xc1, 0x4a, 0xac, 0x23, 0x04, 0xda, 0xed,
+	0xad, 0xf4, 0x95, 0x31, 0x14, 0x6b, 0x09, 0xa5, 0x59, 0x4b, 0x08, 0x88, 0x3e, 0x5f, 0x17, 0xd6,
+	0xf9, 0x01, 0x5b, 0xd7, 0xd5, 0x85, 0x64, 0x15, 0xc0, 0x88, 0x64, 0xbe, 0xef, 0xfa, 0xbc, 0x28,
+	0x17, 0xa9, 0xd8, 0x60, 0x6b, 0xf6, 0x26, 0xfe, 0x29, 0xb3, 0x79, 0x3e, 0x6c, 0x5c, 0x1b, 0x92,
+	0x45, 0xce, 0x8d, 0xfb, 0xcd, 0x9f, 0xc2, 0xea, 0x9c, 0xd9, 0x37, 0x2a, 0x2a, 0x7f, 0x49, 0x41,
+	0x16, 0x35, 0x0b, 0x90, 0x07, 0x93, 0x3a, 0xe0, 0x72, 0x19, 0x2a, 0x36, 0xe4, 0x2e, 0xe4, 0x71,
+	0x61, 0x9f, 0x07, 0x72, 0xc4, 0xc9, 0xe1, 0xb6, 0x13, 0xe0, 0xcc, 0xc2, 0x09, 0xaf, 0x2e, 0x43,
+	0x16, 0xf0, 0x32, 0x92, 0xa1, 0x45, 0x44, 0xf6, 0x10, 0xc0, 0xa6, 0xc4, 0x3f, 0x3b, 0x02, 0x5e,
+	0x30, 0x32, 0x54, 0xee, 0x70, 0x96, 0xe1, 0x2b, 0x3c, 0x50, 0x7c, 0xaa, 0xe4, 0xf9, 0xbe, 0x13,
+	0x60, 0x30, 0x08, 0x92, 0x38, 0x32, 0xc7, 0xa9, 0xc0, 0x21, 0x71, 0xe6, 0x63, 0x28, 0x89, 0x01,
+	0xe6, 0x14, 0x9b, 0x8d, 0x1c, 0xab, 0x81, 0x4f, 0x29, 0x1c, 0x21, 0xb7, 0x21, 0x3b, 0x74, 0xf1,
+	0xe4, 0x42, 0xf4, 0x11, 0x24, 0x14, 0xe5, 0x07, 0xda, 0xfc, 0x33, 0x45, 0x7c, 0xba, 0x14, 0x39,
+	0xc2, 0xe7, 0x6e, 0x3c, 0x54, 0x4e, 0x28, 0x28, 0x09, 0xf2, 0x50, 0x09, 0x75, 0x82, 0xea, 0xbf,
+	0x53, 0x90, 0xad, 0x8f, 0x98, 0x1f, 0xc6, 0xaa, 0x6e, 0x9a, 0x57, 0xdd, 0x4f, 0xf1, 0x0b, 0xea,
+	0x82, 0xf9, 0xc3, 0xf0, 0x52, 0x4b, 0x2d, 0xc9, 0x6f, 0x53, 0x32, 0xf0, 0xb2, 0x30, 0x65, 0x47,
+	0xa5, 0x1c, 0x3c, 0xd3, 0x0e, 0x2f, 0x3d, 0xc6, 0xbd, 0x97, 0xa6, 0x45, 0x8e, 0x20, 0x23, 0x76,
+	0xca, 0x73, 0x16, 0xf0, 0xca, 0x25, 0x3e, 0x2d, 0xa2, 0x2d, 0x79, 0x0a, 0xc5, 0xe9, 0x17, 0xa8,
+	0x96, 0xbd, 0x3e, 0x50, 0xa6, 0xcc, 0x68, 0xa8, 0x2f, 0x3f, 0x50, 0xed, 0xe1, 0x80, 0xbb, 0xb7,
+	0x48, 0x21, 0x82, 0x0c, 0x6e, 0x4e, 0xb4, 0xd3, 0xf2, 0x4b, 0xcc, 0x89, 0x3e, 0x71, 0x85, 0x39,
+	0x11, 0x3b, 0xea, 0xdb, 0x1f, 0x31, 0x3e, 0x87, 0x89, 0x01, 0x31, 0xda, 0x62, 0x2c, 0x86, 0xe1,
+	0x48, 0xba, 0x1d, 0x97, 0x68, 0xfa, 0x64, 0x3c, 0x7c, 0x33, 0x61, 0x76, 0xe8, 0x9c, 0x72, 0x7f,
+	0x17, 0x69, 0x51, 0x20, 0x96, 0x73, 0x5a, 0xfd, 0x04, 0x72, 0xdc, 0xdb, 0x01, 0xf6, 0x28, 0xee,
+	0x11, 0xd9, 0x81, 0x17, 0x7b, 0x14, 0xe7, 0xa3, 0x82, 0xa9, 0xfa, 0x57, 0x05, 0x6e, 0x8b, 0x32,
+	0xd0, 0xf0, 0x19, 0x56, 0x2e, 0xf6, 0x66, 0xc2, 0x82, 0x30, 0xde, 0x9f, 0x94, 0x9b, 0xf5, 0xa7,
+	0x1b, 0x37, 0xd5, 0xa8, 0x3d, 0xa5, 0xdf, 0xb2, 0x3d, 0x55, 0xdf, 0x83, 0x8a, 0xc0, 0x28, 0x0b,
+	0x3c, 0x77, 0x1c, 0xc4, 0xca, 0x83, 0x12, 0x2b, 0x0f, 0x55, 0x0f, 0xd6, 0x93, 0xa6, 0x49, 0xee,
+	0xf9, 0x31, 0xe0, 0x00, 0x56, 0xe5, 0x84, 0xed, 0x4b, 0x16, 0xa9, 0xfa, 0xe3, 0x25, 0xba, 0x44,
+	0x27, 0xd1, 0xca, 0x45, 0x62, 0x5f, 0xfd, 0x56, 0x89, 0xe6, 0x2f, 0x5e, 0xb6, 0xea, 0x7d, 0x9c,
+	0x77, 0xc9, 0x33, 0xc8, 0x89, 0x8a, 0xca, 0xef, 0xac, 0xec, 0x56, 0x97, 0x1c, 0x2b, 0xd8, 0x0f,
+	0x1d, 0xdf, 0x39, 0xa7, 0x52, 0x82, 0x3c, 0x85, 0xec, 0xb9, 0x3b, 0x19, 0x87, 0x5a, 0xea, 0xad,
+	0x45, 0x85, 0x00, 0x06, 0x0c, 0x5f, 0x88, 0x1e, 0x91, 0x16, 0x01, 0xc3, 0x91, 0xa8, 0x87, 0xc4,
+	0x5b, 0x4d, 0x66, 0xbe, 0x25, 0x55, 0xff, 0x9e, 0x02, 0x55, 0xda, 0xc2, 0xc2, 0xef, 0x23, 0x2c,
+	0xc4, 0x2b, 0xa7, 0xde, 0x76, 0x08, 0x41, 0xaf, 0x71, 0xab, 0x64, 0x60, 0x54, 0xbf, 0xab, 0x9d,
+	0x0b, 0xfb, 0xa9, 0x94, 0x20, 0x07, 0x90, 0x77, 0x3d, 0x5c, 0x61, 0x1d, 0xc5, 0x2c, 0xd8, 0x59,
+	0x26, 0x3c, 0x35, 0x6d, 0xa7, 0x27, 0x04, 0x44, 0x0b, 0x8c, 0xc4, 0x37, 0x9f, 0xc1, 0x4a, 0x9c,
+	0x70, 0xa3, 0x26, 0xf1, 0x87, 0x59, 0x34, 0xb0, 0x30, 0x8a, 0x11, 0xcc, 0x0f, 0x11, 0x35, 0x9a,
+	0xb2, 0x24, 0x3f, 0x64, 0x90, 0x49, 0xb6, 0xef, 0x31, 0x3c, 0x2f, 0x61, 0xcd, 0x1c, 0x3b, 0x5e,
+	0x32, 0xd3, 0xe7, 0xb3, 0x21, 0xf6, 0xc4, 0xa9, 0x9b, 0x3d, 0x71, 0x7c, 0xde, 0x4d, 0x27, 0xe7,
+	0xdd, 0xea, 0x1b, 0x20, 0xf1, 0xab, 0xa5, 0x2f, 0x7e, 0x05, 0x1b, 0xd2, 0xb4, 0x3e, 0x27, 0xcc,
+	0x2c, 0x14, 0xbe, 0x79, 0x77, 0xc9, 0xd5, 0xc9, 0x63, 0xe8, 0xfa, 0xc5, 0x15, 0x68, 0x35, 0x8c,
+	0x7e, 0x8f, 0xe0, 0x83, 0xcc, 0x7d, 0x28, 0xca, 0xab, 0xa6, 0xd6, 0x16, 0x04, 0x60, 0x5c, 0xfd,
+	0x4b, 0xe3, 0xc7, 0x90, 0x97, 0x17, 0xbf, 0x4d, 0x65, 0x8a, 0x78, 0xab, 0x03, 0x20, 0xfb, 0xbe,
+	0xe3, 0x9d, 0x35, 0xfd, 0xe1, 0x05, 0xf3, 0x1b, 0x67, 0xce, 0xf8, 0x94, 0x05, 0xd3, 0x0b, 0x94,
+	0xd8, 0x05, 0xcf, 0x20, 0xf3, 0x7a, 0x38, 0x1e, 0xc8, 0xcc, 0x7e, 0xef, 0x8a, 0x6f, 0x89, 0xb9,
+	0x63, 0x78, 0xf7, 0xe0, 0x32, 0xd5, 0xf7, 0x61, 0xb5, 0x31, 0x9a, 0x04, 0x21, 0xf3, 0xaf, 0xa9,
+	0x81, 0x7f, 0x56, 0xa0, 0x8c, 0xc9, 0x71, 0x31, 0x7d, 0xef, 0x03, 0x28, 0x50, 0xf6, 0x86, 0x05,
+	0xe1, 0x67, 0xc7, 0xb2, 0x45, 0x7c, 0xb0, 0xd8, 0x22, 0xe2, 0x12, 0x3b, 0x11, 0xbb, 0x48, 0x8d,
+	0xa9, 0xf4, 0xe6, 0x8f, 0xa1, 0x9c, 0x20, 0xc5, 0x93, 0x23, 0x7d, 0x5d, 0x72, 0x7c, 0x05, 0x95,
+	0xc4, 0x2d, 0x01, 0xa9, 0xc2, 0x8a, 0x5c, 0x37, 0x78, 0xc5, 0x13, 0xc7, 0x24, 0x30, 0xd2, 0x9c,
+	0xb3, 0x46, 0xfe, 0x96, 0xf6, 0xe8, 0xbb, 0x2d, 0xa0, 0x49, 0xa1, 0xda, 0xb7, 0x29, 0xc8, 0x89,
+	0x6f, 0x07, 0xb2, 0x0a, 0x25, 0xd3, 0xaa, 0x5b, 0x47, 0xa6, 0xdd, 0xed, 0x75, 0x75, 0xf5, 0x56,
+	0x0c, 0x30, 0xba, 0x86, 0xa5, 0x2a, 0xa4, 0x0c, 0x45, 0x09, 0xf4, 0x3e, 0x53, 0x53, 0x84, 0x40,
+	0x25, 0xda, 0xb6, 0x5a, 0x6d, 0xa3, 0xab, 0xab, 0x69, 0xa2, 0xc2, 0x8a, 0xc4, 0x74, 0x4a, 0x7b,
+	0x54, 0xcd, 0x10, 0x0d, 0xd6, 0xa7, 0xc7, 0x5a, 0xb6, 0xd1, 0xb5, 0x3f, 0x3f, 0xea, 0xd1, 0xa3,
+	0x8e, 0x9a, 0x25, 0x77, 0xe1, 0xb6, 0xa4, 0x34, 0xf5, 0x46, 0xaf, 0xd3, 0x31, 0x4c, 0xd3, 0xe8,
+	0x75, 0xd5, 0x1c, 0xd9, 0x00, 0x22, 0x09, 0x9d, 0xba, 0xd1, 0xb5, 0xf4, 0x6e, 0xbd, 0xdb, 0xd0,
+	0xd5, 0x7c, 0x4c, 0xc0, 0xb4, 0x7a, 0xb4, 0xbe, 0xaf, 0xdb, 0xcd, 0xde, 0xf3, 0xae, 0x5a, 0x20,
+	0xf7, 0xe1, 0xee, 0x3c, 0x41, 0xdf, 0xa7, 0xf5, 0xa6, 0xde, 0x54, 0x8b, 0x31, 0xa9, 0xae, 0xae,
+	0x37, 0x4d, 0x9b, 0xea, 0x7b, 0xbd, 0x9e, 0xa5, 0x02, 0x79, 0x00, 0xda, 0x9c, 0x14, 0xd5, 0xf7,
+	0xea, 0x6d, 0x7e, 0x59, 0x89, 0x6c, 0xc1, 0x83, 0xf9, 0x33, 0xa9, 0x71, 0x8c, 0x3c, 0x87, 0xed,
+	0x7a, 0x43, 0x57, 0x57, 0x48, 0x05, 0x60, 0xaa, 0xe6, 0x0b, 0xb5, 0x5c, 0xfb, 0x5a, 0x01, 0x10,
+	0x41, 0xca, 0x47, 0xb0, 0x75, 0x50, 0xb9, 0x04, 0xb5, 0xad, 0x97, 0x87, 0x7a, 0xe4, 0xd4, 0x39,
+	0xb4, 0x65, 0xb4, 0x75, 0x55, 0x21, 0x77, 0x60, 0x2d, 0x8e, 0xee, 0xb5, 0x7b, 0x0d, 0xf4, 0xf0,
+	0x06, 0x90, 0x38, 0xdc, 0xdb, 0xfb, 0xa5, 0xde, 0xb0, 0xd4, 0x34, 0xb9, 0x07, 0x77, 0xe2, 0x78,
+	0xa3, 0x7d, 0x64, 0x5a, 0x3a, 0xd5, 0x9b, 0x6a, 0x66, 0xfe, 0xa4, 0x7d, 0x5a, 0x3f, 0x3c, 0x50,
+	0xb3, 0xb5, 0x3f, 0x29, 0x90, 0x13, 0x9f, 0x96, 0xf8, 0x44, 0x2d, 0x33, 0xa1, 0xd3, 0x1a, 0x94,
+	0x23, 0x64, 0xcf, 0xa2, 0x2d, 0x53, 0x55, 0xe2, 0x4c, 0xfa, 0x0b, 0xeb, 0x23, 0x35, 0x15, 0x47,
+	0x5a, 0x47, 0x26, 0xbe, 0xf5, 0x2a, 0x94, 0xa6, 0x07, 0xb5, 0x4c, 0x35, 0x13, 0x07, 0x8e, 0x5b,
+	0xa6, 0x9a, 0x8d, 0x03, 0x2f, 0x5a, 0xa6, 0x9a, 0x8b, 0x03, 0x5f, 0xb4, 0x4c, 0x35, 0x5f, 0xfb,
+	0x46, 0x81, 0x3b, 0x57, 0x66, 0x37, 0x79, 0x07, 0x1e, 0x72, 0xe5, 0x6d, 0x69, 0x4e, 0xe3, 0xa0,
+	0xde, 0xdd, 0xd7, 0x13, 0x7a, 0xbf, 0x0b, 0xef, 0x2c, 0x65, 0xe9, 0xf4, 0x9a, 0x46, 0xcb, 0xd0,
+	0x9b, 0xaa, 0x42, 0xaa, 0xf0, 0x68, 0x29, 0x5b, 0xbd, 0x89, 0x41, 0x92, 0x22, 0xff, 0x07, 0x5b,
+	0x4b, 0x79, 0x9a, 0x7a, 0x5b, 0xb7, 0xf4, 0xa6, 0x9a, 0xae, 0x85, 0xb0, 0x12, 0x1f, 0xc7, 0x79,
+	0xa0, 0xea, 0xc7, 0x3a, 0x35, 0xac, 0x97, 0x09, 0xc5, 0x30, 0xe4, 0x12, 0x78, 0xbd, 0x5d, 0xa7,
+	0x1d, 0x55, 0xc1, 0x87, 0x4b, 0x12, 0x9e, 0xd7, 0x69, 0xd7, 0xe8, 0xee, 0xab, 0x29, 0x9e, 0x27,
+	0x73, 0x67, 0x59, 0x46, 0xeb, 0xa5, 0x9a, 0xae, 0xfd, 0x5e, 0xc1, 0x72, 0x30, 0x1b, 0x9b, 0xf1,
+	0x5a, 0xaa, 0x9b, 0xbd, 0x23, 0xda, 0x48, 0xfa, 0x43, 0x83, 0xf5, 0x24, 0x7e, 0xdc, 0x6b, 0x1f,
+	0x75, 0x30, 0xbe, 0xae, 0x90, 0x68, 0xea, 0x6a, 0x0a, 0xf5, 0x49, 0xe2, 0x32, 0x94, 0xd4, 0x34,
+	0xda, 0x90, 0x24, 0x71, 0xcf, 0xa8, 0x99, 0xda, 0xef, 0x14, 0x58, 0xe5, 0x83, 0xb3, 0x98, 0x34,
+	0xb8, 0x46, 0x9b, 0xb0, 0x51, 0x6f, 0xeb, 0xd4, 0xb2, 0xeb, 0x0d, 0xcb, 0xe8, 0x75, 0x13, 0x5a,
+	0x3d, 0x00, 0x6d, 0x91, 0x26, 0x7c, 0xaa, 0x2a, 0x57, 0x53, 0x1b, 0x54, 0xaf, 0x5b, 0xa8, 0xdf,
+	0x95, 0xd4, 0xa3, 0xc3, 0x26, 0x52, 0xd3, 0xb5, 0x2f, 0xa3, 0xa1, 0x22, 0x36, 0xf3, 0xa1, 0x88,
+	0x30, 0x3b, 0x92, 0x39, 0xac, 0xd3, 0x7a, 0x27, 0x52, 0xe6, 0x3e, 0xdc, 0xbd, 0x8a, 0xda, 0x6b,
+	0xb5, 0x54, 0x05, 0xad, 0xb8, 0x92, 0xd8, 0x55, 0x53, 0xb5, 0x5d, 0xc8, 0xcb, 0xdf, 0xc2, 0x49,
+	0x01, 0x32, 0xf2, 0xb4, 0x3c, 0xa4, 0xdb, 0xbd, 0xe7, 0xaa, 0x42, 0x00, 0x72, 0x1d, 0xbd, 0x69,
+	0x1c, 0x75, 0xd4, 0x14, 0x92, 0x0f, 0x8c, 0xfd, 0x03, 0x35, 0x5d, 0xfb, 0x2d, 0x14, 0xa7, 0x3f,
+	0x86, 0xa3, 0xab, 0x8d, 0x9e, 0x7d, 0x48, 0x7b, 0x98, 0xf2, 0xb6, 0xa9, 0x7f, 0x7e, 0xa4, 0x77,
+	0x2d, 0xa3, 0xde, 0x56, 0x6f, 0x61, 0xce, 0xc6, 0x48, 0xb4, 0xde, 0x6d, 0xf6, 0x30, 0x58, 0xd6,
+	0xa0, 0x1c, 0x83, 0x9b, 0x7b, 0x22, 0x48, 0x12, 0x90, 0x4d, 0xf5, 0x4e, 0x0f, 0x7d, 0x81, 0xc5,
+	0x38, 0x46, 0x69, 0x74, 0x4c, 0x35, 0x53, 0xfb, 0x3a, 0x05, 0xa5, 0xd8, 0x64, 0x88, 0xf7, 0x48,
+	0xfb, 0xb0, 0x6e, 0xc5, 0xc3, 0x26, 0x01, 0x1f, 0xea, 0xdd, 0x26, 0xc6, 0x64, 0xdc, 0x21, 0x82,
+	0x52, 0x3f, 0xae, 0x1b, 0xed, 0xfa, 0x5e, 0x5b, 0x86, 0x4e, 0x92, 0x66, 0x59, 0xf5, 0xc6, 0x01,
+	0xa6, 0xc9, 0x02, 0xa9, 0xa9, 0x4b, 0x52, 0x26, 0xe6, 0xff, 0x19, 0xc9, 0x6a, 0x1c, 0xe0, 0x75,
+	0x59, 0x8c, 0xd2, 0x04, 0x51, 0xb4, 0x90, 0xdc, 0x82, 0x82, 0x51, 0x42, 0xe6, 0xc9, 0x23, 0xd8,
+	0x4c, 0x50, 0x2c, 0xfa, 0x52, 0xde, 0x86, 0x27, 0x16, 0x16, 0x24, 0xa9, 0x8e, 0xc5, 0x5c, 0x57,
+	0x8b, 0xb5, 0x3f, 0x2a, 0xb0, 0x12, 0xff, 0xe9, 0x6c, 0xee, 0xf2, 0x59, 0x17, 0x7c, 0x08, 0xf7,
+	0xe6, 0x71, 0xcb, 0x3e, 0xa4, 0xba, 0xa9, 0x77, 0xb1, 0x27, 0xae, 0x83, 0x9a, 0x24, 0x1f, 0x1d,
+	0x8a, 0xc2, 0x9d, 0x44, 0x79, 0xa3, 0x4a, 0xcf, 0x39, 0xf4, 0xc8, 0x9c, 0xf5, 0xa9, 0x4c, 0xed,
+	0xd7, 0x50, 0x4e, 0xfc, 0xa3, 0x50, 0x74, 0x35, 0xd1, 0x7a, 0x44, 0x70, 0xd9, 0x9d, 0xfa, 0x7e,
+	0x57, 0xb7, 0x8c, 0x86, 0x7a, 0x4b, 0xf4, 0xc8, 0x04, 0xd1, 0x34, 0xb1, 0xd8, 0xf1, 0x6e, 0x97,
+	0xc0, 0xbb, 0xc7, 0x1d, 0x5d, 0x4d, 0xd5, 0xb6, 0xa1, 0x2c, 0xe7, 0xa4, 0xae, 0x1b, 0x0e, 0x4f,
+	0x2e, 0x91, 0x53, 0x66, 0xbb, 0x2c, 0x35, 0x42, 0xc9, 0x5b, 0x35, 0x06, 0xa5, 0xd8, 0x0f, 0x78,
+	0xf8, 0x9a, 0xe2, 0x6d, 0xa3, 0x57, 0x79, 0x61, 0xe9, 0xb4, 0xcb, 0x03, 0x77, 0x9e, 0x64, 0x74,
+	0x25, 0x49, 0xc1, 0xf6, 0x79, 0x25, 0xc9, 0x36, 0x9f, 0x1b, 0x56, 0xe3, 0x40, 0x4d, 0xd5, 0x2c,
+	0xa8, 0xf4, 0x3c, 0xe6, 0xf3, 0x7f, 0x84, 0xb4, 0x46, 0xce, 0x29, 0xfe, 0x80, 0xa4, 0xf6, 0x0e,
+	0xed, 0x56, 0xbb, 0xbe, 0x6f, 0xda, 0x47, 0xdd, 0xcf, 0xba, 0x5c, 0x1d, 0x4c, 0x83, 0x29, 0xca,
+	0xdf, 0x84, 0x97, 0xd1, 0x29, 0x24, 0x9e, 0xdb, 0x6e, 0xf5, 0x68, 0x43, 0x57, 0x53, 0x7b, 0x0f,
+	0xe0, 0x76, 0xdf, 0x3d, 0x9f, 0x1f, 0x82, 0x0e, 0x95, 0x2f, 0xd2, 0x8e, 0x37, 0x7c, 0x95, 0xe3,
+	0xbf, 0x70, 0xfc, 0xf0, 0xbf, 0x03, 0x00, 0xfb, 0x0b, 0x19, 0x00, 0xb5, 0x1f, 0x00, 0x00,
 }
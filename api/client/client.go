@@ -84,9 +84,16 @@ type Client struct {
 	httpClient  *http.Client
 	authstring  string
 	accesstoken string
+	token       string
 	userAgent   string
 }
 
+// SetToken sets the bearer token sent with every request to authenticate
+// the client.
+func (c *Client) SetToken(token string) {
+	c.token = token
+}
+
 func (c *Client) SetTLS(tlsConfig *tls.Config) {
 	c.httpClient = &http.Client{
 		Transport: &http.Transport{TLSClientConfig: tlsConfig},
@@ -109,22 +116,26 @@ func (c *Client) Versions(endpoint string) ([]string, error) {
 
 // Get returns a Request object setup for GET call.
 func (c *Client) Get() *Request {
-	return NewRequest(c.httpClient, c.base, "GET", c.version, c.authstring, c.userAgent)
+	return NewRequest(c.httpClient, c.base, "GET", c.version, c.authstring, c.userAgent).
+		BearerToken(c.token)
 }
 
 // Post returns a Request object setup for POST call.
 func (c *Client) Post() *Request {
-	return NewRequest(c.httpClient, c.base, "POST", c.version, c.authstring, c.userAgent)
+	return NewRequest(c.httpClient, c.base, "POST", c.version, c.authstring, c.userAgent).
+		BearerToken(c.token)
 }
 
 // Put returns a Request object setup for PUT call.
 func (c *Client) Put() *Request {
-	return NewRequest(c.httpClient, c.base, "PUT", c.version, c.authstring, c.userAgent)
+	return NewRequest(c.httpClient, c.base, "PUT", c.version, c.authstring, c.userAgent).
+		BearerToken(c.token)
 }
 
 // Delete returns a Request object setup for DELETE call.
 func (c *Client) Delete() *Request {
-	return NewRequest(c.httpClient, c.base, "DELETE", c.version, c.authstring, c.userAgent)
+	return NewRequest(c.httpClient, c.base, "DELETE", c.version, c.authstring, c.userAgent).
+		BearerToken(c.token)
 }
 
 func unix2HTTP(u *url.URL) {
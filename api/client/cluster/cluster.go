@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"github.com/libopenstorage/openstorage/api"
 	"github.com/libopenstorage/openstorage/api/client"
 	"github.com/libopenstorage/openstorage/cluster"
 )
@@ -63,3 +64,13 @@ func GetSupportedClusterVersions(host string) ([]string, error) {
 	}
 	return versions, nil
 }
+
+// EnumerateAlertTypes returns the catalogue of alert types registered with
+// the cluster.
+func EnumerateAlertTypes(c *client.Client) ([]*api.AlertType, error) {
+	var types []*api.AlertType
+	if err := c.Get().Resource(clusterPath + "/alerttypes").Do().Unmarshal(&types); err != nil {
+		return nil, err
+	}
+	return types, nil
+}
@@ -34,6 +34,7 @@ type Request struct {
 	timeout     time.Duration
 	authstring  string
 	accesstoken string
+	token       string
 }
 
 // Response is a representation of HTTP response received from the server.
@@ -144,6 +145,12 @@ func (r *Request) SetHeader(key, value string) *Request {
 	return r
 }
 
+// BearerToken authenticates the request with the specified token.
+func (r *Request) BearerToken(token string) *Request {
+	r.token = token
+	return r
+}
+
 // Timeout makes the request use the given duration as a timeout. Sets the "timeout"
 // parameter.
 func (r *Request) Timeout(d time.Duration) *Request {
@@ -270,6 +277,10 @@ func (r *Request) Do() *Response {
 		req.Header.Set("Access-Token", r.accesstoken)
 	}
 
+	if len(r.token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+
 	resp, err = r.client.Do(req)
 	if err != nil {
 		return &Response{err: err}
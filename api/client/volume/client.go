@@ -10,6 +10,7 @@ import (
 	"io"
 	"io/ioutil"
 	"strconv"
+	"strings"
 )
 
 const (
@@ -159,9 +160,65 @@ func (v *volumeClient) Inspect(ids []string) ([]*api.Volume, error) {
 // Delete volume.
 // Errors ErrEnoEnt, ErrVolHasSnaps may be returned.
 func (v *volumeClient) Delete(volumeID string) error {
+	return deleteVolume(v.c, volumeID, false)
+}
+
+// deleteErrors are the reasons the server may refuse to delete a volume.
+var deleteErrors = []error{
+	volume.ErrVolSticky,
+	volume.ErrVolAttached,
+	volume.ErrVolHasClones,
+}
+
+func deleteVolume(c *client.Client, volumeID string, force bool) error {
 	response := &api.VolumeResponse{}
-	if err := v.c.Delete().Resource(volumePath).Instance(volumeID).Do().Unmarshal(response); err != nil {
-		return err
+	req := c.Delete().Resource(volumePath).Instance(volumeID)
+	if force {
+		req.QueryOption("force", "true")
+	}
+	resp := req.Do()
+	if err := resp.Unmarshal(response); err != nil {
+		return knownError(resp, deleteErrors, err)
+	}
+	if response.Error != "" {
+		return errors.New(response.Error)
+	}
+	return nil
+}
+
+// knownError returns the error out of known that the server replied with
+// in resp, or err if it replied with another error.
+func knownError(resp *client.Response, known []error, err error) error {
+	body, _ := resp.Body()
+	for _, e := range known {
+		if strings.TrimSpace(string(body)) == e.Error() {
+			return e
+		}
+	}
+	return err
+}
+
+// trashErrors are the errors the server replies with to trash requests.
+var trashErrors = []error{
+	volume.ErrNotSupported,
+	volume.ErrEnoEnt,
+	volume.ErrNotInTrash,
+}
+
+func trashEnumerate(c *client.Client) ([]*api.Volume, error) {
+	var volumes []*api.Volume
+	resp := c.Get().Resource(volumePath + "/trash").Do()
+	if err := resp.Unmarshal(&volumes); err != nil {
+		return nil, knownError(resp, trashErrors, err)
+	}
+	return volumes, nil
+}
+
+func trashOp(req *client.Request) error {
+	response := &api.VolumeResponse{}
+	resp := req.Do()
+	if err := resp.Unmarshal(response); err != nil {
+		return knownError(resp, trashErrors, err)
 	}
 	if response.Error != "" {
 		return errors.New(response.Error)
@@ -283,6 +340,43 @@ func (v *volumeClient) Enumerate(locator *api.VolumeLocator,
 	return volumes, nil
 }
 
+// EnumeratePage returns up to pageSize volumes that match filter in volume
+// ID order, continuing after token.
+func (v *volumeClient) EnumeratePage(filter *api.VolumeFilter, pageSize int,
+	token string) (*api.VolumeEnumerateResponse, error) {
+	req := v.c.Get().Resource(volumePath)
+	req.QueryOption(api.OptPageSize, strconv.Itoa(pageSize))
+	if token != "" {
+		req.QueryOption(api.OptPageToken, token)
+	}
+	if filter != nil {
+		if filter.NamePrefix != "" {
+			req.QueryOption(api.OptNamePrefix, filter.NamePrefix)
+		}
+		if len(filter.Labels) != 0 {
+			req.QueryOptionLabel(api.OptLabel, filter.Labels)
+		}
+		if filter.State != api.VolumeState_VOLUME_STATE_NONE {
+			req.QueryOption(api.OptState, filter.State.String())
+		}
+		if filter.Node != "" {
+			req.QueryOption(api.OptNode, filter.Node)
+		}
+		if filter.Driver != "" {
+			req.QueryOption(api.OptDriver, filter.Driver)
+		}
+	}
+	resp := req.Do()
+	if resp.Error() != nil {
+		return nil, resp.FormatError()
+	}
+	page := &api.VolumeEnumerateResponse{}
+	if err := resp.Unmarshal(page); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
 // Enumerate snaps for specified volume
 // Count indicates the number of snaps populated.
 func (v *volumeClient) SnapEnumerate(ids []string,
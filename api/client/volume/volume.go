@@ -12,6 +12,30 @@ func VolumeDriver(c *client.Client) volume.VolumeDriver {
 	return newVolumeClient(c)
 }
 
+// ForceDelete deletes volumeID even if clones depend on it. Sticky and
+// attached volumes are still not deleted.
+func ForceDelete(c *client.Client, volumeID string) error {
+	return deleteVolume(c, volumeID, true)
+}
+
+// TrashEnumerate returns the volumes in the trash of the driver.
+// Errors ErrNotSupported may be returned.
+func TrashEnumerate(c *client.Client) ([]*api.Volume, error) {
+	return trashEnumerate(c)
+}
+
+// TrashRestore moves volumeID out of the trash.
+// Errors ErrNotSupported, ErrEnoEnt, ErrNotInTrash may be returned.
+func TrashRestore(c *client.Client, volumeID string) error {
+	return trashOp(c.Post().Resource(volumePath + "/trash/restore").Instance(volumeID))
+}
+
+// TrashPurge deletes volumeID from the trash.
+// Errors ErrNotSupported, ErrEnoEnt, ErrNotInTrash may be returned.
+func TrashPurge(c *client.Client, volumeID string) error {
+	return trashOp(c.Delete().Resource(volumePath + "/trash").Instance(volumeID))
+}
+
 // NewAuthDriverClient returns a new REST client of the supplied version for specified driver.
 // host: REST endpoint [http://<ip>:<port> OR unix://<path-to-unix-socket>]. default: [unix:///var/lib/osd/<driverName>.sock]
 // version: Volume API version
@@ -0,0 +1,201 @@
+package mgmt
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/libopenstorage/openstorage/api"
+)
+
+type credentialServer struct {
+	*Server
+}
+
+type backupServer struct {
+	*Server
+}
+
+func (s *credentialServer) Create(
+	ctx context.Context,
+	req *CredentialCreateRequest,
+) (*CredentialCreateResponse, error) {
+	if len(req.GetParams()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Credential parameters must be provided")
+	}
+	id, err := s.driver.CredsCreate(req.GetParams())
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+	return &CredentialCreateResponse{CredentialId: id}, nil
+}
+
+func (s *credentialServer) Enumerate(
+	ctx context.Context,
+	req *CredentialEnumerateRequest,
+) (*CredentialEnumerateResponse, error) {
+	creds, err := s.driver.CredsEnumerate()
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+	// Credentials are provider specific and returned as JSON documents.
+	resp := &CredentialEnumerateResponse{Credentials: make(map[string]string)}
+	for id, c := range creds {
+		b, err := json.Marshal(c)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Unable to encode credential %s: %v", id, err)
+		}
+		resp.Credentials[id] = string(b)
+	}
+	return resp, nil
+}
+
+func (s *credentialServer) Delete(
+	ctx context.Context,
+	req *CredentialDeleteRequest,
+) (*CredentialDeleteResponse, error) {
+	if req.GetCredentialId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Credential id must be provided")
+	}
+	if err := s.driver.CredsDelete(req.GetCredentialId()); err != nil {
+		return nil, errorStatus(err)
+	}
+	return &CredentialDeleteResponse{}, nil
+}
+
+func (s *credentialServer) Validate(
+	ctx context.Context,
+	req *CredentialValidateRequest,
+) (*CredentialValidateResponse, error) {
+	if req.GetCredentialId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Credential id must be provided")
+	}
+	if err := s.driver.CredsValidate(req.GetCredentialId()); err != nil {
+		return nil, errorStatus(err)
+	}
+	return &CredentialValidateResponse{}, nil
+}
+
+func (s *backupServer) Create(
+	ctx context.Context,
+	req *BackupCreateRequest,
+) (*BackupCreateResponse, error) {
+	if req.GetVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume id must be provided")
+	}
+	if err := s.driver.Backup(&api.BackupRequest{
+		VolumeID:       req.GetVolumeId(),
+		CredentialUUID: req.GetCredentialId(),
+		Full:           req.GetFull(),
+	}); err != nil {
+		return nil, errorStatus(err)
+	}
+	return &BackupCreateResponse{}, nil
+}
+
+func (s *backupServer) Restore(
+	ctx context.Context,
+	req *BackupRestoreRequest,
+) (*BackupRestoreResponse, error) {
+	if req.GetBackupId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Backup id must be provided")
+	}
+	resp := s.driver.BackupRestore(&api.BackupRestoreRequest{
+		CloudBackupID:     req.GetBackupId(),
+		RestoreVolumeName: req.GetRestoreVolumeName(),
+		CredentialUUID:    req.GetCredentialId(),
+		NodeID:            req.GetNodeId(),
+	})
+	if resp.RestoreErr != "" {
+		return nil, status.Error(codes.Internal, resp.RestoreErr)
+	}
+	return &BackupRestoreResponse{RestoreVolumeId: resp.RestoreVolumeID}, nil
+}
+
+func (s *backupServer) Enumerate(
+	ctx context.Context,
+	req *BackupEnumerateRequest,
+) (*BackupEnumerateResponse, error) {
+	backups := s.driver.BackupEnumerate(&api.BackupEnumerateRequest{
+		BackupGenericRequest: api.BackupGenericRequest{
+			SrcVolumeID:    req.GetSrcVolumeId(),
+			ClusterID:      req.GetClusterId(),
+			All:            req.GetAll(),
+			CredentialUUID: req.GetCredentialId(),
+		},
+	})
+	if backups.EnumerateErr != "" {
+		return nil, status.Error(codes.Internal, backups.EnumerateErr)
+	}
+	resp := &BackupEnumerateResponse{}
+	for _, b := range backups.Backups {
+		resp.Backups = append(resp.Backups, &BackupInfo{
+			SrcVolumeId:   b.SrcVolumeID,
+			SrcVolumeName: b.SrcVolumeName,
+			BackupId:      b.BackupID,
+			Timestamp:     toTimestamp(b.Timestamp),
+			Status:        b.Status,
+		})
+	}
+	return resp, nil
+}
+
+func (s *backupServer) Delete(
+	ctx context.Context,
+	req *BackupDeleteRequest,
+) (*BackupDeleteResponse, error) {
+	if err := s.driver.BackupDelete(&api.BackupDeleteRequest{
+		BackupGenericRequest: api.BackupGenericRequest{
+			SrcVolumeID:    req.GetSrcVolumeId(),
+			ClusterID:      req.GetClusterId(),
+			All:            req.GetAll(),
+			CredentialUUID: req.GetCredentialId(),
+		},
+	}); err != nil {
+		return nil, errorStatus(err)
+	}
+	return &BackupDeleteResponse{}, nil
+}
+
+func (s *backupServer) Status(
+	ctx context.Context,
+	req *BackupStatusRequest,
+) (*BackupStatusResponse, error) {
+	sts := s.driver.BackupStatus(&api.BackupStsRequest{
+		SrcVolumeID: req.GetSrcVolumeId(),
+		Local:       req.GetLocal(),
+	})
+	if sts.StsErr != "" {
+		return nil, status.Error(codes.Internal, sts.StsErr)
+	}
+	resp := &BackupStatusResponse{Statuses: make(map[string]*BackupStatus)}
+	for id, st := range sts.Statuses {
+		resp.Statuses[id] = &BackupStatus{
+			OpType:        st.OpType,
+			Status:        st.Status,
+			BytesDone:     st.BytesDone,
+			StartTime:     toTimestamp(st.StartTime),
+			CompletedTime: toTimestamp(st.CompletedTime),
+			BackupId:      st.BackupID,
+			NodeId:        st.NodeID,
+		}
+	}
+	return resp, nil
+}
+
+// toTimestamp converts t, leaving zero times unset.
+func toTimestamp(t time.Time) *timestamp.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	ts, err := ptypes.TimestampProto(t)
+	if err != nil {
+		return nil
+	}
+	return ts
+}
@@ -0,0 +1,155 @@
+package mgmt
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/cluster"
+)
+
+type nodeServer struct {
+	*Server
+}
+
+type alertServer struct {
+	*Server
+}
+
+func (s *nodeServer) Enumerate(
+	ctx context.Context,
+	req *NodeEnumerateRequest,
+) (*NodeEnumerateResponse, error) {
+	c, err := s.getCluster()
+	if err != nil {
+		return nil, err
+	}
+	info, err := c.Enumerate()
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+	resp := &NodeEnumerateResponse{ClusterId: info.Id}
+	for i := range info.Nodes {
+		resp.Nodes = append(resp.Nodes, toNode(&info.Nodes[i]))
+	}
+	return resp, nil
+}
+
+func (s *nodeServer) Inspect(
+	ctx context.Context,
+	req *NodeInspectRequest,
+) (*NodeInspectResponse, error) {
+	if req.GetNodeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Node id must be provided")
+	}
+	c, err := s.getCluster()
+	if err != nil {
+		return nil, err
+	}
+	n, err := c.Inspect(req.GetNodeId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Node id %s not found: %s",
+			req.GetNodeId(), err.Error())
+	}
+	return &NodeInspectResponse{Node: toNode(&n)}, nil
+}
+
+func (s *nodeServer) Remove(
+	ctx context.Context,
+	req *NodeRemoveRequest,
+) (*NodeRemoveResponse, error) {
+	if req.GetNodeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Node id must be provided")
+	}
+	c, err := s.getCluster()
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Remove([]api.Node{{Id: req.GetNodeId()}}, req.GetForce()); err != nil {
+		return nil, errorStatus(err)
+	}
+	return &NodeRemoveResponse{}, nil
+}
+
+func (s *alertServer) Enumerate(
+	ctx context.Context,
+	req *AlertEnumerateRequest,
+) (*AlertEnumerateResponse, error) {
+	c, err := s.getCluster()
+	if err != nil {
+		return nil, err
+	}
+	// An unset start time lists alerts from the beginning and an unset end
+	// time lists alerts up to now.
+	start := time.Time{}
+	if req.GetTimeStart() != nil {
+		if start, err = ptypes.Timestamp(req.GetTimeStart()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid start time: %v", err)
+		}
+	}
+	end := time.Now()
+	if req.GetTimeEnd() != nil {
+		if end, err = ptypes.Timestamp(req.GetTimeEnd()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid end time: %v", err)
+		}
+	}
+	alerts, err := c.EnumerateAlerts(start, end, req.GetResourceType())
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+	return &AlertEnumerateResponse{Alerts: alerts.GetAlert()}, nil
+}
+
+func (s *alertServer) Clear(
+	ctx context.Context,
+	req *AlertClearRequest,
+) (*AlertClearResponse, error) {
+	c, err := s.getCluster()
+	if err != nil {
+		return nil, err
+	}
+	if err := c.ClearAlert(req.GetResourceType(), req.GetAlertId()); err != nil {
+		return nil, errorStatus(err)
+	}
+	return &AlertClearResponse{}, nil
+}
+
+func (s *alertServer) Erase(
+	ctx context.Context,
+	req *AlertEraseRequest,
+) (*AlertEraseResponse, error) {
+	c, err := s.getCluster()
+	if err != nil {
+		return nil, err
+	}
+	if err := c.EraseAlert(req.GetResourceType(), req.GetAlertId()); err != nil {
+		return nil, errorStatus(err)
+	}
+	return &AlertEraseResponse{}, nil
+}
+
+func (s *Server) getCluster() (cluster.Cluster, error) {
+	if s.cluster == nil {
+		return nil, status.Error(codes.Unavailable, "Cluster is not enabled")
+	}
+	return s.cluster, nil
+}
+
+func toNode(n *api.Node) *Node {
+	return &Node{
+		Id:         n.Id,
+		Hostname:   n.Hostname,
+		MgmtIp:     n.MgmtIp,
+		DataIp:     n.DataIp,
+		Status:     n.Status,
+		Cpu:        n.Cpu,
+		MemTotal:   n.MemTotal,
+		MemUsed:    n.MemUsed,
+		MemFree:    n.MemFree,
+		NodeLabels: n.NodeLabels,
+	}
+}
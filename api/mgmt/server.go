@@ -0,0 +1,381 @@
+/*
+Package mgmt is the gRPC management API of OSD. It exposes the volume,
+snapshot, node, alert, credential and backup operations of a driver and
+its cluster, and maps them to JSON over HTTP with grpc-gateway.
+*/
+package mgmt
+
+import (
+	"crypto/tls"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"go.pedge.io/dlog"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/libopenstorage/openstorage/cluster"
+	"github.com/libopenstorage/openstorage/pkg/auth"
+	"github.com/libopenstorage/openstorage/volume"
+	volumedrivers "github.com/libopenstorage/openstorage/volume/drivers"
+)
+
+// ServerConfig provides the configuration to the gRPC management server
+// created by NewServer.
+type ServerConfig struct {
+	// DriverName is the volume driver managed by the server.
+	DriverName string
+	// Cluster serves the node and alert calls. They fail with Unavailable
+	// when it is nil.
+	Cluster cluster.Cluster
+	// Socket is the path of the unix socket. Callers on the socket are
+	// trusted like the callers of the REST unix sockets.
+	Socket string
+	// Address is the TCP address of the server. TCP is disabled when it is
+	// empty.
+	Address string
+	// GatewayAddress is the TCP address serving the JSON mapping of the
+	// API. The gateway forwards calls to Address, which must be set.
+	GatewayAddress string
+	// TLS is the server configuration of the TCP listeners. Plain text is
+	// used when it is nil.
+	TLS *tls.Config
+	// ClientTLS is the configuration used by the gateway to reach the
+	// server when TLS is set.
+	ClientTLS *tls.Config
+	// AuthKey requires callers on the TCP listeners to present a bearer
+	// token signed with the key. Authentication is disabled when it is
+	// empty.
+	AuthKey []byte
+}
+
+// Server is a gRPC management server for a single driver.
+type Server struct {
+	driver  volume.VolumeDriver
+	cluster cluster.Cluster
+	config  ServerConfig
+	local   net.Listener
+	remote  net.Listener
+	gateway net.Listener
+	servers []*grpc.Server
+	http    *http.Server
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	running bool
+	lock    sync.Mutex
+}
+
+// NewServer creates a gRPC management server listening on the unix socket
+// and TCP addresses of config.
+func NewServer(config *ServerConfig) (*Server, error) {
+	if config == nil {
+		return nil, fmt.Errorf("Configuration must be provided")
+	}
+	if len(config.DriverName) == 0 {
+		return nil, fmt.Errorf("OSD Driver name must be provided")
+	}
+	if len(config.Socket) == 0 {
+		return nil, fmt.Errorf("Socket must be provided")
+	}
+	if len(config.GatewayAddress) != 0 && len(config.Address) == 0 {
+		return nil, fmt.Errorf("Gateway requires a TCP address")
+	}
+	d, err := volumedrivers.Get(config.DriverName)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get driver %s info: %s", config.DriverName, err.Error())
+	}
+
+	s := &Server{
+		driver:  d,
+		cluster: config.Cluster,
+		config:  *config,
+	}
+	os.Remove(config.Socket)
+	os.MkdirAll(path.Dir(config.Socket), 0755)
+	if s.local, err = net.Listen("unix", config.Socket); err != nil {
+		return nil, fmt.Errorf("Unable to setup server: %s", err.Error())
+	}
+	if len(config.Address) != 0 {
+		if s.remote, err = net.Listen("tcp", config.Address); err != nil {
+			s.local.Close()
+			return nil, fmt.Errorf("Unable to setup server: %s", err.Error())
+		}
+	}
+	if len(config.GatewayAddress) != 0 {
+		if s.gateway, err = net.Listen("tcp", config.GatewayAddress); err != nil {
+			s.local.Close()
+			s.remote.Close()
+			return nil, fmt.Errorf("Unable to setup gateway: %s", err.Error())
+		}
+	}
+	return s, nil
+}
+
+// Start serves the API on the listeners of the server.
+// It will return an error if the server is already running.
+func (s *Server) Start() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.running {
+		return fmt.Errorf("Server already running")
+	}
+
+	local := s.newGrpcServer()
+	s.serve(local, s.local)
+	s.servers = []*grpc.Server{local}
+	dlog.Infof("Management gRPC server ready on %s", s.local.Addr())
+
+	if s.remote != nil {
+		opts := []grpc.ServerOption{}
+		if s.config.TLS != nil {
+			opts = append(opts, grpc.Creds(credentials.NewTLS(s.config.TLS)))
+		}
+		if len(s.config.AuthKey) != 0 {
+			opts = append(opts, grpc.UnaryInterceptor(s.authenticate))
+		}
+		remote := s.newGrpcServer(opts...)
+		s.serve(remote, s.remote)
+		s.servers = append(s.servers, remote)
+		dlog.Infof("Management gRPC server ready on %s", s.Address())
+	}
+
+	if s.gateway != nil {
+		if err := s.startGateway(); err != nil {
+			for _, srv := range s.servers {
+				srv.Stop()
+			}
+			s.wg.Wait()
+			return err
+		}
+		dlog.Infof("Management gRPC gateway ready on %s", s.GatewayAddress())
+	}
+
+	s.running = true
+	return nil
+}
+
+// Stop stops the server. It does nothing if the server is not running.
+func (s *Server) Stop() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.running {
+		return
+	}
+	if s.http != nil {
+		s.cancel()
+		s.http.Close()
+	}
+	for _, srv := range s.servers {
+		srv.Stop()
+	}
+	s.wg.Wait()
+	s.running = false
+}
+
+// Address returns the TCP address of the server, or an empty string if TCP
+// is disabled.
+func (s *Server) Address() string {
+	if s.remote == nil {
+		return ""
+	}
+	return s.remote.Addr().String()
+}
+
+// GatewayAddress returns the TCP address of the gateway, or an empty string
+// if the gateway is disabled.
+func (s *Server) GatewayAddress() string {
+	if s.gateway == nil {
+		return ""
+	}
+	return s.gateway.Addr().String()
+}
+
+func (s *Server) newGrpcServer(opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts, grpc.MaxConcurrentStreams(math.MaxUint32))
+	srv := grpc.NewServer(opts...)
+	RegisterOpenStorageVolumeServer(srv, &volumeServer{s})
+	RegisterOpenStorageSnapshotServer(srv, &snapshotServer{s})
+	RegisterOpenStorageNodeServer(srv, &nodeServer{s})
+	RegisterOpenStorageAlertServer(srv, &alertServer{s})
+	RegisterOpenStorageCredentialServer(srv, &credentialServer{s})
+	RegisterOpenStorageBackupServer(srv, &backupServer{s})
+	return srv
+}
+
+func (s *Server) serve(srv *grpc.Server, l net.Listener) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := srv.Serve(l); err != nil {
+			dlog.Errorf("Management gRPC server on %s stopped: %v", l.Addr(), err)
+		}
+	}()
+}
+
+// startGateway serves the JSON mapping of the API by forwarding calls to
+// the TCP listener, so that they are authenticated like gRPC callers.
+func (s *Server) startGateway() error {
+	endpoint := fmt.Sprintf("localhost:%d", s.remote.Addr().(*net.TCPAddr).Port)
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if s.config.TLS != nil {
+		opts = []grpc.DialOption{
+			grpc.WithTransportCredentials(credentials.NewTLS(s.config.ClientTLS)),
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mux := runtime.NewServeMux()
+	for _, register := range []func(context.Context, *runtime.ServeMux, string, []grpc.DialOption) error{
+		RegisterOpenStorageVolumeHandlerFromEndpoint,
+		RegisterOpenStorageSnapshotHandlerFromEndpoint,
+		RegisterOpenStorageNodeHandlerFromEndpoint,
+		RegisterOpenStorageAlertHandlerFromEndpoint,
+		RegisterOpenStorageCredentialHandlerFromEndpoint,
+		RegisterOpenStorageBackupHandlerFromEndpoint,
+	} {
+		if err := register(ctx, mux, endpoint, opts); err != nil {
+			cancel()
+			return fmt.Errorf("Unable to setup gateway: %s", err.Error())
+		}
+	}
+
+	s.cancel = cancel
+	s.http = &http.Server{Handler: mux, TLSConfig: s.config.TLS}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		var err error
+		if s.config.TLS != nil {
+			err = s.http.ServeTLS(s.gateway, "", "")
+		} else {
+			err = s.http.Serve(s.gateway)
+		}
+		if err != nil && err != http.ErrServerClosed {
+			dlog.Errorf("Management gRPC gateway stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+// methodRoles is the role required to call each method when authentication
+// is enabled. Methods that are not listed are denied.
+var methodRoles = map[string]auth.Role{
+	"/openstorage.mgmt.OpenStorageVolume/Create":        auth.RoleVolumeAdmin,
+	"/openstorage.mgmt.OpenStorageVolume/Inspect":       auth.RoleReadOnly,
+	"/openstorage.mgmt.OpenStorageVolume/Enumerate":     auth.RoleReadOnly,
+	"/openstorage.mgmt.OpenStorageVolume/Set":           auth.RoleVolumeAdmin,
+	"/openstorage.mgmt.OpenStorageVolume/Delete":        auth.RoleVolumeAdmin,
+	"/openstorage.mgmt.OpenStorageVolume/Attach":        auth.RoleVolumeAdmin,
+	"/openstorage.mgmt.OpenStorageVolume/Detach":        auth.RoleVolumeAdmin,
+	"/openstorage.mgmt.OpenStorageVolume/Mount":         auth.RoleVolumeAdmin,
+	"/openstorage.mgmt.OpenStorageVolume/Unmount":       auth.RoleVolumeAdmin,
+	"/openstorage.mgmt.OpenStorageVolume/Stats":         auth.RoleReadOnly,
+	"/openstorage.mgmt.OpenStorageSnapshot/Create":      auth.RoleVolumeAdmin,
+	"/openstorage.mgmt.OpenStorageSnapshot/Enumerate":   auth.RoleReadOnly,
+	"/openstorage.mgmt.OpenStorageSnapshot/Restore":     auth.RoleVolumeAdmin,
+	"/openstorage.mgmt.OpenStorageNode/Enumerate":       auth.RoleReadOnly,
+	"/openstorage.mgmt.OpenStorageNode/Inspect":         auth.RoleReadOnly,
+	"/openstorage.mgmt.OpenStorageNode/Remove":          auth.RoleClusterAdmin,
+	"/openstorage.mgmt.OpenStorageAlert/Enumerate":      auth.RoleReadOnly,
+	"/openstorage.mgmt.OpenStorageAlert/Clear":          auth.RoleClusterAdmin,
+	"/openstorage.mgmt.OpenStorageAlert/Erase":          auth.RoleClusterAdmin,
+	"/openstorage.mgmt.OpenStorageCredential/Create":    auth.RoleClusterAdmin,
+	"/openstorage.mgmt.OpenStorageCredential/Enumerate": auth.RoleClusterAdmin,
+	"/openstorage.mgmt.OpenStorageCredential/Delete":    auth.RoleClusterAdmin,
+	"/openstorage.mgmt.OpenStorageCredential/Validate":  auth.RoleClusterAdmin,
+	"/openstorage.mgmt.OpenStorageBackup/Create":        auth.RoleVolumeAdmin,
+	"/openstorage.mgmt.OpenStorageBackup/Restore":       auth.RoleVolumeAdmin,
+	"/openstorage.mgmt.OpenStorageBackup/Enumerate":     auth.RoleReadOnly,
+	"/openstorage.mgmt.OpenStorageBackup/Delete":        auth.RoleVolumeAdmin,
+	"/openstorage.mgmt.OpenStorageBackup/Status":        auth.RoleReadOnly,
+}
+
+// authenticate verifies the bearer token in the metadata of a call and the
+// role of the caller before calling the handler.
+func (s *Server) authenticate(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	token := bearerToken(md["authorization"])
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "Missing bearer token")
+	}
+	claims, err := auth.Verify(token, s.config.AuthKey)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	role, ok := methodRoles[info.FullMethod]
+	if !ok || !claims.HasRole(role) {
+		dlog.Warnf("Denied %s to %s", info.FullMethod, claims.Subject)
+		return nil, status.Errorf(codes.PermissionDenied,
+			"Caller %s is not allowed to call %s", claims.Subject, info.FullMethod)
+	}
+	return handler(ctx, req)
+}
+
+func bearerToken(values []string) string {
+	const prefix = "Bearer "
+	for _, h := range values {
+		if len(h) > len(prefix) && strings.EqualFold(h[:len(prefix)], prefix) {
+			return strings.TrimSpace(h[len(prefix):])
+		}
+	}
+	return ""
+}
+
+// errorStatus returns err as a gRPC status error with a code matching the
+// volume driver error.
+func errorStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	var code codes.Code
+	switch err {
+	case volume.ErrEnoEnt:
+		code = codes.NotFound
+	case volume.ErrExist:
+		code = codes.AlreadyExists
+	case volume.ErrEinval, volume.ErrInvalidPageToken:
+		code = codes.InvalidArgument
+	case volume.ErrNotSupported:
+		code = codes.Unimplemented
+	case volume.ErrVolAttached,
+		volume.ErrVolDetached,
+		volume.ErrVolAttachedOnRemoteNode,
+		volume.ErrVolAttachedScale,
+		volume.ErrVolHasSnaps,
+		volume.ErrVolBusy,
+		volume.ErrVolShrink,
+		volume.ErrVolSticky,
+		volume.ErrVolHasClones,
+		cluster.ErrRemoveCausesDataLoss,
+		cluster.ErrNodeRemovePending:
+		code = codes.FailedPrecondition
+	case volume.ErrEnomem, volume.ErrVolSizeExceeded:
+		code = codes.ResourceExhausted
+	case volume.ErrDriverInitializing:
+		code = codes.Unavailable
+	default:
+		code = codes.Internal
+	}
+	return status.Error(code, err.Error())
+}
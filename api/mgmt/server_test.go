@@ -0,0 +1,273 @@
+package mgmt
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kubernetes-csi/csi-test/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/libopenstorage/openstorage/api"
+	mockcluster "github.com/libopenstorage/openstorage/cluster/mock"
+	"github.com/libopenstorage/openstorage/pkg/auth"
+	"github.com/libopenstorage/openstorage/volume"
+	volumedrivers "github.com/libopenstorage/openstorage/volume/drivers"
+	mockdriver "github.com/libopenstorage/openstorage/volume/drivers/mock"
+)
+
+const (
+	mockDriverName = "mock"
+)
+
+var testKey = []byte("mgmt-test-key")
+
+type testServer struct {
+	server *Server
+	dir    string
+	m      *mockdriver.MockVolumeDriver
+	c      *mockcluster.MockCluster
+	mc     *gomock.Controller
+}
+
+func newTestServer(t *testing.T, config ServerConfig) *testServer {
+	s := &testServer{}
+	s.mc = gomock.NewController(&utils.SafeGoroutineTester{})
+	s.m = mockdriver.NewMockVolumeDriver(s.mc)
+	s.c = mockcluster.NewMockCluster(s.mc)
+
+	volumedrivers.Add(mockDriverName, func(map[string]string) (volume.VolumeDriver, error) {
+		return s.m, nil
+	})
+	require.NoError(t, volumedrivers.Register(mockDriverName, nil))
+
+	var err error
+	s.dir, err = ioutil.TempDir("", "mgmt")
+	require.NoError(t, err)
+
+	config.DriverName = mockDriverName
+	config.Socket = path.Join(s.dir, "mgmt.sock")
+	if config.Cluster == nil {
+		config.Cluster = s.c
+	}
+	s.server, err = NewServer(&config)
+	require.NoError(t, err)
+	require.NoError(t, s.server.Start())
+	return s
+}
+
+func (s *testServer) Stop() {
+	volumedrivers.Remove(mockDriverName)
+	s.server.Stop()
+	os.RemoveAll(s.dir)
+	s.mc.Finish()
+}
+
+func (s *testServer) dialLocal(t *testing.T) *grpc.ClientConn {
+	conn, err := grpc.Dial(s.server.config.Socket,
+		grpc.WithInsecure(),
+		grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+			return net.DialTimeout("unix", addr, timeout)
+		}))
+	require.NoError(t, err)
+	return conn
+}
+
+func (s *testServer) dialRemote(t *testing.T) *grpc.ClientConn {
+	conn, err := grpc.Dial(s.server.Address(), grpc.WithInsecure())
+	require.NoError(t, err)
+	return conn
+}
+
+func withToken(t *testing.T, roles ...auth.Role) context.Context {
+	token, err := auth.NewToken(&auth.Claims{Subject: "test", Roles: roles}, testKey)
+	require.NoError(t, err)
+	return metadata.NewOutgoingContext(context.Background(),
+		metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestVolumeCalls(t *testing.T) {
+	s := newTestServer(t, ServerConfig{})
+	defer s.Stop()
+	conn := s.dialLocal(t)
+	defer conn.Close()
+	c := NewOpenStorageVolumeClient(conn)
+	ctx := context.Background()
+
+	_, err := c.Create(ctx, &VolumeCreateRequest{})
+	assert.Equal(t, codes.InvalidArgument, grpc.Code(err))
+
+	locator := &api.VolumeLocator{Name: "vol"}
+	spec := &api.VolumeSpec{Size: 1024}
+	s.m.EXPECT().Create(locator, nil, spec).Return("vol-id", nil)
+	created, err := c.Create(ctx, &VolumeCreateRequest{Locator: locator, Spec: spec})
+	require.NoError(t, err)
+	assert.Equal(t, "vol-id", created.GetVolumeId())
+
+	s.m.EXPECT().Inspect([]string{"vol-id"}).Return([]*api.Volume{{Id: "vol-id"}}, nil)
+	inspected, err := c.Inspect(ctx, &VolumeInspectRequest{VolumeId: "vol-id"})
+	require.NoError(t, err)
+	assert.Equal(t, "vol-id", inspected.GetVolume().GetId())
+
+	s.m.EXPECT().Inspect([]string{"missing"}).Return(nil, nil)
+	_, err = c.Inspect(ctx, &VolumeInspectRequest{VolumeId: "missing"})
+	assert.Equal(t, codes.NotFound, grpc.Code(err))
+
+	s.m.EXPECT().Inspect([]string{"busy"}).Return(nil, nil)
+	s.m.EXPECT().Delete("busy").Return(volume.ErrVolAttached)
+	_, err = c.Delete(ctx, &VolumeDeleteRequest{VolumeId: "busy"})
+	assert.Equal(t, codes.FailedPrecondition, grpc.Code(err))
+
+	s.m.EXPECT().
+		EnumeratePage(&api.VolumeFilter{
+			NamePrefix: "vol",
+			State:      api.VolumeState_VOLUME_STATE_ATTACHED,
+		}, 10, "").
+		Return(&api.VolumeEnumerateResponse{
+			Volumes:   []*api.Volume{{Id: "vol-id"}},
+			NextToken: "next",
+		}, nil)
+	page, err := c.Enumerate(ctx, &VolumeEnumerateRequest{
+		NamePrefix: "vol",
+		State:      api.VolumeState_VOLUME_STATE_ATTACHED,
+		PageSize:   10,
+	})
+	require.NoError(t, err)
+	assert.Len(t, page.GetVolumes(), 1)
+	assert.Equal(t, "next", page.GetNextToken())
+
+	s.m.EXPECT().EnumeratePage(gomock.Any(), 0, "bad").Return(nil, volume.ErrInvalidPageToken)
+	_, err = c.Enumerate(ctx, &VolumeEnumerateRequest{PageToken: "bad"})
+	assert.Equal(t, codes.InvalidArgument, grpc.Code(err))
+}
+
+func TestClusterCalls(t *testing.T) {
+	s := newTestServer(t, ServerConfig{})
+	defer s.Stop()
+	conn := s.dialLocal(t)
+	defer conn.Close()
+	ctx := context.Background()
+
+	s.c.EXPECT().Enumerate().Return(api.Cluster{
+		Id:    "cluster",
+		Nodes: []api.Node{{Id: "node1", Status: api.Status_STATUS_OK}},
+	}, nil)
+	nodes, err := NewOpenStorageNodeClient(conn).Enumerate(ctx, &NodeEnumerateRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "cluster", nodes.GetClusterId())
+	require.Len(t, nodes.GetNodes(), 1)
+	assert.Equal(t, api.Status_STATUS_OK, nodes.GetNodes()[0].GetStatus())
+
+	s.c.EXPECT().
+		EnumerateAlerts(time.Time{}, gomock.Any(), api.ResourceType_RESOURCE_TYPE_VOLUME).
+		Return(&api.Alerts{Alert: []*api.Alert{{Id: 1}}}, nil)
+	alerts, err := NewOpenStorageAlertClient(conn).Enumerate(ctx, &AlertEnumerateRequest{
+		ResourceType: api.ResourceType_RESOURCE_TYPE_VOLUME,
+	})
+	require.NoError(t, err)
+	assert.Len(t, alerts.GetAlerts(), 1)
+
+	s.m.EXPECT().CredsEnumerate().Return(map[string]interface{}{
+		"cred": map[string]string{"provider": "s3"},
+	}, nil)
+	creds, err := NewOpenStorageCredentialClient(conn).Enumerate(ctx, &CredentialEnumerateRequest{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"provider":"s3"}`, creds.GetCredentials()["cred"])
+}
+
+func TestAuthentication(t *testing.T) {
+	s := newTestServer(t, ServerConfig{Address: "127.0.0.1:0", AuthKey: testKey})
+	defer s.Stop()
+	conn := s.dialRemote(t)
+	defer conn.Close()
+	c := NewOpenStorageVolumeClient(conn)
+	req := &VolumeDeleteRequest{VolumeId: "vol-id"}
+
+	_, err := c.Delete(context.Background(), req)
+	assert.Equal(t, codes.Unauthenticated, grpc.Code(err))
+
+	_, err = c.Delete(withToken(t, auth.RoleReadOnly), req)
+	assert.Equal(t, codes.PermissionDenied, grpc.Code(err))
+
+	s.m.EXPECT().Inspect([]string{"vol-id"}).Return(nil, nil).Times(2)
+	s.m.EXPECT().Delete("vol-id").Return(nil).Times(2)
+	_, err = c.Delete(withToken(t, auth.RoleVolumeAdmin), req)
+	assert.NoError(t, err)
+
+	// Callers on the unix socket are trusted.
+	local := s.dialLocal(t)
+	defer local.Close()
+	_, err = NewOpenStorageVolumeClient(local).Delete(context.Background(), req)
+	assert.NoError(t, err)
+}
+
+func TestGateway(t *testing.T) {
+	s := newTestServer(t, ServerConfig{
+		Address:        "127.0.0.1:0",
+		GatewayAddress: "127.0.0.1:0",
+		AuthKey:        testKey,
+	})
+	defer s.Stop()
+	url := "http://" + s.server.GatewayAddress() + "/v1/volumes/inspect"
+
+	post := func(token string) *http.Response {
+		req, err := http.NewRequest("POST", url, bytes.NewBufferString(`{"volume_id":"vol-id"}`))
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := post("")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	token, err := auth.NewToken(&auth.Claims{Subject: "test", Roles: []auth.Role{auth.RoleReadOnly}}, testKey)
+	require.NoError(t, err)
+	s.m.EXPECT().Inspect([]string{"vol-id"}).Return([]*api.Volume{{Id: "vol-id"}}, nil)
+	resp = post(token)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var out struct {
+		Volume struct {
+			Id string `json:"id"`
+		} `json:"volume"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	assert.Equal(t, "vol-id", out.Volume.Id)
+}
+
+func TestNoCluster(t *testing.T) {
+	mc := gomock.NewController(t)
+	defer mc.Finish()
+	s := &Server{driver: mockdriver.NewMockVolumeDriver(mc)}
+	_, err := (&nodeServer{s}).Enumerate(context.Background(), &NodeEnumerateRequest{})
+	assert.Equal(t, codes.Unavailable, grpc.Code(err))
+}
+
+func TestErrorStatus(t *testing.T) {
+	assert.NoError(t, errorStatus(nil))
+	assert.Equal(t, codes.NotFound, grpc.Code(errorStatus(volume.ErrEnoEnt)))
+	assert.Equal(t, codes.AlreadyExists, grpc.Code(errorStatus(volume.ErrExist)))
+	assert.Equal(t, codes.Unimplemented, grpc.Code(errorStatus(volume.ErrNotSupported)))
+	assert.Equal(t, codes.ResourceExhausted, grpc.Code(errorStatus(volume.ErrVolSizeExceeded)))
+	assert.Equal(t, codes.Internal, grpc.Code(errorStatus(os.ErrPermission)))
+	err := status.Error(codes.Aborted, "aborted")
+	assert.Equal(t, err, errorStatus(err))
+}
@@ -0,0 +1,234 @@
+package mgmt
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+type volumeServer struct {
+	*Server
+}
+
+type snapshotServer struct {
+	*Server
+}
+
+func (s *volumeServer) Create(
+	ctx context.Context,
+	req *VolumeCreateRequest,
+) (*VolumeCreateResponse, error) {
+	if req.GetLocator().GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume name must be provided")
+	}
+	if req.GetSpec() == nil {
+		return nil, status.Error(codes.InvalidArgument, "Volume spec must be provided")
+	}
+	id, err := s.driver.Create(req.GetLocator(), req.GetSource(), req.GetSpec())
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+	return &VolumeCreateResponse{VolumeId: id}, nil
+}
+
+func (s *volumeServer) Inspect(
+	ctx context.Context,
+	req *VolumeInspectRequest,
+) (*VolumeInspectResponse, error) {
+	v, err := s.inspect(req.GetVolumeId())
+	if err != nil {
+		return nil, err
+	}
+	return &VolumeInspectResponse{Volume: v}, nil
+}
+
+func (s *volumeServer) Enumerate(
+	ctx context.Context,
+	req *VolumeEnumerateRequest,
+) (*VolumeEnumerateResponse, error) {
+	if req.GetPageSize() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Page size cannot be negative")
+	}
+	resp, err := s.driver.EnumeratePage(&api.VolumeFilter{
+		NamePrefix: req.GetNamePrefix(),
+		Labels:     req.GetLabels(),
+		State:      req.GetState(),
+		Node:       req.GetNode(),
+	}, int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+	return &VolumeEnumerateResponse{
+		Volumes:   resp.Volumes,
+		NextToken: resp.NextToken,
+	}, nil
+}
+
+func (s *volumeServer) Set(
+	ctx context.Context,
+	req *VolumeSetRequest,
+) (*VolumeSetResponse, error) {
+	if req.GetVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume id must be provided")
+	}
+	if err := s.driver.Set(req.GetVolumeId(), req.GetLocator(), req.GetSpec()); err != nil {
+		return nil, errorStatus(err)
+	}
+	v, err := s.inspect(req.GetVolumeId())
+	if err != nil {
+		return nil, err
+	}
+	return &VolumeSetResponse{Volume: v}, nil
+}
+
+func (s *volumeServer) Delete(
+	ctx context.Context,
+	req *VolumeDeleteRequest,
+) (*VolumeDeleteResponse, error) {
+	if req.GetVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume id must be provided")
+	}
+	if err := volume.CheckDelete(s.driver, req.GetVolumeId(), req.GetForce()); err != nil {
+		return nil, errorStatus(err)
+	}
+	if err := s.driver.Delete(req.GetVolumeId()); err != nil {
+		return nil, errorStatus(err)
+	}
+	return &VolumeDeleteResponse{}, nil
+}
+
+func (s *volumeServer) Attach(
+	ctx context.Context,
+	req *VolumeAttachRequest,
+) (*VolumeAttachResponse, error) {
+	if req.GetVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume id must be provided")
+	}
+	devicePath, err := s.driver.Attach(req.GetVolumeId(), req.GetOptions())
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+	return &VolumeAttachResponse{DevicePath: devicePath}, nil
+}
+
+func (s *volumeServer) Detach(
+	ctx context.Context,
+	req *VolumeDetachRequest,
+) (*VolumeDetachResponse, error) {
+	if req.GetVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume id must be provided")
+	}
+	if err := s.driver.Detach(req.GetVolumeId(), req.GetOptions()); err != nil {
+		return nil, errorStatus(err)
+	}
+	return &VolumeDetachResponse{}, nil
+}
+
+func (s *volumeServer) Mount(
+	ctx context.Context,
+	req *VolumeMountRequest,
+) (*VolumeMountResponse, error) {
+	if req.GetVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume id must be provided")
+	}
+	if req.GetMountPath() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Mount path must be provided")
+	}
+	if err := s.driver.Mount(req.GetVolumeId(), req.GetMountPath(), req.GetOptions()); err != nil {
+		return nil, errorStatus(err)
+	}
+	return &VolumeMountResponse{}, nil
+}
+
+func (s *volumeServer) Unmount(
+	ctx context.Context,
+	req *VolumeUnmountRequest,
+) (*VolumeUnmountResponse, error) {
+	if req.GetVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume id must be provided")
+	}
+	if req.GetMountPath() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Mount path must be provided")
+	}
+	if err := s.driver.Unmount(req.GetVolumeId(), req.GetMountPath(), req.GetOptions()); err != nil {
+		return nil, errorStatus(err)
+	}
+	return &VolumeUnmountResponse{}, nil
+}
+
+func (s *volumeServer) Stats(
+	ctx context.Context,
+	req *VolumeStatsRequest,
+) (*VolumeStatsResponse, error) {
+	if req.GetVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume id must be provided")
+	}
+	stats, err := s.driver.Stats(req.GetVolumeId(), req.GetCumulative())
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+	return &VolumeStatsResponse{Stats: stats}, nil
+}
+
+func (s *snapshotServer) Create(
+	ctx context.Context,
+	req *SnapshotCreateRequest,
+) (*SnapshotCreateResponse, error) {
+	if req.GetVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume id must be provided")
+	}
+	locator := req.GetLocator()
+	if locator == nil {
+		locator = &api.VolumeLocator{}
+	}
+	id, err := s.driver.Snapshot(req.GetVolumeId(), req.GetReadonly(), locator)
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+	return &SnapshotCreateResponse{SnapshotId: id}, nil
+}
+
+func (s *snapshotServer) Enumerate(
+	ctx context.Context,
+	req *SnapshotEnumerateRequest,
+) (*SnapshotEnumerateResponse, error) {
+	snaps, err := s.driver.SnapEnumerate(req.GetVolumeIds(), req.GetLabels())
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+	return &SnapshotEnumerateResponse{Snapshots: snaps}, nil
+}
+
+func (s *snapshotServer) Restore(
+	ctx context.Context,
+	req *SnapshotRestoreRequest,
+) (*SnapshotRestoreResponse, error) {
+	if req.GetVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume id must be provided")
+	}
+	if req.GetSnapshotId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Snapshot id must be provided")
+	}
+	if err := s.driver.Restore(req.GetVolumeId(), req.GetSnapshotId()); err != nil {
+		return nil, errorStatus(err)
+	}
+	return &SnapshotRestoreResponse{}, nil
+}
+
+// inspect returns the volume or a NotFound status.
+func (s *Server) inspect(volumeID string) (*api.Volume, error) {
+	if volumeID == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume id must be provided")
+	}
+	vols, err := s.driver.Inspect([]string{volumeID})
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+	if len(vols) == 0 {
+		return nil, status.Errorf(codes.NotFound, "Volume id %s not found", volumeID)
+	}
+	return vols[0], nil
+}
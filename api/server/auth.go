@@ -0,0 +1,114 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"go.pedge.io/dlog"
+
+	"github.com/gorilla/context"
+	"github.com/libopenstorage/openstorage/pkg/auth"
+)
+
+const (
+	// LocalSubject is the identity of callers on the local unix sockets.
+	LocalSubject = "local"
+)
+
+type callerKey struct{}
+
+var (
+	authKey  []byte
+	authLock sync.Mutex
+)
+
+// Caller returns the identity of the caller of an authenticated request.
+func Caller(r *http.Request) (*auth.Claims, bool) {
+	claims, ok := context.Get(r, callerKey{}).(*auth.Claims)
+	return claims, ok
+}
+
+// EnableAuth requires callers of the REST servers listening on a TCP port to
+// present a bearer token signed with key and holding the role required by
+// the route. It must be called before the servers are started.
+func EnableAuth(key []byte) {
+	authLock.Lock()
+	defer authLock.Unlock()
+	authKey = key
+}
+
+// tokenAuthenticator returns the authenticator for TCP listeners, or nil if
+// authentication is disabled.
+func tokenAuthenticator() func(*Route) http.HandlerFunc {
+	authLock.Lock()
+	key := authKey
+	authLock.Unlock()
+	if len(key) == 0 {
+		return nil
+	}
+	return func(route *Route) http.HandlerFunc {
+		return authenticate(route, key)
+	}
+}
+
+// localAuthenticator attaches the local administrator identity to requests
+// received on a unix socket.
+func localAuthenticator(route *Route) http.HandlerFunc {
+	claims := &auth.Claims{
+		Subject: LocalSubject,
+		Roles:   []auth.Role{auth.RoleClusterAdmin},
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		context.Set(r, callerKey{}, claims)
+		route.fn(w, r)
+	}
+}
+
+// authenticate verifies the bearer token of the request and the role of the
+// caller before calling the route handler.
+// Routes without a role are denied to every caller.
+func authenticate(route *Route, key []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if route.role == "" {
+			authError(w, r, "No role is defined for this route", http.StatusForbidden)
+			return
+		}
+		token := bearerToken(r)
+		if token == "" {
+			authError(w, r, "Missing bearer token", http.StatusUnauthorized)
+			return
+		}
+		claims, err := auth.Verify(token, key)
+		if err != nil {
+			authError(w, r, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if !claims.HasRole(route.role) {
+			authError(w, r,
+				fmt.Sprintf("Caller %s does not have the %s role", claims.Subject, route.role),
+				http.StatusForbidden)
+			return
+		}
+		context.Set(r, callerKey{}, claims)
+		route.fn(w, r)
+	}
+}
+
+func bearerToken(r *http.Request) string {
+	h := r.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if len(h) > len(prefix) && strings.EqualFold(h[:len(prefix)], prefix) {
+		return strings.TrimSpace(h[len(prefix):])
+	}
+	return ""
+}
+
+func authError(w http.ResponseWriter, r *http.Request, msg string, code int) {
+	dlog.Warnf("Denied %s %s from %s: %s", r.Method, r.URL.Path, r.RemoteAddr, msg)
+	if code == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	}
+	http.Error(w, msg, code)
+}
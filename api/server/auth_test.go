@@ -0,0 +1,137 @@
+package server
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+	client "github.com/libopenstorage/openstorage/api/client/volume"
+	"github.com/libopenstorage/openstorage/pkg/auth"
+)
+
+var testAuthKey = []byte("test-secret")
+
+func testToken(t *testing.T, subject string, role auth.Role) string {
+	token, err := auth.NewToken(&auth.Claims{
+		Subject:   subject,
+		Roles:     []auth.Role{role},
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}, testAuthKey)
+	require.NoError(t, err)
+	return token
+}
+
+func newTestAuthRouter(routes []*Route) *mux.Router {
+	return newRouter(routes, func(route *Route) http.HandlerFunc {
+		return authenticate(route, testAuthKey)
+	})
+}
+
+func doAuthRequest(t *testing.T, verb, url, token string) (int, string) {
+	req, err := http.NewRequest(verb, url, nil)
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestAuthRoles(t *testing.T) {
+	caller := func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := Caller(r)
+		require.True(t, ok)
+		fmt.Fprint(w, claims.Subject)
+	}
+	ts := httptest.NewServer(newTestAuthRouter([]*Route{
+		{verb: "GET", path: "/open", fn: func(w http.ResponseWriter, r *http.Request) {}},
+		{verb: "GET", path: "/caller", fn: caller, role: auth.RoleReadOnly},
+		{verb: "DELETE", path: "/node", fn: caller, role: auth.RoleClusterAdmin},
+	}))
+	defer ts.Close()
+
+	// Routes without a role are refused, even to administrators.
+	code, _ := doAuthRequest(t, "GET", ts.URL+"/open", "")
+	require.Equal(t, http.StatusForbidden, code)
+	code, _ = doAuthRequest(t, "GET", ts.URL+"/open", testToken(t, "bob", auth.RoleClusterAdmin))
+	require.Equal(t, http.StatusForbidden, code)
+
+	code, _ = doAuthRequest(t, "GET", ts.URL+"/caller", "")
+	require.Equal(t, http.StatusUnauthorized, code)
+	code, _ = doAuthRequest(t, "GET", ts.URL+"/caller", "bad.token.value")
+	require.Equal(t, http.StatusUnauthorized, code)
+
+	code, body := doAuthRequest(t, "GET", ts.URL+"/caller", testToken(t, "alice", auth.RoleReadOnly))
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "alice", body)
+
+	code, _ = doAuthRequest(t, "DELETE", ts.URL+"/node", testToken(t, "alice", auth.RoleVolumeAdmin))
+	require.Equal(t, http.StatusForbidden, code)
+	code, body = doAuthRequest(t, "DELETE", ts.URL+"/node", testToken(t, "bob", auth.RoleClusterAdmin))
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "bob", body)
+}
+
+func TestAuthVolumeClient(t *testing.T) {
+	ts := httptest.NewServer(newTestAuthRouter((&volAPI{}).Routes()))
+	defer ts.Close()
+	testVolDriver := newTestServer(t)
+	defer testVolDriver.Stop()
+
+	cl, err := client.NewDriverClient(ts.URL, mockDriverName, version, mockDriverName)
+	require.NoError(t, err)
+	driver := client.VolumeDriver(cl)
+
+	locator := &api.VolumeLocator{Name: "myvol"}
+	source := &api.Source{}
+	spec := &api.VolumeSpec{Size: 1234}
+
+	// No token.
+	_, err = driver.Create(locator, source, spec)
+	require.Error(t, err)
+
+	// Read-only callers cannot create volumes.
+	cl.SetToken(testToken(t, "alice", auth.RoleReadOnly))
+	_, err = driver.Create(locator, source, spec)
+	require.Error(t, err)
+
+	cl.SetToken(testToken(t, "alice", auth.RoleVolumeAdmin))
+	testVolDriver.MockDriver().
+		EXPECT().
+		Create(locator, source, spec).
+		Return("myid", nil)
+	id, err := driver.Create(locator, source, spec)
+	require.NoError(t, err)
+	require.Equal(t, "myid", id)
+
+	// Credentials are restricted to cluster administrators.
+	_, err = client.VolumeDriver(cl).CredsEnumerate()
+	require.Error(t, err)
+}
+
+func TestAuthPluginRoutes(t *testing.T) {
+	routes := append(newVolumePlugin(mockDriverName).Routes(), newGraphPlugin(mockDriverName).Routes()...)
+	for _, route := range routes {
+		require.NotEmpty(t, route.role, "%s %s has no role", route.verb, route.path)
+	}
+
+	ts := httptest.NewServer(newTestAuthRouter(newVolumePlugin(mockDriverName).Routes()))
+	defer ts.Close()
+	for _, method := range []string{"Create", "Remove", "Mount", "Unmount"} {
+		code, _ := doAuthRequest(t, "POST", ts.URL+volDriverPath(method), "")
+		require.Equal(t, http.StatusUnauthorized, code, method)
+		code, _ = doAuthRequest(t, "POST", ts.URL+volDriverPath(method), testToken(t, "alice", auth.RoleReadOnly))
+		require.Equal(t, http.StatusForbidden, code, method)
+	}
+}
@@ -9,8 +9,10 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/libopenstorage/openstorage/alert"
 	"github.com/libopenstorage/openstorage/api"
 	"github.com/libopenstorage/openstorage/cluster"
+	"github.com/libopenstorage/openstorage/pkg/auth"
 )
 
 const (
@@ -24,23 +26,24 @@ type clusterApi struct {
 
 func (c *clusterApi) Routes() []*Route {
 	return []*Route{
-		{verb: "GET", path: "/cluster/versions", fn: c.versions},
-		{verb: "GET", path: clusterPath("/enumerate", cluster.APIVersion), fn: c.enumerate},
-		{verb: "GET", path: clusterPath("/gossipstate", cluster.APIVersion), fn: c.gossipState},
-		{verb: "GET", path: clusterPath("/nodestatus", cluster.APIVersion), fn: c.nodeStatus},
-		{verb: "GET", path: clusterPath("/nodehealth", cluster.APIVersion), fn: c.nodeHealth},
-		{verb: "GET", path: clusterPath("/status", cluster.APIVersion), fn: c.status},
-		{verb: "GET", path: clusterPath("/peerstatus", cluster.APIVersion), fn: c.peerStatus},
-		{verb: "GET", path: clusterPath("/inspect/{id}", cluster.APIVersion), fn: c.inspect},
-		{verb: "DELETE", path: clusterPath("", cluster.APIVersion), fn: c.delete},
-		{verb: "DELETE", path: clusterPath("/{id}", cluster.APIVersion), fn: c.delete},
-		{verb: "PUT", path: clusterPath("/enablegossip", cluster.APIVersion), fn: c.enableGossip},
-		{verb: "PUT", path: clusterPath("/disablegossip", cluster.APIVersion), fn: c.disableGossip},
-		{verb: "PUT", path: clusterPath("/shutdown", cluster.APIVersion), fn: c.shutdown},
-		{verb: "PUT", path: clusterPath("/shutdown/{id}", cluster.APIVersion), fn: c.shutdown},
-		{verb: "GET", path: clusterPath("/alerts/{resource}", cluster.APIVersion), fn: c.enumerateAlerts},
-		{verb: "PUT", path: clusterPath("/alerts/{resource}/{id}", cluster.APIVersion), fn: c.clearAlert},
-		{verb: "DELETE", path: clusterPath("/alerts/{resource}/{id}", cluster.APIVersion), fn: c.eraseAlert},
+		{verb: "GET", path: "/cluster/versions", fn: c.versions, role: auth.RoleReadOnly},
+		{verb: "GET", path: clusterPath("/enumerate", cluster.APIVersion), fn: c.enumerate, role: auth.RoleReadOnly},
+		{verb: "GET", path: clusterPath("/gossipstate", cluster.APIVersion), fn: c.gossipState, role: auth.RoleReadOnly},
+		{verb: "GET", path: clusterPath("/nodestatus", cluster.APIVersion), fn: c.nodeStatus, role: auth.RoleReadOnly},
+		{verb: "GET", path: clusterPath("/nodehealth", cluster.APIVersion), fn: c.nodeHealth, role: auth.RoleReadOnly},
+		{verb: "GET", path: clusterPath("/status", cluster.APIVersion), fn: c.status, role: auth.RoleReadOnly},
+		{verb: "GET", path: clusterPath("/peerstatus", cluster.APIVersion), fn: c.peerStatus, role: auth.RoleReadOnly},
+		{verb: "GET", path: clusterPath("/inspect/{id}", cluster.APIVersion), fn: c.inspect, role: auth.RoleReadOnly},
+		{verb: "DELETE", path: clusterPath("", cluster.APIVersion), fn: c.delete, role: auth.RoleClusterAdmin},
+		{verb: "DELETE", path: clusterPath("/{id}", cluster.APIVersion), fn: c.delete, role: auth.RoleClusterAdmin},
+		{verb: "PUT", path: clusterPath("/enablegossip", cluster.APIVersion), fn: c.enableGossip, role: auth.RoleClusterAdmin},
+		{verb: "PUT", path: clusterPath("/disablegossip", cluster.APIVersion), fn: c.disableGossip, role: auth.RoleClusterAdmin},
+		{verb: "PUT", path: clusterPath("/shutdown", cluster.APIVersion), fn: c.shutdown, role: auth.RoleClusterAdmin},
+		{verb: "PUT", path: clusterPath("/shutdown/{id}", cluster.APIVersion), fn: c.shutdown, role: auth.RoleClusterAdmin},
+		{verb: "GET", path: clusterPath("/alerttypes", cluster.APIVersion), fn: c.enumerateAlertTypes, role: auth.RoleReadOnly},
+		{verb: "GET", path: clusterPath("/alerts/{resource}", cluster.APIVersion), fn: c.enumerateAlerts, role: auth.RoleReadOnly},
+		{verb: "PUT", path: clusterPath("/alerts/{resource}/{id}", cluster.APIVersion), fn: c.clearAlert, role: auth.RoleClusterAdmin},
+		{verb: "DELETE", path: clusterPath("/alerts/{resource}/{id}", cluster.APIVersion), fn: c.eraseAlert, role: auth.RoleClusterAdmin},
 	}
 }
 
@@ -485,6 +488,24 @@ func (c *clusterApi) versions(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(versions)
 }
 
+// swagger:operation GET /cluster/alerttypes cluster alerts enumerate enumerateAlertTypes
+//
+// This will return the catalogue of registered alert types
+//
+// ---
+// produces:
+// - application/json
+// responses:
+//   '200':
+//      description: List of alert types
+//      schema:
+//       type: array
+//       items:
+//          $ref: '#/definitions/AlertType'
+func (c *clusterApi) enumerateAlertTypes(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(alert.EnumerateTypes())
+}
+
 // swagger:operation GET /cluster/alerts/{resource} cluster alerts enumerate enumerateAlerts
 //
 // This will return a list of alerts for the requested resource
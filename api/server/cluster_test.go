@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	types "github.com/libopenstorage/gossip/types"
+	"github.com/libopenstorage/openstorage/alert"
 	"github.com/libopenstorage/openstorage/api"
 	clusterclient "github.com/libopenstorage/openstorage/api/client/cluster"
 	"github.com/libopenstorage/openstorage/cluster"
@@ -392,3 +393,31 @@ func TestSetLoggingURLSuccess(t *testing.T) {
 	assert.NoError(t, resp)
 
 }
+
+func TestEnumerateAlertTypesSuccess(t *testing.T) {
+
+	// create an instance of clusterAPI to get access to
+	// alert types endpoint handler
+	capi := &clusterApi{}
+
+	// create a HTTP Test server
+	ts := httptest.NewServer(http.HandlerFunc(capi.enumerateAlertTypes))
+
+	alertType := &api.AlertType{
+		Type:     1000,
+		Name:     "TestAlertType",
+		Severity: api.SeverityType_SEVERITY_TYPE_WARNING,
+		Resource: api.ResourceType_RESOURCE_TYPE_NODE,
+		Message:  "Node {{.ResourceId}} test alert",
+	}
+	assert.NoError(t, alert.RegisterType(alertType))
+
+	// create a cluster client to make the REST call
+	c, err := clusterclient.NewClusterClient(ts.URL, "v1")
+	assert.NoError(t, err)
+
+	// make the REST call
+	alertTypes, err := clusterclient.EnumerateAlertTypes(c)
+	assert.NoError(t, err)
+	assert.Contains(t, alertTypes, alertType)
+}
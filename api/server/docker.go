@@ -11,6 +11,7 @@ import (
 	"github.com/libopenstorage/openstorage/api"
 	"github.com/libopenstorage/openstorage/api/spec"
 	"github.com/libopenstorage/openstorage/config"
+	"github.com/libopenstorage/openstorage/pkg/auth"
 	"github.com/libopenstorage/openstorage/pkg/options"
 	"github.com/libopenstorage/openstorage/pkg/util"
 	"github.com/libopenstorage/openstorage/volume"
@@ -94,16 +95,16 @@ func (d *driver) volNotMounted(request string, id string) error {
 
 func (d *driver) Routes() []*Route {
 	return []*Route{
-		{verb: "POST", path: volDriverPath("Create"), fn: d.create},
-		{verb: "POST", path: volDriverPath("Remove"), fn: d.remove},
-		{verb: "POST", path: volDriverPath("Mount"), fn: d.mount},
-		{verb: "POST", path: volDriverPath("Path"), fn: d.path},
-		{verb: "POST", path: volDriverPath("List"), fn: d.list},
-		{verb: "POST", path: volDriverPath("Get"), fn: d.get},
-		{verb: "POST", path: volDriverPath("Unmount"), fn: d.unmount},
-		{verb: "POST", path: volDriverPath("Capabilities"), fn: d.capabilities},
-		{verb: "POST", path: "/Plugin.Activate", fn: d.handshake},
-		{verb: "GET", path: "/status", fn: d.status},
+		{verb: "POST", path: volDriverPath("Create"), fn: d.create, role: auth.RoleVolumeAdmin},
+		{verb: "POST", path: volDriverPath("Remove"), fn: d.remove, role: auth.RoleVolumeAdmin},
+		{verb: "POST", path: volDriverPath("Mount"), fn: d.mount, role: auth.RoleVolumeAdmin},
+		{verb: "POST", path: volDriverPath("Path"), fn: d.path, role: auth.RoleReadOnly},
+		{verb: "POST", path: volDriverPath("List"), fn: d.list, role: auth.RoleReadOnly},
+		{verb: "POST", path: volDriverPath("Get"), fn: d.get, role: auth.RoleReadOnly},
+		{verb: "POST", path: volDriverPath("Unmount"), fn: d.unmount, role: auth.RoleVolumeAdmin},
+		{verb: "POST", path: volDriverPath("Capabilities"), fn: d.capabilities, role: auth.RoleReadOnly},
+		{verb: "POST", path: "/Plugin.Activate", fn: d.handshake, role: auth.RoleReadOnly},
+		{verb: "GET", path: "/status", fn: d.status, role: auth.RoleReadOnly},
 	}
 }
 
@@ -237,6 +238,12 @@ func (d *driver) remove(w http.ResponseWriter, r *http.Request) {
 
 	_, _, _, _, name := d.SpecFromString(request.Name)
 
+	if vol, err := d.volFromName(name); err == nil {
+		if err := volume.CheckDelete(v, vol.Id, false); err != nil {
+			d.errorResponse(method, w, err)
+			return
+		}
+	}
 	if err = v.Delete(name); err != nil {
 		d.errorResponse(method, w, err)
 		return
@@ -11,6 +11,7 @@ import (
 	"github.com/docker/docker/pkg/archive"
 	"github.com/libopenstorage/openstorage/config"
 	"github.com/libopenstorage/openstorage/graph"
+	"github.com/libopenstorage/openstorage/pkg/auth"
 )
 
 const (
@@ -54,20 +55,20 @@ func graphDriverPath(method string) string {
 
 func (d *graphDriver) Routes() []*Route {
 	return []*Route{
-		{verb: "POST", path: graphDriverPath("Init"), fn: d.init},
-		{verb: "POST", path: graphDriverPath("Create"), fn: d.create},
-		{verb: "POST", path: graphDriverPath("Remove"), fn: d.remove},
-		{verb: "POST", path: graphDriverPath("Get"), fn: d.get},
-		{verb: "POST", path: graphDriverPath("Put"), fn: d.put},
-		{verb: "POST", path: graphDriverPath("Exists"), fn: d.exists},
-		{verb: "POST", path: graphDriverPath("Status"), fn: d.graphStatus},
-		{verb: "POST", path: graphDriverPath("GetMetadata"), fn: d.getMetadata},
-		{verb: "POST", path: graphDriverPath("Cleanup"), fn: d.cleanup},
-		{verb: "POST", path: graphDriverPath("Diff"), fn: d.diff},
-		{verb: "POST", path: graphDriverPath("Changes"), fn: d.changes},
-		{verb: "POST", path: graphDriverPath("ApplyDiff"), fn: d.applyDiff},
-		{verb: "POST", path: graphDriverPath("DiffSize"), fn: d.diffSize},
-		{verb: "POST", path: "/Plugin.Activate", fn: d.handshake},
+		{verb: "POST", path: graphDriverPath("Init"), fn: d.init, role: auth.RoleVolumeAdmin},
+		{verb: "POST", path: graphDriverPath("Create"), fn: d.create, role: auth.RoleVolumeAdmin},
+		{verb: "POST", path: graphDriverPath("Remove"), fn: d.remove, role: auth.RoleVolumeAdmin},
+		{verb: "POST", path: graphDriverPath("Get"), fn: d.get, role: auth.RoleVolumeAdmin},
+		{verb: "POST", path: graphDriverPath("Put"), fn: d.put, role: auth.RoleVolumeAdmin},
+		{verb: "POST", path: graphDriverPath("Exists"), fn: d.exists, role: auth.RoleReadOnly},
+		{verb: "POST", path: graphDriverPath("Status"), fn: d.graphStatus, role: auth.RoleReadOnly},
+		{verb: "POST", path: graphDriverPath("GetMetadata"), fn: d.getMetadata, role: auth.RoleReadOnly},
+		{verb: "POST", path: graphDriverPath("Cleanup"), fn: d.cleanup, role: auth.RoleVolumeAdmin},
+		{verb: "POST", path: graphDriverPath("Diff"), fn: d.diff, role: auth.RoleReadOnly},
+		{verb: "POST", path: graphDriverPath("Changes"), fn: d.changes, role: auth.RoleReadOnly},
+		{verb: "POST", path: graphDriverPath("ApplyDiff"), fn: d.applyDiff, role: auth.RoleVolumeAdmin},
+		{verb: "POST", path: graphDriverPath("DiffSize"), fn: d.diffSize, role: auth.RoleReadOnly},
+		{verb: "POST", path: "/Plugin.Activate", fn: d.handshake, role: auth.RoleReadOnly},
 	}
 }
 
@@ -1,15 +1,18 @@
 package server
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
 	"path"
+	"sync"
 
 	"go.pedge.io/dlog"
 
 	"github.com/gorilla/mux"
+	"github.com/libopenstorage/openstorage/pkg/auth"
 )
 
 // Route is a specification and  handler for a REST endpoint.
@@ -17,6 +20,9 @@ type Route struct {
 	verb string
 	path string
 	fn   func(http.ResponseWriter, *http.Request)
+	// role required to call the endpoint when authentication is enabled.
+	// Routes without a role are refused when authentication is enabled.
+	role auth.Role
 }
 
 func (r *Route) GetVerb() string {
@@ -31,11 +37,36 @@ func (r *Route) GetFn() func(http.ResponseWriter, *http.Request) {
 	return r.fn
 }
 
+func (r *Route) GetRole() auth.Role {
+	return r.role
+}
+
+var (
+	serverTLS *tls.Config
+	tlsLock   sync.Mutex
+)
+
+// EnableTLS serves the management and cluster APIs listening on a TCP port
+// over HTTPS with the given configuration. Client certificates are required
+// if the configuration asks for them. It must be called before the servers
+// are started.
+func EnableTLS(tlsConfig *tls.Config) {
+	tlsLock.Lock()
+	defer tlsLock.Unlock()
+	serverTLS = tlsConfig
+}
+
+func getTLS() *tls.Config {
+	tlsLock.Lock()
+	defer tlsLock.Unlock()
+	return serverTLS
+}
+
 // StartGraphAPI starts a REST server to receive GraphDriver commands from
 // the Linux container engine.
 func StartGraphAPI(name string, restBase string) error {
 	graphPlugin := newGraphPlugin(name)
-	if err := startServer(name, restBase, 0, graphPlugin.Routes()); err != nil {
+	if err := startServer(name, restBase, 0, graphPlugin.Routes(), false); err != nil {
 		return err
 	}
 
@@ -80,6 +111,7 @@ func StartVolumeMgmtAPI(
 		mgmtBase,
 		mgmtPort,
 		volMgmtApi.Routes(),
+		true,
 	); err != nil {
 		return err
 	}
@@ -105,6 +137,7 @@ func StartVolumePluginAPI(
 		pluginBase,
 		pluginPort,
 		volPluginApi.Routes(),
+		false,
 	); err != nil {
 		return err
 	}
@@ -115,7 +148,7 @@ func StartVolumePluginAPI(
 // from the CLI/UX to control the OSD cluster.
 func StartClusterAPI(clusterApiBase string, clusterPort uint16) error {
 	clusterApi := newClusterAPI()
-	if err := startServer("osd", clusterApiBase, clusterPort, clusterApi.Routes()); err != nil {
+	if err := startServer("osd", clusterApiBase, clusterPort, clusterApi.Routes(), true); err != nil {
 		return err
 	}
 
@@ -127,16 +160,13 @@ func GetClusterAPIRoutes() []*Route {
 	return clusterApi.Routes()
 }
 
-func startServer(name string, sockBase string, port uint16, routes []*Route) error {
+// startServer serves routes on a unix socket and, if port is set, on a TCP
+// port. The TCP port uses TLS when secure is set and TLS is enabled.
+func startServer(name string, sockBase string, port uint16, routes []*Route, secure bool) error {
 	var (
 		listener net.Listener
 		err      error
 	)
-	router := mux.NewRouter()
-	router.NotFoundHandler = http.HandlerFunc(notFound)
-	for _, v := range routes {
-		router.Methods(v.verb).Path(v.path).HandlerFunc(v.fn)
-	}
 	socket := path.Join(sockBase, name+".sock")
 	os.Remove(socket)
 	os.MkdirAll(path.Dir(socket), 0755)
@@ -147,14 +177,36 @@ func startServer(name string, sockBase string, port uint16, routes []*Route) err
 		dlog.Warnln("Cannot listen on UNIX socket: ", err)
 		return err
 	}
-	go http.Serve(listener, router)
+	// The unix socket is only reachable by local administrators.
+	go http.Serve(listener, newRouter(routes, localAuthenticator))
 	if port != 0 {
-		dlog.Printf("Starting REST service on port : %v", port)
-		go http.ListenAndServe(fmt.Sprintf(":%d", port), router)
+		router := newRouter(routes, tokenAuthenticator())
+		addr := fmt.Sprintf(":%d", port)
+		if tlsConfig := getTLS(); secure && tlsConfig != nil {
+			dlog.Printf("Starting REST service on port : %v (TLS)", port)
+			srv := &http.Server{Addr: addr, Handler: router, TLSConfig: tlsConfig}
+			go srv.ListenAndServeTLS("", "")
+		} else {
+			dlog.Printf("Starting REST service on port : %v", port)
+			go http.ListenAndServe(addr, router)
+		}
 	}
 	return nil
 }
 
+func newRouter(routes []*Route, authenticator func(*Route) http.HandlerFunc) *mux.Router {
+	router := mux.NewRouter()
+	router.NotFoundHandler = http.HandlerFunc(notFound)
+	for _, v := range routes {
+		if authenticator == nil {
+			router.Methods(v.verb).Path(v.path).HandlerFunc(v.fn)
+		} else {
+			router.Methods(v.verb).Path(v.path).HandlerFunc(authenticator(v))
+		}
+	}
+	return router
+}
+
 type restServer interface {
 	Routes() []*Route
 	String() string
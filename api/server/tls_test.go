@@ -0,0 +1,157 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api/client"
+	"github.com/libopenstorage/openstorage/config"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// newTestCert creates a certificate signed by parent, or a self-signed CA
+// if parent is nil, and writes it with its key to dir.
+func newTestCert(t *testing.T, dir, name string, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{
+			x509.ExtKeyUsageServerAuth,
+			x509.ExtKeyUsageClientAuth,
+		},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	signer, signerKey := tmpl, key
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, name+".crt"),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, name+".key"),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	return &testCert{cert: cert, key: key}
+}
+
+func freePort(t *testing.T) uint16 {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return uint16(l.Addr().(*net.TCPAddr).Port)
+}
+
+func TestTLSServer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "osd-tls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca := newTestCert(t, dir, "ca", nil)
+	newTestCert(t, dir, "server", ca)
+	newTestCert(t, dir, "client", ca)
+	// A client certificate issued by another CA.
+	other := newTestCert(t, dir, "other-ca", nil)
+	newTestCert(t, dir, "other", other)
+
+	serverConfig := &config.TLSConfig{
+		CertFile:     path.Join(dir, "server.crt"),
+		KeyFile:      path.Join(dir, "server.key"),
+		ClientCAFile: path.Join(dir, "ca.crt"),
+	}
+	tlsConfig, err := serverConfig.ServerConfig()
+	require.NoError(t, err)
+	EnableTLS(tlsConfig)
+	defer EnableTLS(nil)
+
+	port := freePort(t)
+	routes := []*Route{
+		{verb: "GET", path: "/v1/status", fn: func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, "ok")
+		}},
+	}
+	require.NoError(t, startServer("tls", dir, port, routes, true))
+	host := fmt.Sprintf("https://127.0.0.1:%d", port)
+
+	newClient := func(name string) *client.Client {
+		tlsConfig := &tls.Config{RootCAs: x509.NewCertPool()}
+		tlsConfig.RootCAs.AddCert(ca.cert)
+		if name != "" {
+			clientConfig := &config.TLSConfig{
+				CertFile: path.Join(dir, name+".crt"),
+				KeyFile:  path.Join(dir, name+".key"),
+				CAFile:   path.Join(dir, "ca.crt"),
+			}
+			tlsConfig, err = clientConfig.ClientConfig()
+			require.NoError(t, err)
+		}
+		c, err := client.NewClient(host, "v1", "")
+		require.NoError(t, err)
+		c.SetTLS(tlsConfig)
+		return c
+	}
+
+	status := func(c *client.Client) error {
+		body, err := c.Get().Resource("status").Do().Body()
+		if err == nil && string(body) != "ok" {
+			err = fmt.Errorf("Unexpected response %q", body)
+		}
+		return err
+	}
+
+	c := newClient("client")
+	for i := 0; i < 50; i++ {
+		if err = status(c); err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	require.NoError(t, err)
+
+	// Client certificates are required and must be issued by the client CA.
+	require.Error(t, status(newClient("")))
+	require.Error(t, status(newClient("other")))
+
+	// Plain HTTP is refused.
+	plain, err := client.NewClient(fmt.Sprintf("http://127.0.0.1:%d", port), "v1", "")
+	require.NoError(t, err)
+	require.Error(t, status(plain))
+
+	// The unix socket is unchanged.
+	local, err := client.NewClient(client.GetUnixServerPath("tls", dir+"/"), "v1", "")
+	require.NoError(t, err)
+	require.NoError(t, status(local))
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/libopenstorage/openstorage/api"
 	"github.com/libopenstorage/openstorage/api/errors"
+	"github.com/libopenstorage/openstorage/pkg/auth"
 	"github.com/libopenstorage/openstorage/volume"
 	"github.com/libopenstorage/openstorage/volume/drivers"
 )
@@ -303,11 +304,20 @@ func (vd *volAPI) inspect(w http.ResponseWriter, r *http.Request) {
 //   in: path
 //   description: id to get volume with
 //   required: true
+// - name: force
+//   in: query
+//   description: delete the volume even if clones depend on it
+//   required: false
+//   type: boolean
 // responses:
 //   '200':
 //     description: volume set response
 //     schema:
 //         "$ref": "#/definitions/VolumeResponse"
+//   '409':
+//     description: volume is attached or has dependent clones
+//   '412':
+//     description: volume is sticky
 //   default:
 //     description: unexpected error
 //     schema:
@@ -331,6 +341,19 @@ func (vd *volAPI) delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	force := false
+	if f := r.URL.Query().Get("force"); f != "" {
+		if force, err = strconv.ParseBool(f); err != nil {
+			vd.sendError(vd.name, method, w, "Invalid force option: "+f,
+				http.StatusBadRequest)
+			return
+		}
+	}
+	if err := volume.CheckDelete(d, volumeID, force); err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), deleteStatus(err))
+		return
+	}
+
 	volumeResponse := &api.VolumeResponse{}
 
 	if err := d.Delete(volumeID); err != nil {
@@ -339,6 +362,164 @@ func (vd *volAPI) delete(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(volumeResponse)
 }
 
+// deleteStatus returns the HTTP status for a refused delete.
+func deleteStatus(err error) int {
+	switch err {
+	case volume.ErrVolSticky:
+		return http.StatusPreconditionFailed
+	case volume.ErrVolAttached, volume.ErrVolHasClones:
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// trashDriver returns the trash of the driver of r.
+func (vd *volAPI) trashDriver(r *http.Request) (volume.TrashDriver, error) {
+	d, err := vd.getVolDriver(r)
+	if err != nil {
+		return nil, err
+	}
+	t, ok := d.(volume.TrashDriver)
+	if !ok {
+		return nil, volume.ErrNotSupported
+	}
+	return t, nil
+}
+
+// trashStatus returns the HTTP status for a failed trash operation.
+func trashStatus(err error) int {
+	switch err {
+	case volume.ErrNotSupported:
+		return http.StatusNotImplemented
+	case volume.ErrEnoEnt:
+		return http.StatusNotFound
+	case volume.ErrNotInTrash:
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// swagger:operation GET /osd-volumes/trash volume trashEnumerate trashEnumerateVolumes
+//
+// Enumerate the volumes in the trash.
+//
+// ---
+// produces:
+// - application/json
+// responses:
+//   '200':
+//     description: an array of volumes
+//     schema:
+//       type: array
+//       items:
+//          $ref: '#/definitions/Volume'
+//   '501':
+//     description: the driver has no trash or it is not enabled
+func (vd *volAPI) trashEnumerate(w http.ResponseWriter, r *http.Request) {
+	method := "trashEnumerate"
+	t, err := vd.trashDriver(r)
+	if err == nil {
+		var vols []*api.Volume
+		if vols, err = t.TrashEnumerate(); err == nil {
+			json.NewEncoder(w).Encode(vols)
+			return
+		}
+	}
+	if err == volume.ErrDriverNotFound {
+		notFound(w, r)
+		return
+	}
+	vd.sendError(vd.name, method, w, err.Error(), trashStatus(err))
+}
+
+// swagger:operation POST /osd-volumes/trash/restore/{id} volume trashRestore trashRestoreVolume
+//
+// Move volume with specified id out of the trash.
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - name: id
+//   in: path
+//   description: id of the volume to restore
+//   required: true
+// responses:
+//   '200':
+//     description: volume set response
+//     schema:
+//         "$ref": "#/definitions/VolumeResponse"
+//   '404':
+//     description: volume does not exist
+//   '409':
+//     description: volume is not in the trash
+//   '501':
+//     description: the driver has no trash or it is not enabled
+func (vd *volAPI) trashRestore(w http.ResponseWriter, r *http.Request) {
+	vd.trashOp(w, r, "trashRestore", func(t volume.TrashDriver, volumeID string) error {
+		return t.TrashRestore(volumeID)
+	})
+}
+
+// swagger:operation DELETE /osd-volumes/trash/{id} volume trashPurge trashPurgeVolume
+//
+// Delete volume with specified id from the trash.
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - name: id
+//   in: path
+//   description: id of the volume to purge
+//   required: true
+// responses:
+//   '200':
+//     description: volume set response
+//     schema:
+//         "$ref": "#/definitions/VolumeResponse"
+//   '404':
+//     description: volume does not exist
+//   '409':
+//     description: volume is not in the trash
+//   '501':
+//     description: the driver has no trash or it is not enabled
+func (vd *volAPI) trashPurge(w http.ResponseWriter, r *http.Request) {
+	vd.trashOp(w, r, "trashPurge", func(t volume.TrashDriver, volumeID string) error {
+		return t.TrashPurge(volumeID)
+	})
+}
+
+// trashOp runs op on the volume of r in the trash of its driver.
+func (vd *volAPI) trashOp(
+	w http.ResponseWriter,
+	r *http.Request,
+	method string,
+	op func(t volume.TrashDriver, volumeID string) error,
+) {
+	volumeID, err := vd.parseID(r)
+	if err != nil {
+		e := fmt.Errorf("Failed to parse parse volumeID: %s", err.Error())
+		vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+		return
+	}
+
+	vd.logRequest(method, volumeID).Infoln("")
+
+	t, err := vd.trashDriver(r)
+	if err == nil {
+		err = op(t, volumeID)
+	}
+	switch err {
+	case nil:
+		json.NewEncoder(w).Encode(&api.VolumeResponse{})
+	case volume.ErrDriverNotFound:
+		notFound(w, r)
+	default:
+		vd.sendError(vd.name, method, w, err.Error(), trashStatus(err))
+	}
+}
+
 // swagger:operation GET /osd-volumes volume enumerate enumerateVolumes
 //
 // Enumerate all volumes
@@ -372,9 +553,41 @@ func (vd *volAPI) delete(w http.ResponseWriter, r *http.Request) {
 //   required: false
 //   type: string
 //   format: uuid
+// - name: PageSize
+//   in: query
+//   description: Maximum number of volumes to return. When set, the response
+//     is a page of volumes and the filter parameters below apply. 0 returns
+//     all volumes.
+//   required: false
+//   type: integer
+// - name: PageToken
+//   in: query
+//   description: NextToken of the previous page
+//   required: false
+//   type: string
+// - name: NamePrefix
+//   in: query
+//   description: Volume name prefix
+//   required: false
+//   type: string
+// - name: State
+//   in: query
+//   description: Volume state, e.g. VOLUME_STATE_ATTACHED
+//   required: false
+//   type: string
+// - name: Node
+//   in: query
+//   description: Node the volume is attached on or replicated to
+//   required: false
+//   type: string
+// - name: Driver
+//   in: query
+//   description: Volume driver
+//   required: false
+//   type: string
 // responses:
 //   '200':
-//      description: an array of volumes
+//      description: an array of volumes, or a page of volumes if PageSize is set
 //      schema:
 //         type: array
 //         items:
@@ -393,6 +606,10 @@ func (vd *volAPI) enumerate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	params := r.URL.Query()
+	if _, ok := params[api.OptPageSize]; ok {
+		vd.enumeratePage(w, r, d)
+		return
+	}
 	v := params[string(api.OptName)]
 	if v != nil {
 		locator.Name = v[0]
@@ -433,6 +650,50 @@ func (vd *volAPI) enumerate(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(vols)
 }
 
+// enumeratePage returns a page of the volumes selected by the filter query
+// parameters.
+func (vd *volAPI) enumeratePage(w http.ResponseWriter, r *http.Request, d volume.VolumeDriver) {
+	method := "enumeratePage"
+	params := r.URL.Query()
+
+	pageSize, err := strconv.Atoi(params.Get(api.OptPageSize))
+	if err != nil || pageSize < 0 {
+		vd.sendError(vd.name, method, w, "Invalid page size", http.StatusBadRequest)
+		return
+	}
+	filter := &api.VolumeFilter{
+		NamePrefix: params.Get(api.OptNamePrefix),
+		Node:       params.Get(api.OptNode),
+		Driver:     params.Get(api.OptDriver),
+	}
+	if v := params.Get(api.OptLabel); v != "" {
+		if err := json.Unmarshal([]byte(v), &filter.Labels); err != nil {
+			e := fmt.Errorf("Failed to parse parse VolumeLabels: %s", err.Error())
+			vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if v := params.Get(api.OptState); v != "" {
+		state, ok := api.VolumeState_value[v]
+		if !ok {
+			e := fmt.Errorf("Invalid volume state: %s", v)
+			vd.sendError(vd.name, method, w, e.Error(), http.StatusBadRequest)
+			return
+		}
+		filter.State = api.VolumeState(state)
+	}
+
+	resp, err := d.EnumeratePage(filter, pageSize, params.Get(api.OptPageToken))
+	if err == volume.ErrInvalidPageToken {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
 // swagger:operation POST /osd-snapshots snapshot create createSnap
 //
 // Take a snapshot of volume in SnapCreateRequest
@@ -917,37 +1178,40 @@ func backupPath(route, version string) string {
 
 func (vd *volAPI) Routes() []*Route {
 	return []*Route{
-		{verb: "GET", path: "/" + api.OsdVolumePath + "/versions", fn: vd.versions},
-		{verb: "POST", path: volPath("", volume.APIVersion), fn: vd.create},
-		{verb: "PUT", path: volPath("/{id}", volume.APIVersion), fn: vd.volumeSet},
-		{verb: "GET", path: volPath("", volume.APIVersion), fn: vd.enumerate},
-		{verb: "GET", path: volPath("/{id}", volume.APIVersion), fn: vd.inspect},
-		{verb: "DELETE", path: volPath("/{id}", volume.APIVersion), fn: vd.delete},
-		{verb: "GET", path: volPath("/stats", volume.APIVersion), fn: vd.stats},
-		{verb: "GET", path: volPath("/stats/{id}", volume.APIVersion), fn: vd.stats},
-		{verb: "GET", path: volPath("/usedsize", volume.APIVersion), fn: vd.usedsize},
-		{verb: "GET", path: volPath("/usedsize/{id}", volume.APIVersion), fn: vd.usedsize},
-		{verb: "GET", path: volPath("/requests", volume.APIVersion), fn: vd.requests},
-		{verb: "GET", path: volPath("/requests/{id}", volume.APIVersion), fn: vd.requests},
-		{verb: "POST", path: volPath("/quiesce/{id}", volume.APIVersion), fn: vd.quiesce},
-		{verb: "POST", path: volPath("/unquiesce/{id}", volume.APIVersion), fn: vd.unquiesce},
-		{verb: "POST", path: snapPath("", volume.APIVersion), fn: vd.snap},
-		{verb: "GET", path: snapPath("", volume.APIVersion), fn: vd.snapEnumerate},
-		{verb: "POST", path: snapPath("/restore/{id}", volume.APIVersion), fn: vd.restore},
-		{verb: "GET", path: credsPath("", volume.APIVersion), fn: vd.credsEnumerate},
-		{verb: "POST", path: credsPath("", volume.APIVersion), fn: vd.credsCreate},
-		{verb: "DELETE", path: credsPath("/{uuid}", volume.APIVersion), fn: vd.credsDelete},
-		{verb: "POST", path: credsPath("/validate/{uuid}", volume.APIVersion), fn: vd.credsValidate},
-		{verb: "POST", path: backupPath("", volume.APIVersion), fn: vd.backup},
-		{verb: "POST", path: backupPath("/restore", volume.APIVersion), fn: vd.backuprestore},
-		{verb: "GET", path: backupPath("", volume.APIVersion), fn: vd.backupenumerate},
-		{verb: "DELETE", path: backupPath("", volume.APIVersion), fn: vd.backupdelete},
-		{verb: "POST", path: backupPath("/status", volume.APIVersion), fn: vd.backupstatus},
-		{verb: "GET", path: backupPath("/catalogue", volume.APIVersion), fn: vd.backupcatalogue},
-		{verb: "GET", path: backupPath("/history", volume.APIVersion), fn: vd.backuphistory},
-		{verb: "POST", path: backupPath("/statechange", volume.APIVersion), fn: vd.backupstatechange},
-		{verb: "POST", path: backupPath("/schedcreate", volume.APIVersion), fn: vd.backupschedcreate},
-		{verb: "POST", path: backupPath("/scheddelete", volume.APIVersion), fn: vd.backupscheddelete},
-		{verb: "GET", path: backupPath("/schedenumerate", volume.APIVersion), fn: vd.backupschedenumerate},
+		{verb: "GET", path: "/" + api.OsdVolumePath + "/versions", fn: vd.versions, role: auth.RoleReadOnly},
+		{verb: "POST", path: volPath("", volume.APIVersion), fn: vd.create, role: auth.RoleVolumeAdmin},
+		{verb: "PUT", path: volPath("/{id}", volume.APIVersion), fn: vd.volumeSet, role: auth.RoleVolumeAdmin},
+		{verb: "GET", path: volPath("", volume.APIVersion), fn: vd.enumerate, role: auth.RoleReadOnly},
+		{verb: "GET", path: volPath("/trash", volume.APIVersion), fn: vd.trashEnumerate, role: auth.RoleReadOnly},
+		{verb: "POST", path: volPath("/trash/restore/{id}", volume.APIVersion), fn: vd.trashRestore, role: auth.RoleVolumeAdmin},
+		{verb: "DELETE", path: volPath("/trash/{id}", volume.APIVersion), fn: vd.trashPurge, role: auth.RoleVolumeAdmin},
+		{verb: "GET", path: volPath("/{id}", volume.APIVersion), fn: vd.inspect, role: auth.RoleReadOnly},
+		{verb: "DELETE", path: volPath("/{id}", volume.APIVersion), fn: vd.delete, role: auth.RoleVolumeAdmin},
+		{verb: "GET", path: volPath("/stats", volume.APIVersion), fn: vd.stats, role: auth.RoleReadOnly},
+		{verb: "GET", path: volPath("/stats/{id}", volume.APIVersion), fn: vd.stats, role: auth.RoleReadOnly},
+		{verb: "GET", path: volPath("/usedsize", volume.APIVersion), fn: vd.usedsize, role: auth.RoleReadOnly},
+		{verb: "GET", path: volPath("/usedsize/{id}", volume.APIVersion), fn: vd.usedsize, role: auth.RoleReadOnly},
+		{verb: "GET", path: volPath("/requests", volume.APIVersion), fn: vd.requests, role: auth.RoleReadOnly},
+		{verb: "GET", path: volPath("/requests/{id}", volume.APIVersion), fn: vd.requests, role: auth.RoleReadOnly},
+		{verb: "POST", path: volPath("/quiesce/{id}", volume.APIVersion), fn: vd.quiesce, role: auth.RoleVolumeAdmin},
+		{verb: "POST", path: volPath("/unquiesce/{id}", volume.APIVersion), fn: vd.unquiesce, role: auth.RoleVolumeAdmin},
+		{verb: "POST", path: snapPath("", volume.APIVersion), fn: vd.snap, role: auth.RoleVolumeAdmin},
+		{verb: "GET", path: snapPath("", volume.APIVersion), fn: vd.snapEnumerate, role: auth.RoleReadOnly},
+		{verb: "POST", path: snapPath("/restore/{id}", volume.APIVersion), fn: vd.restore, role: auth.RoleVolumeAdmin},
+		{verb: "GET", path: credsPath("", volume.APIVersion), fn: vd.credsEnumerate, role: auth.RoleClusterAdmin},
+		{verb: "POST", path: credsPath("", volume.APIVersion), fn: vd.credsCreate, role: auth.RoleClusterAdmin},
+		{verb: "DELETE", path: credsPath("/{uuid}", volume.APIVersion), fn: vd.credsDelete, role: auth.RoleClusterAdmin},
+		{verb: "POST", path: credsPath("/validate/{uuid}", volume.APIVersion), fn: vd.credsValidate, role: auth.RoleClusterAdmin},
+		{verb: "POST", path: backupPath("", volume.APIVersion), fn: vd.backup, role: auth.RoleVolumeAdmin},
+		{verb: "POST", path: backupPath("/restore", volume.APIVersion), fn: vd.backuprestore, role: auth.RoleVolumeAdmin},
+		{verb: "GET", path: backupPath("", volume.APIVersion), fn: vd.backupenumerate, role: auth.RoleReadOnly},
+		{verb: "DELETE", path: backupPath("", volume.APIVersion), fn: vd.backupdelete, role: auth.RoleVolumeAdmin},
+		{verb: "POST", path: backupPath("/status", volume.APIVersion), fn: vd.backupstatus, role: auth.RoleVolumeAdmin},
+		{verb: "GET", path: backupPath("/catalogue", volume.APIVersion), fn: vd.backupcatalogue, role: auth.RoleReadOnly},
+		{verb: "GET", path: backupPath("/history", volume.APIVersion), fn: vd.backuphistory, role: auth.RoleReadOnly},
+		{verb: "POST", path: backupPath("/statechange", volume.APIVersion), fn: vd.backupstatechange, role: auth.RoleVolumeAdmin},
+		{verb: "POST", path: backupPath("/schedcreate", volume.APIVersion), fn: vd.backupschedcreate, role: auth.RoleVolumeAdmin},
+		{verb: "POST", path: backupPath("/scheddelete", volume.APIVersion), fn: vd.backupscheddelete, role: auth.RoleVolumeAdmin},
+		{verb: "GET", path: backupPath("/schedenumerate", volume.APIVersion), fn: vd.backupschedenumerate, role: auth.RoleReadOnly},
 	}
 }
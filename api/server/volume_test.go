@@ -6,6 +6,7 @@ import (
 
 	"github.com/libopenstorage/openstorage/api"
 	volumeclient "github.com/libopenstorage/openstorage/api/client/volume"
+	"github.com/libopenstorage/openstorage/volume"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -100,6 +101,14 @@ func TestVolumeDeleteSuccess(t *testing.T) {
 	// Setup mock
 	id := "myid"
 
+	testVolDriver.MockDriver().
+		EXPECT().
+		Inspect([]string{id}).
+		Return([]*api.Volume{{Id: id}}, nil)
+	testVolDriver.MockDriver().
+		EXPECT().
+		Enumerate(&api.VolumeLocator{}, nil).
+		Return(nil, nil)
 	testVolDriver.MockDriver().
 		EXPECT().
 		Delete(id).
@@ -125,6 +134,14 @@ func TestVolumeDeleteFailed(t *testing.T) {
 	// Setup mock
 	id := "myid"
 
+	testVolDriver.MockDriver().
+		EXPECT().
+		Inspect([]string{id}).
+		Return([]*api.Volume{{Id: id}}, nil)
+	testVolDriver.MockDriver().
+		EXPECT().
+		Enumerate(&api.VolumeLocator{}, nil).
+		Return(nil, nil)
 	testVolDriver.MockDriver().
 		EXPECT().
 		Delete(id).
@@ -138,6 +155,80 @@ func TestVolumeDeleteFailed(t *testing.T) {
 	assert.Contains(t, err.Error(), "error in delete")
 }
 
+func TestVolumeDeleteProtected(t *testing.T) {
+
+	ts, testVolDriver := Setup(t)
+
+	defer ts.Close()
+	defer testVolDriver.Stop()
+
+	client, err := volumeclient.NewDriverClient(ts.URL, mockDriverName, version, mockDriverName)
+	assert.Nil(t, err)
+	driverclient := volumeclient.VolumeDriver(client)
+
+	// Setup mock
+	id := "myid"
+	clone := &api.Volume{Id: "clone", Source: &api.Source{Parent: id}}
+	protected := []struct {
+		vol *api.Volume
+		err error
+	}{
+		{&api.Volume{Id: id, Spec: &api.VolumeSpec{Sticky: true}}, volume.ErrVolSticky},
+		{&api.Volume{Id: id, AttachedOn: "node1"}, volume.ErrVolAttached},
+		{&api.Volume{Id: id, State: api.VolumeState_VOLUME_STATE_ATTACHED}, volume.ErrVolAttached},
+		{&api.Volume{Id: id, AttachPath: []string{"/mnt/myid"}}, volume.ErrVolAttached},
+	}
+	for _, p := range protected {
+		testVolDriver.MockDriver().
+			EXPECT().
+			Inspect([]string{id}).
+			Return([]*api.Volume{p.vol}, nil)
+		assert.Equal(t, p.err, driverclient.Delete(id))
+	}
+
+	// Volumes that cannot be inspected are not deleted.
+	testVolDriver.MockDriver().
+		EXPECT().
+		Inspect([]string{id}).
+		Return(nil, fmt.Errorf("kvdb unreachable"))
+	assert.NotNil(t, driverclient.Delete(id))
+
+	testVolDriver.MockDriver().
+		EXPECT().
+		Inspect([]string{id}).
+		Return([]*api.Volume{{Id: id}}, nil).
+		Times(2)
+	testVolDriver.MockDriver().
+		EXPECT().
+		Enumerate(&api.VolumeLocator{}, nil).
+		Return([]*api.Volume{clone}, nil)
+	assert.Equal(t, volume.ErrVolHasClones, driverclient.Delete(id))
+
+	// Forced deletes leave the clones behind.
+	testVolDriver.MockDriver().
+		EXPECT().
+		Delete(id).
+		Return(nil)
+	assert.Nil(t, volumeclient.ForceDelete(client, id))
+}
+
+func TestVolumeTrashNotSupported(t *testing.T) {
+
+	ts, testVolDriver := Setup(t)
+
+	defer ts.Close()
+	defer testVolDriver.Stop()
+
+	client, err := volumeclient.NewDriverClient(ts.URL, mockDriverName, version, mockDriverName)
+	assert.Nil(t, err)
+
+	// The mock driver has no trash.
+	_, err = volumeclient.TrashEnumerate(client)
+	assert.Equal(t, volume.ErrNotSupported, err)
+	assert.Equal(t, volume.ErrNotSupported, volumeclient.TrashRestore(client, "myid"))
+	assert.Equal(t, volume.ErrNotSupported, volumeclient.TrashPurge(client, "myid"))
+}
+
 func TestVolumeSnapshotCreateSuccess(t *testing.T) {
 
 	var err error
@@ -1451,3 +1542,41 @@ func TestCredsValidateFailed(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Contains(t, err.Error(), "error in creds validate")
 }
+
+func TestVolumeEnumeratePage(t *testing.T) {
+	ts, testVolDriver := Setup(t)
+	defer ts.Close()
+	defer testVolDriver.Stop()
+
+	cl, err := volumeclient.NewDriverClient(ts.URL, mockDriverName, version, mockDriverName)
+	require.NoError(t, err)
+	driverclient := volumeclient.VolumeDriver(cl)
+
+	filter := &api.VolumeFilter{
+		NamePrefix: "vol",
+		Labels:     map[string]string{"app": "db"},
+		State:      api.VolumeState_VOLUME_STATE_ATTACHED,
+		Node:       "node1",
+	}
+	resp := &api.VolumeEnumerateResponse{
+		Volumes:   []*api.Volume{&api.Volume{Id: "vol1"}},
+		NextToken: "token2",
+	}
+	testVolDriver.MockDriver().
+		EXPECT().
+		EnumeratePage(filter, 1, "token1").
+		Return(resp, nil)
+
+	page, err := driverclient.EnumeratePage(filter, 1, "token1")
+	require.NoError(t, err)
+	require.Len(t, page.Volumes, 1)
+	assert.Equal(t, "vol1", page.Volumes[0].Id)
+	assert.Equal(t, "token2", page.NextToken)
+
+	testVolDriver.MockDriver().
+		EXPECT().
+		EnumeratePage(gomock.Any(), 0, "bad").
+		Return(nil, volume.ErrInvalidPageToken)
+	_, err = driverclient.EnumeratePage(nil, 0, "bad")
+	require.Error(t, err)
+}
@@ -99,7 +99,7 @@ var (
 		api.SpecAutoAggregationValue + "),?")
 	compressedRegex   = regexp.MustCompile(api.SpecCompressed + "=([A-Za-z]+),?")
 	snapScheduleRegex = regexp.MustCompile(api.SpecSnapshotSchedule +
-		`=([A-Za-z0-9:;@=#]+),?`)
+		`=([A-Za-z0-9:;@=#*/_+ -]+),?`)
 	ioProfileRegex = regexp.MustCompile(api.SpecIoProfile + "=([0-9A-Za-z_-]+),?")
 )
 
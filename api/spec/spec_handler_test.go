@@ -54,3 +54,12 @@ func TestOptIoProfile(t *testing.T) {
 
 	testSpecFromStringErr(t, api.SpecIoProfile, "2")
 }
+
+func TestOptSnapshotSchedule(t *testing.T) {
+	testSpecOptString(t, api.SpecSnapshotSchedule, "periodic=60")
+
+	spec := testSpecFromString(t, api.SpecSnapshotSchedule,
+		"cron=*/30 1-5 * * mon#fri@America/New_York#7")
+	require.Equal(t, "cron=*/30 1-5 * * mon,fri@America/New_York,7",
+		spec.SnapshotSchedule, "Unexpected snap_schedule value")
+}
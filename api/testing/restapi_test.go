@@ -131,6 +131,14 @@ func TestVolumeDeleteSuccess(t *testing.T) {
 	// Setup mock
 	id := "myid"
 
+	ts.MockDriver().
+		EXPECT().
+		Inspect([]string{id}).
+		Return([]*api.Volume{{Id: id}}, nil)
+	ts.MockDriver().
+		EXPECT().
+		Enumerate(&api.VolumeLocator{}, nil).
+		Return(nil, nil)
 	ts.MockDriver().
 		EXPECT().
 		Delete(id).
@@ -157,6 +165,14 @@ func TestVolumeDeleteFailed(t *testing.T) {
 
 	id := "myid"
 
+	ts.MockDriver().
+		EXPECT().
+		Inspect([]string{id}).
+		Return([]*api.Volume{{Id: id}}, nil)
+	ts.MockDriver().
+		EXPECT().
+		Enumerate(&api.VolumeLocator{}, nil).
+		Return(nil, nil)
 	ts.MockDriver().
 		EXPECT().
 		Delete(id).
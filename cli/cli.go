@@ -2,6 +2,8 @@ package cli
 
 import (
 	"github.com/codegangsta/cli"
+
+	"github.com/libopenstorage/openstorage/api/client"
 )
 
 const (
@@ -11,6 +13,14 @@ const (
 	DaemonFlag = "daemon"
 	// DriverFlag key for for the driver parameter.
 	DriverFlag = "driver"
+	// EndpointFlag key for the REST endpoint of the volume driver API.
+	EndpointFlag = "endpoint"
+	// ClusterEndpointFlag key for the REST endpoint of the cluster API.
+	ClusterEndpointFlag = "cluster-endpoint"
+	// TokenFlag key for the bearer token sent to the REST endpoints.
+	TokenFlag = "token"
+	// TokenEnv environment variable holding the bearer token.
+	TokenEnv = "OSD_TOKEN"
 )
 
 // DaemonMode returns true if we are running as daemon
@@ -22,3 +32,33 @@ func DaemonMode(c *cli.Context) bool {
 func DriverName(c *cli.Context) string {
 	return c.GlobalString(DriverFlag)
 }
+
+// ClientFlags returns the global flags used by the CLI commands to reach
+// the REST endpoints of a remote OSD server.
+func ClientFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:   EndpointFlag,
+			Usage:  "REST endpoint of the volume driver API, e.g. https://10.0.0.1:9001. Defaults to the local unix socket",
+			EnvVar: "OSD_ENDPOINT",
+		},
+		cli.StringFlag{
+			Name:   ClusterEndpointFlag,
+			Usage:  "REST endpoint of the cluster API, e.g. https://10.0.0.1:9100. Defaults to the local unix socket",
+			EnvVar: "OSD_CLUSTER_ENDPOINT",
+		},
+		cli.StringFlag{
+			Name:   TokenFlag,
+			Usage:  "bearer token to authenticate with the REST endpoints",
+			EnvVar: TokenEnv,
+		},
+	}
+}
+
+// setToken configures clnt to send the token given with the -<TokenFlag>
+// parameter.
+func setToken(c *cli.Context, clnt *client.Client) {
+	if token := c.GlobalString(TokenFlag); token != "" {
+		clnt.SetToken(token)
+	}
+}
@@ -1,8 +1,13 @@
 package cli
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"testing"
 
+	"github.com/codegangsta/cli"
 	"github.com/libopenstorage/openstorage/api"
 	"github.com/stretchr/testify/require"
 )
@@ -41,3 +46,32 @@ func TestCmdMarshalProto(t *testing.T) {
 		data,
 	)
 }
+
+func TestClientFlags(t *testing.T) {
+	var auth string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		fmt.Fprint(w, "{}")
+	}))
+	defer ts.Close()
+
+	run := func(args ...string) {
+		c := &clusterClient{}
+		app := cli.NewApp()
+		app.Flags = ClientFlags()
+		app.Action = func(context *cli.Context) {
+			c.clusterOptions(context)
+			_, err := c.manager.Enumerate()
+			require.NoError(t, err)
+		}
+		require.NoError(t, app.Run(append([]string{"osd"}, args...)))
+	}
+
+	run("--"+ClusterEndpointFlag, ts.URL, "--"+TokenFlag, "flag-token")
+	require.Equal(t, "Bearer flag-token", auth)
+
+	os.Setenv(TokenEnv, "env-token")
+	defer os.Unsetenv(TokenEnv)
+	run("--"+ClusterEndpointFlag, ts.URL)
+	require.Equal(t, "Bearer env-token", auth)
+}
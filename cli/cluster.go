@@ -11,21 +11,25 @@ import (
 
 	"github.com/libopenstorage/gossip/types"
 	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/api/client"
 	clusterclient "github.com/libopenstorage/openstorage/api/client/cluster"
 	"github.com/libopenstorage/openstorage/cluster"
 )
 
 type clusterClient struct {
 	manager cluster.Cluster
+	client  *client.Client
 }
 
 func (c *clusterClient) clusterOptions(context *cli.Context) {
 	// Currently we choose the default version
-	clnt, err := clusterclient.NewClusterClient("", cluster.APIVersion)
+	clnt, err := clusterclient.NewClusterClient(context.GlobalString(ClusterEndpointFlag), cluster.APIVersion)
 	if err != nil {
 		fmt.Printf("Failed to initialize client library: %v\n", err)
 		os.Exit(1)
 	}
+	setToken(context, clnt)
+	c.client = clnt
 	c.manager = clusterclient.ClusterManager(clnt)
 }
 
@@ -143,6 +147,35 @@ func (c *clusterClient) gossipStatus(context *cli.Context) {
 	}
 }
 
+func (c *clusterClient) alertTypes(context *cli.Context) {
+	c.clusterOptions(context)
+	jsonOut := context.GlobalBool("json")
+	outFd := os.Stdout
+	fn := "alert-types"
+
+	alertTypes, err := clusterclient.EnumerateAlertTypes(c.client)
+	if err != nil {
+		cmdError(context, fn, err)
+		return
+	}
+
+	if jsonOut {
+		fmtOutput(context, &Format{Result: alertTypes})
+	} else {
+		w := new(tabwriter.Writer)
+		w.Init(outFd, 12, 12, 1, ' ', 0)
+
+		fmt.Fprintln(w, "TYPE\t NAME\t RESOURCE\t SEVERITY\t TTL\t AUTO CLEAR")
+		for _, t := range alertTypes {
+			fmt.Fprintln(w, t.Type, "\t", t.Name, "\t", t.Resource, "\t",
+				t.Severity, "\t", t.Ttl, "\t", t.AutoClear)
+		}
+
+		fmt.Fprintln(w)
+		w.Flush()
+	}
+}
+
 // ClusterCommands exports CLI comamnds for File VolumeDriver
 func ClusterCommands() []cli.Command {
 	c := &clusterClient{}
@@ -192,6 +225,12 @@ func ClusterCommands() []cli.Command {
 			Usage:   "Display gossip status",
 			Action:  c.gossipStatus,
 		},
+		{
+			Name:    "alert-types",
+			Aliases: []string{"at"},
+			Usage:   "List the alert types raised in the cluster",
+			Action:  c.alertTypes,
+		},
 		{
 			Name:    "remove",
 			Aliases: []string{"r"},
@@ -8,6 +8,7 @@ import (
 
 	"github.com/codegangsta/cli"
 	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/api/client"
 	clusterclient "github.com/libopenstorage/openstorage/api/client/cluster"
 	volumeclient "github.com/libopenstorage/openstorage/api/client/volume"
 	"github.com/libopenstorage/openstorage/cluster"
@@ -34,6 +35,7 @@ const (
 
 type volDriver struct {
 	volDriver volume.VolumeDriver
+	client    *client.Client
 	name      string
 }
 
@@ -55,11 +57,13 @@ func processLabels(s string) (map[string]string, error) {
 
 func (v *volDriver) volumeOptions(context *cli.Context) {
 	// Currently we choose the default version
-	clnt, err := volumeclient.NewDriverClient("", v.name, volume.APIVersion, "")
+	clnt, err := volumeclient.NewDriverClient(context.GlobalString(EndpointFlag), v.name, volume.APIVersion, "")
 	if err != nil {
 		fmt.Printf("Failed to initialize client library: %v\n", err)
 		os.Exit(1)
 	}
+	setToken(context, clnt)
+	v.client = clnt
 	v.volDriver = volumeclient.VolumeDriver(clnt)
 }
 
@@ -269,8 +273,22 @@ func (v *volDriver) volumeDelete(context *cli.Context) {
 	}
 	volumeID := context.Args()[0]
 	v.volumeOptions(context)
-	err := v.volDriver.Delete(volumeID)
-	if err != nil {
+	var err error
+	if context.Bool("force") {
+		err = volumeclient.ForceDelete(v.client, volumeID)
+	} else {
+		err = v.volDriver.Delete(volumeID)
+	}
+	switch err {
+	case nil:
+	case volume.ErrVolHasClones:
+		cmdErrorBody(context, fn, err,
+			"Use --force to delete the volume and keep its clones")
+		return
+	case volume.ErrVolAttached:
+		cmdErrorBody(context, fn, err, "Detach the volume before deleting it")
+		return
+	default:
 		cmdError(context, fn, err)
 		return
 	}
@@ -278,6 +296,53 @@ func (v *volDriver) volumeDelete(context *cli.Context) {
 	fmtOutput(context, &Format{UUID: []string{context.Args()[0]}})
 }
 
+func (v *volDriver) trashEnumerate(context *cli.Context) {
+	fn := "trashEnumerate"
+	v.volumeOptions(context)
+	volumes, err := volumeclient.TrashEnumerate(v.client)
+	if err != nil {
+		v.trashError(context, fn, err)
+		return
+	}
+	cmdOutputVolumes(volumes, context.GlobalBool("raw"))
+}
+
+func (v *volDriver) trashRestore(context *cli.Context) {
+	v.trashOp(context, "trashRestore", volumeclient.TrashRestore)
+}
+
+func (v *volDriver) trashPurge(context *cli.Context) {
+	v.trashOp(context, "trashPurge", volumeclient.TrashPurge)
+}
+
+func (v *volDriver) trashOp(
+	context *cli.Context,
+	fn string,
+	op func(c *client.Client, volumeID string) error,
+) {
+	if len(context.Args()) < 1 {
+		missingParameter(context, fn, "volumeID", "Invalid number of arguments")
+		return
+	}
+	volumeID := context.Args()[0]
+	v.volumeOptions(context)
+	if err := op(v.client, volumeID); err != nil {
+		v.trashError(context, fn, err)
+		return
+	}
+	fmtOutput(context, &Format{UUID: []string{volumeID}})
+}
+
+func (v *volDriver) trashError(context *cli.Context, fn string, err error) {
+	if err == volume.ErrNotSupported {
+		cmdErrorBody(context, fn, err, fmt.Sprintf(
+			"Set trash_retention in the %v driver config to enable the trash",
+			v.name))
+		return
+	}
+	cmdError(context, fn, err)
+}
+
 func (v *volDriver) snapCreate(context *cli.Context) {
 	var err error
 	var labels map[string]string
@@ -340,11 +405,12 @@ func (v *volDriver) snapEnumerate(context *cli.Context) {
 func (v *volDriver) volumeAlerts(context *cli.Context) {
 	v.volumeOptions(context)
 
-	clnt, err := clusterclient.NewClusterClient("", cluster.APIVersion)
+	clnt, err := clusterclient.NewClusterClient(context.GlobalString(ClusterEndpointFlag), cluster.APIVersion)
 	if err != nil {
 		fmt.Printf("Failed to initialize client library: %v\n", err)
 		return
 	}
+	setToken(context, clnt)
 	manager := clusterclient.ClusterManager(clnt)
 	alerts, err := manager.EnumerateAlerts(time.Time{}, time.Time{}, api.ResourceType_RESOURCE_TYPE_VOLUME)
 	if err != nil {
@@ -433,8 +499,14 @@ func baseVolumeCommand(v *volDriver) []cli.Command {
 		{
 			Name:    "delete",
 			Aliases: []string{"rm"},
-			Usage:   "Detach specified volume",
+			Usage:   "Delete specified volume",
 			Action:  v.volumeDelete,
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "force,f",
+					Usage: "delete the volume even if clones depend on it",
+				},
+			},
 		},
 		{
 			Name:    "enumerate",
@@ -504,6 +576,28 @@ func baseVolumeCommand(v *volDriver) []cli.Command {
 				},
 			},
 		},
+		{
+			Name:  "trash",
+			Usage: "Manage deleted volumes kept in the trash",
+			Subcommands: []cli.Command{
+				{
+					Name:    "enumerate",
+					Aliases: []string{"e"},
+					Usage:   "Enumerate volumes in the trash",
+					Action:  v.trashEnumerate,
+				},
+				{
+					Name:   "restore",
+					Usage:  "Move specified volume out of the trash",
+					Action: v.trashRestore,
+				},
+				{
+					Name:   "purge",
+					Usage:  "Delete specified volume from the trash now",
+					Action: v.trashPurge,
+				},
+			},
+		},
 	}
 	return commands
 }
@@ -27,26 +27,36 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
 	"net/url"
 	"os"
 	"runtime"
 	"strconv"
+	"time"
 
 	"go.pedge.io/dlog"
 
 	"github.com/codegangsta/cli"
 	"github.com/docker/docker/pkg/reexec"
+	"github.com/libopenstorage/openstorage/alert"
+	"github.com/libopenstorage/openstorage/alert/notifier"
 	"github.com/libopenstorage/openstorage/api"
 	"github.com/libopenstorage/openstorage/api/flexvolume"
+	"github.com/libopenstorage/openstorage/api/mgmt"
 	"github.com/libopenstorage/openstorage/api/server"
 	osdcli "github.com/libopenstorage/openstorage/cli"
 	"github.com/libopenstorage/openstorage/cluster"
 	"github.com/libopenstorage/openstorage/config"
 	"github.com/libopenstorage/openstorage/csi"
 	"github.com/libopenstorage/openstorage/graph/drivers"
+	"github.com/libopenstorage/openstorage/osdconfig"
+	"github.com/libopenstorage/openstorage/pkg/auth"
+	"github.com/libopenstorage/openstorage/pkg/sched"
 	"github.com/libopenstorage/openstorage/volume"
 	"github.com/libopenstorage/openstorage/volume/drivers"
+	"github.com/libopenstorage/openstorage/volume/snapsched"
 	"github.com/portworx/kvdb"
 	"github.com/portworx/kvdb/consul"
 	etcd "github.com/portworx/kvdb/etcd/v2"
@@ -89,7 +99,24 @@ func main() {
 			Usage: "file to read the OSD configuration from.",
 			Value: "",
 		},
+		cli.StringFlag{
+			Name:  "tls-cert",
+			Usage: "PEM certificate file to serve the management APIs over HTTPS",
+		},
+		cli.StringFlag{
+			Name:  "tls-key",
+			Usage: "PEM key file of the TLS certificate",
+		},
+		cli.StringFlag{
+			Name:  "tls-client-ca",
+			Usage: "PEM CA file to verify client certificates. Clients must present a certificate when set",
+		},
+		cli.StringFlag{
+			Name:  "tls-ca",
+			Usage: "PEM CA file to verify the management APIs of other nodes",
+		},
 	}
+	app.Flags = append(app.Flags, osdcli.ClientFlags()...)
 	app.Action = wrapAction(start)
 	app.Commands = []cli.Command{
 		{
@@ -171,6 +198,18 @@ func start(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if v := c.String("tls-cert"); v != "" {
+		cfg.Osd.TLS.CertFile = v
+	}
+	if v := c.String("tls-key"); v != "" {
+		cfg.Osd.TLS.KeyFile = v
+	}
+	if v := c.String("tls-client-ca"); v != "" {
+		cfg.Osd.TLS.ClientCAFile = v
+	}
+	if v := c.String("tls-ca"); v != "" {
+		cfg.Osd.TLS.CAFile = v
+	}
 	kvdbURL := c.String("kvdb")
 	u, err := url.Parse(kvdbURL)
 	scheme := u.Scheme
@@ -184,6 +223,38 @@ func start(c *cli.Context) error {
 		return fmt.Errorf("Failed to initialize KVDB: %v", err)
 	}
 
+	// Require bearer tokens on the REST ports, if enabled.
+	var (
+		internalToken string
+		authKey       []byte
+	)
+	if secret := cfg.Osd.Auth.SharedSecret; secret != "" {
+		authKey = []byte(secret)
+		server.EnableAuth(authKey)
+		// Token used by this node to call the volume API of its peers.
+		internalToken, err = auth.NewToken(&auth.Claims{
+			Subject:  cfg.Osd.ClusterConfig.NodeId,
+			Issuer:   "osd",
+			Roles:    []auth.Role{auth.RoleVolumeAdmin},
+			IssuedAt: time.Now().Unix(),
+		}, authKey)
+		if err != nil {
+			return fmt.Errorf("Unable to create internal auth token: %v", err)
+		}
+	}
+
+	// Serve the management APIs over HTTPS, if enabled.
+	var serverTLS, clientTLS *tls.Config
+	if cfg.Osd.TLS.Enabled() {
+		if serverTLS, err = cfg.Osd.TLS.ServerConfig(); err != nil {
+			return err
+		}
+		server.EnableTLS(serverTLS)
+		if clientTLS, err = cfg.Osd.TLS.ClientConfig(); err != nil {
+			return err
+		}
+	}
+
 	// Start the cluster state machine, if enabled.
 	clusterInit := false
 	if cfg.Osd.ClusterConfig.NodeId != "" && cfg.Osd.ClusterConfig.ClusterId != "" {
@@ -194,9 +265,32 @@ func start(c *cli.Context) error {
 		if err := server.StartClusterAPI(cluster.APIBase, 0); err != nil {
 			return fmt.Errorf("Unable to start cluster API server: %v", err)
 		}
+		// Alerts raised by the volume drivers, e.g. during volume recovery.
+		alerts, err := alert.New(alert.Name, cfg.Osd.ClusterConfig.ClusterId, kv)
+		if err != nil {
+			return fmt.Errorf("Unable to init alerts: %v", err)
+		}
+		// Deliver alerts to the sinks of the cluster configuration.
+		configManager, err := osdconfig.NewManager(context.Background(), kv)
+		if err != nil {
+			return fmt.Errorf("Unable to watch the cluster configuration: %v", err)
+		}
+		if _, err := notifier.Start(
+			alerts,
+			kv,
+			configManager,
+			cfg.Osd.ClusterConfig.ClusterId,
+			cfg.Osd.ClusterConfig.NodeId,
+		); err != nil {
+			return fmt.Errorf("Unable to start alert notifier: %v", err)
+		}
 		clusterInit = true
 	}
 
+	if sched.Instance() == nil {
+		sched.Init(time.Second)
+	}
+
 	isDefaultSet := false
 	// Start the volume drivers.
 	for d, v := range cfg.Osd.Drivers {
@@ -205,7 +299,7 @@ func start(c *cli.Context) error {
 			return fmt.Errorf("Unable to start volume driver: %v, %v", d, err)
 		}
 
-		var mgmtPort, pluginPort uint64
+		var mgmtPort, pluginPort, grpcPort, grpcGatewayPort uint64
 		if port, ok := v[config.MgmtPortKey]; ok {
 			mgmtPort, err = strconv.ParseUint(port, 10, 16)
 			if err != nil {
@@ -224,6 +318,20 @@ func start(c *cli.Context) error {
 			pluginPort = 0
 		}
 
+		if port, ok := v[config.GrpcPortKey]; ok {
+			grpcPort, err = strconv.ParseUint(port, 10, 16)
+			if err != nil {
+				return fmt.Errorf("Invalid OSD Config File. Invalid gRPC Port number for Driver : %s", d)
+			}
+		}
+
+		if port, ok := v[config.GrpcGatewayPortKey]; ok {
+			grpcGatewayPort, err = strconv.ParseUint(port, 10, 16)
+			if err != nil {
+				return fmt.Errorf("Invalid OSD Config File. Invalid gRPC Gateway Port number for Driver : %s", d)
+			}
+		}
+
 		if err := server.StartPluginAPI(
 			d,
 			volume.DriverAPIBase,
@@ -247,11 +355,56 @@ func start(c *cli.Context) error {
 			Address:    fmt.Sprintf("/var/lib/osd/driver/%s-csi.sock", d),
 			DriverName: d,
 			Cluster:    cm,
+			MgmtPort:   uint16(mgmtPort),
+			AuthToken:  internalToken,
+			TLS:        clientTLS,
 		})
 		if err != nil {
 			return fmt.Errorf("Failed to start CSI server for driver %s: %v", d, err)
 		}
 		csiServer.Start()
+
+		// Start the gRPC management API for this driver
+		mgmtConfig := &mgmt.ServerConfig{
+			DriverName: d,
+			Cluster:    cm,
+			Socket:     fmt.Sprintf("%s%s-grpc.sock", volume.DriverAPIBase, d),
+			TLS:        serverTLS,
+			ClientTLS:  clientTLS,
+			AuthKey:    authKey,
+		}
+		if grpcPort != 0 {
+			mgmtConfig.Address = fmt.Sprintf(":%d", grpcPort)
+		}
+		if grpcGatewayPort != 0 {
+			mgmtConfig.GatewayAddress = fmt.Sprintf(":%d", grpcGatewayPort)
+		}
+		mgmtServer, err := mgmt.NewServer(mgmtConfig)
+		if err != nil {
+			return fmt.Errorf("Failed to start gRPC management server for driver %s: %v", d, err)
+		}
+		if err := mgmtServer.Start(); err != nil {
+			return fmt.Errorf("Failed to start gRPC management server for driver %s: %v", d, err)
+		}
+
+		// Start the snapshot scheduler for this driver
+		driver, err := volumedrivers.Get(d)
+		if err != nil {
+			return fmt.Errorf("Unable to find volume driver %s: %v", d, err)
+		}
+		var nodes snapsched.Nodes
+		if clusterInit {
+			nodes = cm
+		}
+		if err := snapsched.New(
+			driver,
+			kvdb.Instance(),
+			sched.Instance(),
+			nodes,
+			cfg.Osd.ClusterConfig.NodeId,
+		).Start(); err != nil {
+			return fmt.Errorf("Unable to start snapshot scheduler for %s: %v", d, err)
+		}
 	}
 
 	if cfg.Osd.ClusterConfig.DefaultDriver != "" && !isDefaultSet {
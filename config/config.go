@@ -1,6 +1,8 @@
 package config
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -17,6 +19,8 @@ const (
 	UrlKey                    = "url"
 	MgmtPortKey               = "mgmtPort"
 	PluginPortKey             = "pluginPort"
+	GrpcPortKey               = "grpcPort"
+	GrpcGatewayPortKey        = "grpcGatewayPort"
 	VersionKey                = "version"
 	DataDir                   = ".data"
 	FlexVolumePort     uint16 = 2345
@@ -42,9 +46,88 @@ type ClusterConfig struct {
 	FluentDHost   string
 }
 
+// AuthConfig configures authentication of the REST management API.
+type AuthConfig struct {
+	// SharedSecret is the HMAC key used to sign and verify bearer tokens.
+	// Authentication is disabled when it is empty.
+	SharedSecret string `yaml:"shared_secret"`
+}
+
+// TLSConfig configures TLS for the TCP listeners of the REST management
+// and cluster APIs. The unix socket listeners are not affected.
+type TLSConfig struct {
+	// CertFile and KeyFile hold the PEM encoded certificate and key of the
+	// server. TLS is disabled when they are empty.
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+	// ClientCAFile holds the PEM encoded CAs used to verify client
+	// certificates. When set, clients must present a valid certificate.
+	ClientCAFile string `yaml:"client_ca_file"`
+	// CAFile holds the PEM encoded CAs used to verify the servers of other
+	// nodes. The system roots are used when it is empty.
+	CAFile string `yaml:"ca_file"`
+}
+
+// Enabled returns true if a server certificate is configured.
+func (c *TLSConfig) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != ""
+}
+
+// ServerConfig returns the TLS configuration of the REST servers.
+func (c *TLSConfig) ServerConfig() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load TLS certificate: %v", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if c.ClientCAFile != "" {
+		if tlsConfig.ClientCAs, err = loadCertPool(c.ClientCAFile); err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
+
+// ClientConfig returns the TLS configuration used to call the REST servers
+// of other nodes. The server certificate is presented as client certificate.
+func (c *TLSConfig) ClientConfig() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load TLS certificate: %v", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if c.CAFile != "" {
+		if tlsConfig.RootCAs, err = loadCertPool(c.CAFile); err != nil {
+			return nil, err
+		}
+	}
+	return tlsConfig, nil
+}
+
+func loadCertPool(file string) (*x509.CertPool, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read CA file (%s): %v", file, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("No certificates found in CA file (%s)", file)
+	}
+	return pool, nil
+}
+
 type Config struct {
 	Osd struct {
 		ClusterConfig ClusterConfig `yaml:"cluster"`
+		Auth          AuthConfig    `yaml:"auth"`
+		TLS           TLSConfig     `yaml:"tls"`
 		// map[string]string is volume.VolumeParams equivalent
 		Drivers map[string]map[string]string
 		// map[string]string is volume.VolumeParams equivalent
@@ -22,7 +22,10 @@ import (
 	"github.com/portworx/kvdb"
 
 	"github.com/libopenstorage/openstorage/api"
+	volumeclient "github.com/libopenstorage/openstorage/api/client/volume"
+	"github.com/libopenstorage/openstorage/pkg/options"
 	"github.com/libopenstorage/openstorage/pkg/util"
+	"github.com/libopenstorage/openstorage/volume"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
 	"go.pedge.io/dlog"
@@ -37,6 +40,7 @@ const (
 	volumeCapabilityMessageReadOnlyVolume     = "Volume is read only"
 	volumeCapabilityMessageNotReadOnlyVolume  = "Volume is not read only"
 	defaultCSIVolumeSize                      = uint64(1024 * 1024 * 1024)
+	publishInfoDevicePath                     = "devicePath"
 )
 
 // ControllerGetCapabilities is a CSI API functions which returns to the caller
@@ -69,32 +73,266 @@ func (s *OsdCsiServer) ControllerGetCapabilities(
 		},
 	}
 
+	// Attaching and detaching volumes supported
+	capPublishUnpublishVolume := &csi.ControllerServiceCapability{
+		Type: &csi.ControllerServiceCapability_Rpc{
+			Rpc: &csi.ControllerServiceCapability_RPC{
+				Type: csi.ControllerServiceCapability_RPC_PUBLISH_UNPUBLISH_VOLUME,
+			},
+		},
+	}
+
 	return &csi.ControllerGetCapabilitiesResponse{
 		Capabilities: []*csi.ControllerServiceCapability{
 			capCreateDeleteVolume,
 			capListVolumes,
+			capPublishUnpublishVolume,
 		},
 	}, nil
 
 }
 
 // ControllerPublishVolume is a CSI API implements the attachment of a volume
-// on to a node.
+// on to a node. Block volumes are attached on the node and the device path
+// is returned in the publish info. Other volumes need no attachment.
 func (s *OsdCsiServer) ControllerPublishVolume(
-	context.Context,
-	*csi.ControllerPublishVolumeRequest,
+	ctx context.Context,
+	req *csi.ControllerPublishVolumeRequest,
 ) (*csi.ControllerPublishVolumeResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "This request is not supported")
 
+	dlog.Debugf("ControllerPublishVolume req[%#v]", req)
+
+	// Check arguments
+	if req.GetVersion() == nil {
+		return nil, status.Error(codes.InvalidArgument, "Version must be provided")
+	}
+	if len(req.GetVolumeId()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Volume id must be provided")
+	}
+	if len(req.GetNodeId()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Node id must be provided")
+	}
+	if req.GetVolumeCapability() == nil || req.GetVolumeCapability().GetAccessMode() == nil {
+		return nil, status.Error(codes.InvalidArgument, "Volume access mode must be provided")
+	}
+
+	// Get volume information
+	if _, err := util.VolumeFromName(s.driver, req.GetVolumeId()); err != nil {
+		return nil, status.Errorf(codes.NotFound, "Volume id %s not found: %s",
+			req.GetVolumeId(),
+			err.Error())
+	}
+
+	// Gather volume attributes
+	spec, _, _, err := s.specHandler.SpecFromOpts(req.GetVolumeAttributes())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Invalid volume attributes: %#v",
+			req.GetVolumeAttributes())
+	}
+
+	// Only block drivers attach volumes
+	if s.driver.Type() != api.DriverType_DRIVER_TYPE_BLOCK {
+		return &csi.ControllerPublishVolumeResponse{}, nil
+	}
+
+	node, local, err := s.clusterNode(req.GetNodeId())
+	if err != nil {
+		return nil, err
+	}
+	if !local && node.Status != api.Status_STATUS_OK {
+		return nil, status.Errorf(codes.Unavailable, "Node %s is not online: %v",
+			req.GetNodeId(),
+			node.Status)
+	}
+	d, err := s.nodeDriver(node, local)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := make(map[string]string)
+	if len(spec.GetPassphrase()) != 0 {
+		opts[options.OptionsSecret] = spec.GetPassphrase()
+	}
+	devicePath, err := d.Attach(req.GetVolumeId(), opts)
+	if err == volume.ErrVolAttachedOnRemoteNode {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"Volume %s is attached on another node",
+			req.GetVolumeId())
+	} else if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"Unable to attach volume %s on node %s: %s",
+			req.GetVolumeId(),
+			req.GetNodeId(),
+			err.Error())
+	}
+
+	dlog.Infof("Volume %s attached on node %s at %s",
+		req.GetVolumeId(),
+		req.GetNodeId(),
+		devicePath)
+
+	return &csi.ControllerPublishVolumeResponse{
+		PublishVolumeInfo: map[string]string{
+			publishInfoDevicePath: devicePath,
+		},
+	}, nil
 }
 
 // ControllerUnpublishVolume is a CSI API which implements the detaching of a volume
-// onto a node.
+// onto a node. Volumes attached on a node that is not online are force
+// detached from the local node.
 func (s *OsdCsiServer) ControllerUnpublishVolume(
-	context.Context,
-	*csi.ControllerUnpublishVolumeRequest,
+	ctx context.Context,
+	req *csi.ControllerUnpublishVolumeRequest,
 ) (*csi.ControllerUnpublishVolumeResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "This request is not supported")
+
+	dlog.Debugf("ControllerUnpublishVolume req[%#v]", req)
+
+	// Check arguments
+	if req.GetVersion() == nil {
+		return nil, status.Error(codes.InvalidArgument, "Version must be provided")
+	}
+	if len(req.GetVolumeId()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Volume id must be provided")
+	}
+
+	// Get volume information
+	v, err := util.VolumeFromName(s.driver, req.GetVolumeId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Volume id %s not found: %s",
+			req.GetVolumeId(),
+			err.Error())
+	}
+
+	// Only block drivers attach volumes
+	if s.driver.Type() != api.DriverType_DRIVER_TYPE_BLOCK {
+		return &csi.ControllerUnpublishVolumeResponse{}, nil
+	}
+
+	// Without a node id, detach from the node the volume is attached on
+	nodeID := req.GetNodeId()
+	if len(nodeID) == 0 {
+		nodeID = v.GetAttachedOn()
+	}
+	if len(nodeID) == 0 || (v.GetAttachedOn() != "" && v.GetAttachedOn() != nodeID) {
+		dlog.Infof("Volume %s is not attached on node %s", v.GetId(), nodeID)
+		return &csi.ControllerUnpublishVolumeResponse{}, nil
+	}
+
+	node, local, err := s.clusterNode(nodeID)
+	if err != nil {
+		return nil, err
+	}
+	flags := api.OperationFlags_OP_FLAGS_NONE
+	d := s.driver
+	if !local {
+		if node.Status == api.Status_STATUS_OK {
+			if d, err = s.nodeDriver(node, local); err != nil {
+				return nil, err
+			}
+		} else {
+			dlog.Warnf("Node %s is not online, force detaching volume %s",
+				nodeID,
+				v.GetId())
+			flags = api.OperationFlags_OP_FLAGS_DETACH_FORCE
+		}
+	}
+
+	if err := d.Detach(v.GetId(), detachOptions(flags)); err != nil &&
+		err != volume.ErrVolDetached {
+		return nil, status.Errorf(
+			codes.Internal,
+			"Unable to detach volume %s from node %s: %s",
+			v.GetId(),
+			nodeID,
+			err.Error())
+	}
+
+	dlog.Infof("Volume %s detached from node %s", v.GetId(), nodeID)
+
+	return &csi.ControllerUnpublishVolumeResponse{}, nil
+}
+
+// clusterNode returns the cluster node with the specified id and whether
+// it is the local node.
+func (s *OsdCsiServer) clusterNode(nodeID string) (api.Node, bool, error) {
+	clus, err := s.cluster.Enumerate()
+	if err != nil {
+		return api.Node{}, false, status.Errorf(
+			codes.Internal,
+			"Unable to Enumerate cluster: %s",
+			err.Error())
+	}
+	if clus.NodeId == nodeID {
+		return api.Node{Id: nodeID, Status: api.Status_STATUS_OK}, true, nil
+	}
+	node, err := s.cluster.Inspect(nodeID)
+	if err != nil {
+		return api.Node{}, false, status.Errorf(
+			codes.NotFound,
+			"Node %s not found: %s",
+			nodeID,
+			err.Error())
+	}
+	return node, false, nil
+}
+
+// nodeDriver returns the driver used to attach and detach volumes on node.
+func (s *OsdCsiServer) nodeDriver(node api.Node, local bool) (volume.VolumeDriver, error) {
+	if local {
+		return s.driver, nil
+	}
+	d, err := s.remoteDriver(node)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"Unable to reach node %s: %s",
+			node.Id,
+			err.Error())
+	}
+	return d, nil
+}
+
+// remoteVolumeDriver returns a client to the volume management API of the
+// driver on node.
+func (s *OsdCsiServer) remoteVolumeDriver(node api.Node) (volume.VolumeDriver, error) {
+	if s.mgmtPort == 0 {
+		return nil, fmt.Errorf("Volume management port of driver %s is not configured",
+			s.driverName)
+	}
+	if len(node.MgmtIp) == 0 {
+		return nil, fmt.Errorf("Node %s has no management IP", node.Id)
+	}
+	scheme := "http"
+	if s.tlsConfig != nil {
+		scheme = "https"
+	}
+	c, err := volumeclient.NewDriverClient(
+		fmt.Sprintf("%s://%s:%d", scheme, node.MgmtIp, s.mgmtPort),
+		s.driverName,
+		"",
+		s.driverName)
+	if err != nil {
+		return nil, err
+	}
+	c.SetToken(s.authToken)
+	if s.tlsConfig != nil {
+		c.SetTLS(s.tlsConfig)
+	}
+	return volumeclient.VolumeDriver(c), nil
+}
+
+// detachOptions returns the driver options for the operation flags.
+func detachOptions(flags api.OperationFlags) map[string]string {
+	opts := make(map[string]string)
+	if flags == api.OperationFlags_OP_FLAGS_DETACH_FORCE {
+		opts[options.OptionsForceDetach] = "true"
+	}
+	return opts
 }
 
 // ValidateVolumeCapabilities is a CSI API used by container orchestration systems
@@ -241,8 +479,8 @@ func (s *OsdCsiServer) ValidateVolumeCapabilities(
 // using other interfaces. This is important because the user could
 // be requesting to mount a OSD volume created using non-CSI interfaces.
 //
-// This call does not yet implement tokens to due to the following
-// issue: https://github.com/container-storage-interface/spec/issues/138
+// When max_entries is set, volumes are returned in pages and next_token is
+// passed as starting_token to get the following page.
 func (s *OsdCsiServer) ListVolumes(
 	ctx context.Context,
 	req *csi.ListVolumesRequest,
@@ -257,21 +495,15 @@ func (s *OsdCsiServer) ListVolumes(
 		return nil, status.Error(codes.InvalidArgument, "Version must be provided")
 	}
 
-	// Until the issue #138 on the CSI spec is resolved we will not support
-	// tokenization
-	if req.GetMaxEntries() != 0 {
-		return nil, status.Error(
-			codes.Unimplemented,
-			"Driver does not support tokenization. Please see "+
-				"https://github.com/container-storage-interface/spec/issues/138")
-	}
-
-	volumes, err := s.driver.Enumerate(&api.VolumeLocator{}, nil)
-	if err != nil {
+	page, err := s.driver.EnumeratePage(nil, int(req.GetMaxEntries()), req.GetStartingToken())
+	if err == volume.ErrInvalidPageToken {
+		return nil, status.Errorf(codes.Aborted, "Invalid starting token %s", req.GetStartingToken())
+	} else if err != nil {
 		errs := fmt.Sprintf("Unable to get list of volumes: %s", err.Error())
 		dlog.Errorln(errs)
 		return nil, status.Error(codes.Internal, errs)
 	}
+	volumes := page.Volumes
 	entries := make([]*csi.ListVolumesResponse_Entry, len(volumes))
 	for i, v := range volumes {
 		// Initialize entry
@@ -293,7 +525,8 @@ func (s *OsdCsiServer) ListVolumes(
 	}
 
 	return &csi.ListVolumesResponse{
-		Entries: entries,
+		Entries:   entries,
+		NextToken: page.NextToken,
 	}, nil
 }
 
@@ -450,6 +683,14 @@ func (s *OsdCsiServer) DeleteVolume(
 		return nil, err
 	}
 
+	// Refuse to delete protected volumes
+	if err := volume.CheckDelete(s.driver, req.GetVolumeId(), false); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"Unable to delete volume with id %s: %s",
+			req.GetVolumeId(),
+			err.Error())
+	}
+
 	// Delete volume
 	err = s.driver.Delete(req.GetVolumeId())
 	if err != nil {
@@ -21,6 +21,9 @@ import (
 	"testing"
 
 	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/options"
+	"github.com/libopenstorage/openstorage/volume"
+	mockdriver "github.com/libopenstorage/openstorage/volume/drivers/mock"
 	"github.com/portworx/kvdb"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
@@ -50,6 +53,7 @@ func TestControllerGetCapabilities(t *testing.T) {
 	expectedValues := []csi.ControllerServiceCapability_RPC_Type{
 		csi.ControllerServiceCapability_RPC_LIST_VOLUMES,
 		csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME,
+		csi.ControllerServiceCapability_RPC_PUBLISH_UNPUBLISH_VOLUME,
 	}
 	caps := r.GetCapabilities()
 	assert.Len(t, caps, len(expectedValues))
@@ -65,38 +69,420 @@ func TestControllerGetCapabilities(t *testing.T) {
 	assert.Equal(t, found, len(expectedValues))
 }
 
-func TestControllerPublishVolume(t *testing.T) {
+func TestControllerPublishVolumeBadArguments(t *testing.T) {
 	// Create server and client connection
 	s := newTestServer(t)
 	defer s.Stop()
+	c := csi.NewControllerClient(s.Conn())
 
-	// Make a call
+	tests := []struct {
+		req                   *csi.ControllerPublishVolumeRequest
+		expectedErrorContains string
+	}{
+		{
+			req:                   &csi.ControllerPublishVolumeRequest{},
+			expectedErrorContains: "Version",
+		},
+		{
+			req: &csi.ControllerPublishVolumeRequest{
+				Version: &csi.Version{},
+			},
+			expectedErrorContains: "Volume id",
+		},
+		{
+			req: &csi.ControllerPublishVolumeRequest{
+				Version:  &csi.Version{},
+				VolumeId: "abc",
+			},
+			expectedErrorContains: "Node id",
+		},
+		{
+			req: &csi.ControllerPublishVolumeRequest{
+				Version:  &csi.Version{},
+				VolumeId: "abc",
+				NodeId:   "node",
+			},
+			expectedErrorContains: "access mode",
+		},
+	}
+
+	for _, test := range tests {
+		_, err := c.ControllerPublishVolume(context.Background(), test.req)
+		assert.NotNil(t, err)
+		serverError, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, serverError.Code(), codes.InvalidArgument)
+		assert.Contains(t, serverError.Message(), test.expectedErrorContains)
+	}
+}
+
+func newControllerPublishVolumeRequest(name, nodeID string) *csi.ControllerPublishVolumeRequest {
+	return &csi.ControllerPublishVolumeRequest{
+		Version:  &csi.Version{},
+		VolumeId: name,
+		NodeId:   nodeID,
+		VolumeCapability: &csi.VolumeCapability{
+			AccessMode: &csi.VolumeCapability_AccessMode{},
+		},
+	}
+}
+
+func TestControllerPublishVolumeNotFound(t *testing.T) {
+	// Create server and client connection
+	s := newTestServer(t)
+	defer s.Stop()
 	c := csi.NewControllerClient(s.Conn())
-	_, err := c.ControllerPublishVolume(context.Background(), &csi.ControllerPublishVolumeRequest{})
-	assert.NotNil(t, err)
 
+	name := "myvol"
+	s.MockDriver().
+		EXPECT().
+		Inspect([]string{name}).
+		Return(nil, fmt.Errorf("not found")).
+		Times(1)
+	s.MockDriver().
+		EXPECT().
+		Enumerate(&api.VolumeLocator{Name: name}, nil).
+		Return(nil, fmt.Errorf("not found")).
+		Times(1)
+
+	_, err := c.ControllerPublishVolume(context.Background(),
+		newControllerPublishVolumeRequest(name, "node1"))
+	assert.NotNil(t, err)
 	serverError, ok := status.FromError(err)
 	assert.True(t, ok)
+	assert.Equal(t, serverError.Code(), codes.NotFound)
+}
+
+func TestControllerPublishVolumeNotBlock(t *testing.T) {
+	// Create server and client connection
+	s := newTestServer(t)
+	defer s.Stop()
+	c := csi.NewControllerClient(s.Conn())
 
-	assert.Equal(t, serverError.Code(), codes.Unimplemented)
-	assert.Contains(t, serverError.Message(), "not supported")
+	name := "myvol"
+	gomock.InOrder(
+		s.MockDriver().
+			EXPECT().
+			Inspect([]string{name}).
+			Return([]*api.Volume{
+				&api.Volume{
+					Id: name,
+				},
+			}, nil).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Type().
+			Return(api.DriverType_DRIVER_TYPE_FILE).
+			Times(1),
+	)
+
+	r, err := c.ControllerPublishVolume(context.Background(),
+		newControllerPublishVolumeRequest(name, "node1"))
+	assert.Nil(t, err)
+	assert.Empty(t, r.GetPublishVolumeInfo())
 }
 
-func TestControllerUnPublishVolume(t *testing.T) {
+func TestControllerPublishVolumeLocal(t *testing.T) {
 	// Create server and client connection
 	s := newTestServer(t)
 	defer s.Stop()
+	c := csi.NewControllerClient(s.Conn())
 
-	// Make a call
+	name := "myvol"
+	devicePath := "/dev/mydev"
+	gomock.InOrder(
+		s.MockDriver().
+			EXPECT().
+			Inspect([]string{name}).
+			Return([]*api.Volume{
+				&api.Volume{
+					Id: name,
+				},
+			}, nil).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Type().
+			Return(api.DriverType_DRIVER_TYPE_BLOCK).
+			Times(1),
+		s.MockCluster().
+			EXPECT().
+			Enumerate().
+			Return(api.Cluster{NodeId: "node1"}, nil).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Attach(name, map[string]string{}).
+			Return(devicePath, nil).
+			Times(1),
+	)
+
+	r, err := c.ControllerPublishVolume(context.Background(),
+		newControllerPublishVolumeRequest(name, "node1"))
+	assert.Nil(t, err)
+	assert.Equal(t, devicePath, r.GetPublishVolumeInfo()[publishInfoDevicePath])
+}
+
+func TestControllerPublishVolumeRemote(t *testing.T) {
+	// Create server and client connection
+	s := newTestServer(t)
+	defer s.Stop()
 	c := csi.NewControllerClient(s.Conn())
-	_, err := c.ControllerUnpublishVolume(context.Background(), &csi.ControllerUnpublishVolumeRequest{})
-	assert.NotNil(t, err)
 
+	// Volumes on other nodes are attached through their driver
+	remote := mockdriver.NewMockVolumeDriver(s.mc)
+	s.Server().(*OsdCsiServer).remoteDriver = func(node api.Node) (volume.VolumeDriver, error) {
+		assert.Equal(t, "node2", node.Id)
+		return remote, nil
+	}
+
+	name := "myvol"
+	devicePath := "/dev/mydev"
+	gomock.InOrder(
+		s.MockDriver().
+			EXPECT().
+			Inspect([]string{name}).
+			Return([]*api.Volume{
+				&api.Volume{
+					Id: name,
+				},
+			}, nil).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Type().
+			Return(api.DriverType_DRIVER_TYPE_BLOCK).
+			Times(1),
+		s.MockCluster().
+			EXPECT().
+			Enumerate().
+			Return(api.Cluster{NodeId: "node1"}, nil).
+			Times(1),
+		s.MockCluster().
+			EXPECT().
+			Inspect("node2").
+			Return(api.Node{Id: "node2", Status: api.Status_STATUS_OK}, nil).
+			Times(1),
+		remote.
+			EXPECT().
+			Attach(name, gomock.Any()).
+			Return(devicePath, nil).
+			Times(1),
+	)
+
+	r, err := c.ControllerPublishVolume(context.Background(),
+		newControllerPublishVolumeRequest(name, "node2"))
+	assert.Nil(t, err)
+	assert.Equal(t, devicePath, r.GetPublishVolumeInfo()[publishInfoDevicePath])
+}
+
+func TestControllerPublishVolumeNodeErrors(t *testing.T) {
+	// Create server and client connection
+	s := newTestServer(t)
+	defer s.Stop()
+	c := csi.NewControllerClient(s.Conn())
+
+	name := "myvol"
+	s.MockDriver().
+		EXPECT().
+		Inspect([]string{name}).
+		Return([]*api.Volume{
+			&api.Volume{
+				Id: name,
+			},
+		}, nil).
+		AnyTimes()
+	s.MockDriver().
+		EXPECT().
+		Type().
+		Return(api.DriverType_DRIVER_TYPE_BLOCK).
+		AnyTimes()
+	s.MockCluster().
+		EXPECT().
+		Enumerate().
+		Return(api.Cluster{NodeId: "node1"}, nil).
+		AnyTimes()
+
+	// Unknown node
+	s.MockCluster().
+		EXPECT().
+		Inspect("node2").
+		Return(api.Node{}, fmt.Errorf("no such node")).
+		Times(1)
+	_, err := c.ControllerPublishVolume(context.Background(),
+		newControllerPublishVolumeRequest(name, "node2"))
 	serverError, ok := status.FromError(err)
 	assert.True(t, ok)
+	assert.Equal(t, serverError.Code(), codes.NotFound)
+
+	// Node is down
+	s.MockCluster().
+		EXPECT().
+		Inspect("node3").
+		Return(api.Node{Id: "node3", Status: api.Status_STATUS_OFFLINE}, nil).
+		Times(1)
+	_, err = c.ControllerPublishVolume(context.Background(),
+		newControllerPublishVolumeRequest(name, "node3"))
+	serverError, ok = status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, serverError.Code(), codes.Unavailable)
+
+	// Attach fails
+	s.MockDriver().
+		EXPECT().
+		Attach(name, gomock.Any()).
+		Return("", fmt.Errorf("ATTACH ERROR")).
+		Times(1)
+	_, err = c.ControllerPublishVolume(context.Background(),
+		newControllerPublishVolumeRequest(name, "node1"))
+	serverError, ok = status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, serverError.Code(), codes.Internal)
+	assert.Contains(t, serverError.Message(), "ATTACH ERROR")
+}
+
+func TestControllerUnpublishVolumeBadArguments(t *testing.T) {
+	// Create server and client connection
+	s := newTestServer(t)
+	defer s.Stop()
+	c := csi.NewControllerClient(s.Conn())
+
+	_, err := c.ControllerUnpublishVolume(context.Background(),
+		&csi.ControllerUnpublishVolumeRequest{})
+	serverError, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, serverError.Code(), codes.InvalidArgument)
+	assert.Contains(t, serverError.Message(), "Version")
+
+	_, err = c.ControllerUnpublishVolume(context.Background(),
+		&csi.ControllerUnpublishVolumeRequest{
+			Version: &csi.Version{},
+		})
+	serverError, ok = status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, serverError.Code(), codes.InvalidArgument)
+	assert.Contains(t, serverError.Message(), "Volume id")
+}
+
+func TestControllerUnpublishVolume(t *testing.T) {
+	// Create server and client connection
+	s := newTestServer(t)
+	defer s.Stop()
+	c := csi.NewControllerClient(s.Conn())
+
+	name := "myvol"
+	gomock.InOrder(
+		s.MockDriver().
+			EXPECT().
+			Inspect([]string{name}).
+			Return([]*api.Volume{
+				&api.Volume{
+					Id:         name,
+					AttachedOn: "node1",
+				},
+			}, nil).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Type().
+			Return(api.DriverType_DRIVER_TYPE_BLOCK).
+			Times(1),
+		s.MockCluster().
+			EXPECT().
+			Enumerate().
+			Return(api.Cluster{NodeId: "node1"}, nil).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Detach(name, map[string]string{}).
+			Return(nil).
+			Times(1),
+	)
+
+	_, err := c.ControllerUnpublishVolume(context.Background(),
+		&csi.ControllerUnpublishVolumeRequest{
+			Version:  &csi.Version{},
+			VolumeId: name,
+			NodeId:   "node1",
+		})
+	assert.Nil(t, err)
+
+	// Not attached on the node
+	gomock.InOrder(
+		s.MockDriver().
+			EXPECT().
+			Inspect([]string{name}).
+			Return([]*api.Volume{
+				&api.Volume{
+					Id:         name,
+					AttachedOn: "node2",
+				},
+			}, nil).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Type().
+			Return(api.DriverType_DRIVER_TYPE_BLOCK).
+			Times(1),
+	)
+	_, err = c.ControllerUnpublishVolume(context.Background(),
+		&csi.ControllerUnpublishVolumeRequest{
+			Version:  &csi.Version{},
+			VolumeId: name,
+			NodeId:   "node1",
+		})
+	assert.Nil(t, err)
+}
+
+func TestControllerUnpublishVolumeForce(t *testing.T) {
+	// Create server and client connection
+	s := newTestServer(t)
+	defer s.Stop()
+	c := csi.NewControllerClient(s.Conn())
+
+	// Detach from the node the volume is attached on when no node is
+	// specified. The node is down so the volume is force detached.
+	name := "myvol"
+	gomock.InOrder(
+		s.MockDriver().
+			EXPECT().
+			Inspect([]string{name}).
+			Return([]*api.Volume{
+				&api.Volume{
+					Id:         name,
+					AttachedOn: "node2",
+				},
+			}, nil).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Type().
+			Return(api.DriverType_DRIVER_TYPE_BLOCK).
+			Times(1),
+		s.MockCluster().
+			EXPECT().
+			Enumerate().
+			Return(api.Cluster{NodeId: "node1"}, nil).
+			Times(1),
+		s.MockCluster().
+			EXPECT().
+			Inspect("node2").
+			Return(api.Node{Id: "node2", Status: api.Status_STATUS_OFFLINE}, nil).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Detach(name, map[string]string{options.OptionsForceDetach: "true"}).
+			Return(nil).
+			Times(1),
+	)
 
-	assert.Equal(t, serverError.Code(), codes.Unimplemented)
-	assert.Contains(t, serverError.Message(), "not supported")
+	_, err := c.ControllerUnpublishVolume(context.Background(),
+		&csi.ControllerUnpublishVolumeRequest{
+			Version:  &csi.Version{},
+			VolumeId: name,
+		})
+	assert.Nil(t, err)
 }
 
 func TestControllerValidateVolumeCapabilitiesBadArguments(t *testing.T) {
@@ -789,15 +1175,19 @@ func TestControllerListVolumesInvalidArguments(t *testing.T) {
 	assert.Equal(t, serverError.Code(), codes.InvalidArgument)
 	assert.Contains(t, serverError.Message(), "Version")
 
-	// Expect error with maxentries set
-	// To be removed once CSI Spec issue #138 is resolved
+	// Expect error with an invalid starting token
 	req.Version = &csi.Version{}
-	req.MaxEntries = 1
+	req.StartingToken = "bad"
+	s.MockDriver().
+		EXPECT().
+		EnumeratePage(nil, 0, "bad").
+		Return(nil, volume.ErrInvalidPageToken).
+		Times(1)
 	_, err = c.ListVolumes(context.Background(), req)
 	assert.NotNil(t, err)
 	serverError, ok = status.FromError(err)
 	assert.True(t, ok)
-	assert.Equal(t, serverError.Code(), codes.Unimplemented)
+	assert.Equal(t, serverError.Code(), codes.Aborted)
 	assert.Contains(t, serverError.Message(), "token")
 }
 
@@ -810,7 +1200,7 @@ func TestControllerListVolumesEnumerateError(t *testing.T) {
 	// Setup mock
 	s.MockDriver().
 		EXPECT().
-		Enumerate(gomock.Any(), gomock.Any()).
+		EnumeratePage(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(nil, fmt.Errorf("TEST")).
 		Times(1)
 
@@ -874,8 +1264,8 @@ func TestControllerListVolumes(t *testing.T) {
 	}
 	s.MockDriver().
 		EXPECT().
-		Enumerate(gomock.Any(), gomock.Any()).
-		Return(mockVolumeList, nil).
+		EnumeratePage(nil, 0, "").
+		Return(&api.VolumeEnumerateResponse{Volumes: mockVolumeList}, nil).
 		Times(1)
 
 	// Setup request
@@ -917,6 +1307,51 @@ func TestControllerListVolumes(t *testing.T) {
 	assert.Equal(t, found, len(mockVolumeList))
 }
 
+func TestControllerListVolumesPaged(t *testing.T) {
+	// Create server and client connection
+	s := newTestServer(t)
+	defer s.Stop()
+	c := csi.NewControllerClient(s.Conn())
+
+	// Setup mock
+	gomock.InOrder(
+		s.MockDriver().
+			EXPECT().
+			EnumeratePage(nil, 1, "").
+			Return(&api.VolumeEnumerateResponse{
+				Volumes: []*api.Volume{
+					&api.Volume{Id: "one", Spec: &api.VolumeSpec{}},
+				},
+				NextToken: "next",
+			}, nil),
+		s.MockDriver().
+			EXPECT().
+			EnumeratePage(nil, 1, "next").
+			Return(&api.VolumeEnumerateResponse{
+				Volumes: []*api.Volume{
+					&api.Volume{Id: "two", Spec: &api.VolumeSpec{}},
+				},
+			}, nil),
+	)
+
+	req := &csi.ListVolumesRequest{
+		Version:    &csi.Version{},
+		MaxEntries: 1,
+	}
+	r, err := c.ListVolumes(context.Background(), req)
+	assert.Nil(t, err)
+	assert.Len(t, r.GetEntries(), 1)
+	assert.Equal(t, "one", r.GetEntries()[0].GetVolumeInfo().GetId())
+	assert.Equal(t, "next", r.GetNextToken())
+
+	req.StartingToken = r.GetNextToken()
+	r, err = c.ListVolumes(context.Background(), req)
+	assert.Nil(t, err)
+	assert.Len(t, r.GetEntries(), 1)
+	assert.Equal(t, "two", r.GetEntries()[0].GetVolumeInfo().GetId())
+	assert.Empty(t, r.GetNextToken())
+}
+
 func TestControllerCreateVolumeInvalidArguments(t *testing.T) {
 	// Create server and client connection
 	s := newTestServer(t)
@@ -1775,6 +2210,11 @@ func TestControllerDeleteVolumeError(t *testing.T) {
 					Id: myid,
 				},
 			}, nil).
+			Times(2),
+		s.MockDriver().
+			EXPECT().
+			Enumerate(&api.VolumeLocator{}, nil).
+			Return(nil, nil).
 			Times(1),
 		s.MockDriver().
 			EXPECT().
@@ -1837,6 +2277,11 @@ func TestControllerDeleteVolume(t *testing.T) {
 					Id: myid,
 				},
 			}, nil).
+			Times(2),
+		s.MockDriver().
+			EXPECT().
+			Enumerate(&api.VolumeLocator{}, nil).
+			Return(nil, nil).
 			Times(1),
 		s.MockDriver().
 			EXPECT().
@@ -17,6 +17,7 @@ limitations under the License.
 package csi
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"sync"
@@ -26,6 +27,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
+	"github.com/libopenstorage/openstorage/api"
 	"github.com/libopenstorage/openstorage/api/spec"
 	"github.com/libopenstorage/openstorage/cluster"
 	"github.com/libopenstorage/openstorage/volume"
@@ -39,6 +41,15 @@ type OsdCsiServerConfig struct {
 	Address    string
 	DriverName string
 	Cluster    cluster.Cluster
+	// MgmtPort is the port of the volume management API of the driver on
+	// the cluster nodes. It is used to attach volumes on remote nodes.
+	MgmtPort uint16
+	// AuthToken is the bearer token sent to the volume management API of
+	// remote nodes.
+	AuthToken string
+	// TLS is the client configuration used to reach the volume management
+	// API of remote nodes over HTTPS. Plain HTTP is used when it is nil.
+	TLS *tls.Config
 }
 
 // OsdCsiServer is a OSD CSI compliant server which
@@ -53,6 +64,12 @@ type OsdCsiServer struct {
 	running     bool
 	lock        sync.Mutex
 	specHandler spec.SpecHandler
+	driverName  string
+	mgmtPort    uint16
+	authToken   string
+	tlsConfig   *tls.Config
+	// remoteDriver returns the driver used for volumes on a remote node
+	remoteDriver func(node api.Node) (volume.VolumeDriver, error)
 }
 
 // NewOsdCsiServer creates a gRPC CSI complient server on the
@@ -82,12 +99,18 @@ func NewOsdCsiServer(config *OsdCsiServerConfig) (Server, error) {
 		return nil, fmt.Errorf("Unable to setup server: %s", err.Error())
 	}
 
-	return &OsdCsiServer{
+	s := &OsdCsiServer{
 		listener:    l,
 		driver:      d,
 		cluster:     config.Cluster,
 		specHandler: spec.NewSpecHandler(),
-	}, nil
+		driverName:  config.DriverName,
+		mgmtPort:    config.MgmtPort,
+		authToken:   config.AuthToken,
+		tlsConfig:   config.TLS,
+	}
+	s.remoteDriver = s.remoteVolumeDriver
+	return s, nil
 }
 
 // Start is used to start the server.
@@ -20,8 +20,6 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/libopenstorage/openstorage/api"
-	"github.com/libopenstorage/openstorage/pkg/options"
 	"github.com/libopenstorage/openstorage/pkg/util"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
@@ -81,7 +79,7 @@ func (s *OsdCsiServer) NodePublishVolume(
 	}
 
 	// Get volume information
-	v, err := util.VolumeFromName(s.driver, req.GetVolumeId())
+	_, err := util.VolumeFromName(s.driver, req.GetVolumeId())
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "Volume id %s not found: %s",
 			req.GetVolumeId(),
@@ -89,20 +87,13 @@ func (s *OsdCsiServer) NodePublishVolume(
 	}
 
 	// Gather volume attributes
-	spec, _, _, err := s.specHandler.SpecFromOpts(req.GetVolumeAttributes())
-	if err != nil {
+	if _, _, _, err := s.specHandler.SpecFromOpts(req.GetVolumeAttributes()); err != nil {
 		return nil, status.Errorf(
 			codes.InvalidArgument,
 			"Invalid volume attributes: %#v",
 			req.GetVolumeAttributes())
 	}
 
-	// This seems weird as a way to change opts to map[string]string
-	opts := make(map[string]string)
-	if len(spec.GetPassphrase()) != 0 {
-		opts[options.OptionsSecret] = spec.GetPassphrase()
-	}
-
 	// Verify target location is an existing directory
 	// See: https://github.com/container-storage-interface/spec/issues/60
 	if err := verifyTargetLocation(req.GetTargetPath()); err != nil {
@@ -113,25 +104,9 @@ func (s *OsdCsiServer) NodePublishVolume(
 			err.Error())
 	}
 
-	// If this is for a block driver, first attach the volume
-	if s.driver.Type() == api.DriverType_DRIVER_TYPE_BLOCK {
-		if _, err := s.driver.Attach(req.GetVolumeId(), opts); err != nil {
-			return nil, status.Errorf(
-				codes.Internal,
-				"Unable to attach volume: %s",
-				err.Error())
-		}
-	}
-
+	// Block volumes have been attached by ControllerPublishVolume.
 	// Mount volume onto the path
 	if err := s.driver.Mount(req.GetVolumeId(), req.GetTargetPath(), nil); err != nil {
-		// Detach on error
-		detachErr := s.driver.Detach(v.GetId(), opts)
-		if detachErr != nil {
-			dlog.Errorf("Unable to detach volume %s: %s",
-				v.GetId(),
-				detachErr.Error())
-		}
 		return nil, status.Errorf(
 			codes.Internal,
 			"Unable to mount volume %s onto %s: %s",
@@ -190,15 +165,7 @@ func (s *OsdCsiServer) NodeUnpublishVolume(
 			err.Error())
 	}
 
-	if s.driver.Type() == api.DriverType_DRIVER_TYPE_BLOCK {
-		if err = s.driver.Detach(req.GetVolumeId(), nil); err != nil {
-			return nil, status.Errorf(
-				codes.Internal,
-				"Unable to detach volume: %s",
-				err.Error())
-		}
-	}
-
+	// Block volumes are detached by ControllerUnpublishVolume.
 	dlog.Infof("Volume %s unmounted", req.GetVolumeId())
 
 	return &csi.NodeUnpublishVolumeResponse{}, nil
@@ -311,62 +311,6 @@ func TestNodePublishVolumeInvalidTargetLocation(t *testing.T) {
 	}
 }
 
-func TestNodePublishVolumeFailedToAttach(t *testing.T) {
-	// Create server and client connection
-	s := newTestServer(t)
-	defer s.Stop()
-
-	// Make a call
-	c := csi.NewNodeClient(s.Conn())
-
-	name := "myvol"
-	size := uint64(10)
-	gomock.InOrder(
-		s.MockDriver().
-			EXPECT().
-			Inspect([]string{name}).
-			Return([]*api.Volume{
-				&api.Volume{
-					Id: name,
-					Locator: &api.VolumeLocator{
-						Name: name,
-					},
-					Spec: &api.VolumeSpec{
-						Size: size,
-					},
-				},
-			}, nil).
-			Times(1),
-		s.MockDriver().
-			EXPECT().
-			Type().
-			Return(api.DriverType_DRIVER_TYPE_BLOCK).
-			Times(1),
-		s.MockDriver().
-			EXPECT().
-			Attach(name, gomock.Any()).
-			Return("", fmt.Errorf("TEST")).
-			Times(1),
-	)
-
-	req := &csi.NodePublishVolumeRequest{
-		Version:    &csi.Version{},
-		VolumeId:   name,
-		TargetPath: "/mnt",
-		VolumeCapability: &csi.VolumeCapability{
-			AccessMode: &csi.VolumeCapability_AccessMode{},
-		},
-	}
-
-	_, err := c.NodePublishVolume(context.Background(), req)
-	assert.NotNil(t, err)
-	serverError, ok := status.FromError(err)
-	assert.True(t, ok)
-	assert.Equal(t, serverError.Code(), codes.Internal)
-	assert.Contains(t, serverError.Message(), "Unable to attach volume")
-	assert.Contains(t, serverError.Message(), "TEST")
-}
-
 func TestNodePublishVolumeFailedMount(t *testing.T) {
 	// Create server and client connection
 	s := newTestServer(t)
@@ -394,26 +338,11 @@ func TestNodePublishVolumeFailedMount(t *testing.T) {
 				},
 			}, nil).
 			Times(1),
-		s.MockDriver().
-			EXPECT().
-			Type().
-			Return(api.DriverType_DRIVER_TYPE_BLOCK).
-			Times(1),
-		s.MockDriver().
-			EXPECT().
-			Attach(name, gomock.Any()).
-			Return("", nil).
-			Times(1),
 		s.MockDriver().
 			EXPECT().
 			Mount(name, targetPath, nil).
 			Return(fmt.Errorf("MOUNT ERROR")).
 			Times(1),
-		s.MockDriver().
-			EXPECT().
-			Detach(name, gomock.Any()).
-			Return(nil).
-			Times(1),
 	)
 
 	req := &csi.NodePublishVolumeRequest{
@@ -461,16 +390,6 @@ func TestNodePublishVolumeMount(t *testing.T) {
 				},
 			}, nil).
 			Times(1),
-		s.MockDriver().
-			EXPECT().
-			Type().
-			Return(api.DriverType_DRIVER_TYPE_BLOCK).
-			Times(1),
-		s.MockDriver().
-			EXPECT().
-			Attach(name, gomock.Any()).
-			Return("", nil).
-			Times(1),
 		s.MockDriver().
 			EXPECT().
 			Mount(name, targetPath, nil).
@@ -626,65 +545,6 @@ func TestNodeUnpublishVolumeFailedToUnmount(t *testing.T) {
 	assert.Contains(t, serverError.Message(), "TEST")
 }
 
-func TestNodeUnpublishVolumeFailedDetach(t *testing.T) {
-	// Create server and client connection
-	s := newTestServer(t)
-	defer s.Stop()
-
-	// Make a call
-	c := csi.NewNodeClient(s.Conn())
-
-	name := "myvol"
-	size := uint64(10)
-	targetPath := "/mnt"
-	gomock.InOrder(
-		s.MockDriver().
-			EXPECT().
-			Inspect([]string{name}).
-			Return([]*api.Volume{
-				&api.Volume{
-					Id: name,
-					Locator: &api.VolumeLocator{
-						Name: name,
-					},
-					Spec: &api.VolumeSpec{
-						Size: size,
-					},
-				},
-			}, nil).
-			Times(1),
-		s.MockDriver().
-			EXPECT().
-			Unmount(name, targetPath, nil).
-			Return(nil).
-			Times(1),
-		s.MockDriver().
-			EXPECT().
-			Type().
-			Return(api.DriverType_DRIVER_TYPE_BLOCK).
-			Times(1),
-		s.MockDriver().
-			EXPECT().
-			Detach(name, gomock.Any()).
-			Return(fmt.Errorf("DETACH ERROR")).
-			Times(1),
-	)
-
-	req := &csi.NodeUnpublishVolumeRequest{
-		Version:    &csi.Version{},
-		VolumeId:   name,
-		TargetPath: targetPath,
-	}
-
-	_, err := c.NodeUnpublishVolume(context.Background(), req)
-	assert.NotNil(t, err)
-	serverError, ok := status.FromError(err)
-	assert.True(t, ok)
-	assert.Equal(t, serverError.Code(), codes.Internal)
-	assert.Contains(t, serverError.Message(), "Unable to detach volume")
-	assert.Contains(t, serverError.Message(), "DETACH ERROR")
-}
-
 func TestNodeUnpublishVolumeUnmount(t *testing.T) {
 	// Create server and client connection
 	s := newTestServer(t)
@@ -717,16 +577,6 @@ func TestNodeUnpublishVolumeUnmount(t *testing.T) {
 			Unmount(name, targetPath, nil).
 			Return(nil).
 			Times(1),
-		s.MockDriver().
-			EXPECT().
-			Type().
-			Return(api.DriverType_DRIVER_TYPE_BLOCK).
-			Times(1),
-		s.MockDriver().
-			EXPECT().
-			Detach(name, gomock.Any()).
-			Return(nil).
-			Times(1),
 	)
 
 	req := &csi.NodeUnpublishVolumeRequest{
@@ -27,26 +27,57 @@ type KvdbConfig struct {
 
 // ClusterConfig is a cluster level config parameter struct
 type ClusterConfig struct {
-	Description    string         `json:"description,omitempty"`
-	Mode           string         `json:"mode,omitempty"`
-	Version        string         `json:"version,omitempty"`
-	Created        string         `json:"created,omitempty"`
-	ClusterId      string         `json:"cluster_id,omitempty"`
-	LoggingUrl     string         `json:"logging_url,omitempty"`
-	AlertingUrl    string         `json:"alerting_url,omitempty"`
-	Scheduler      string         `json:"scheduler,omitempty"`
-	Multicontainer bool           `json:"multicontainer,omitempty"`
-	Nolh           bool           `json:"nolh,omitempty"`
-	Callhome       bool           `json:"callhome,omitempty"`
-	Bootstrap      bool           `json:"bootstrap,omitempty"`
-	TunnelEndPoint string         `json:"tunnel_end_point,omitempty"`
-	TunnelCerts    []string       `json:"tunnel_certs,omitempty"`
-	Driver         string         `json:"driver,omitempty"`
-	DebugLevel     string         `json:"debug_level,omitempty"`
-	Domain         string         `json:"domain,omitempty"`
-	Secrets        *SecretsConfig `json:"secrets,omitempty"`
-	Kvdb           *KvdbConfig    `json:"kvdb,omitempty"`
-	Private        interface{}    `json:"generic,omitempty"`
+	Description    string          `json:"description,omitempty"`
+	Mode           string          `json:"mode,omitempty"`
+	Version        string          `json:"version,omitempty"`
+	Created        string          `json:"created,omitempty"`
+	ClusterId      string          `json:"cluster_id,omitempty"`
+	LoggingUrl     string          `json:"logging_url,omitempty"`
+	AlertingUrl    string          `json:"alerting_url,omitempty"`
+	Alerting       *AlertingConfig `json:"alerting,omitempty"`
+	Scheduler      string          `json:"scheduler,omitempty"`
+	Multicontainer bool            `json:"multicontainer,omitempty"`
+	Nolh           bool            `json:"nolh,omitempty"`
+	Callhome       bool            `json:"callhome,omitempty"`
+	Bootstrap      bool            `json:"bootstrap,omitempty"`
+	TunnelEndPoint string          `json:"tunnel_end_point,omitempty"`
+	TunnelCerts    []string        `json:"tunnel_certs,omitempty"`
+	Driver         string          `json:"driver,omitempty"`
+	DebugLevel     string          `json:"debug_level,omitempty"`
+	Domain         string          `json:"domain,omitempty"`
+	Secrets        *SecretsConfig  `json:"secrets,omitempty"`
+	Kvdb           *KvdbConfig     `json:"kvdb,omitempty"`
+	Private        interface{}     `json:"generic,omitempty"`
+}
+
+// AlertingConfig lists the sinks alerts are delivered to
+type AlertingConfig struct {
+	Webhooks []*WebhookConfig `json:"webhooks,omitempty"`
+	Syslog   []*SyslogConfig  `json:"syslog,omitempty"`
+}
+
+// AlertFilterConfig selects the alerts delivered to a sink.
+// Severity is the least severe level delivered: alarm, warning or notify.
+// Resources lists the resource types delivered: volume, node, cluster or drive.
+type AlertFilterConfig struct {
+	Severity  string   `json:"severity,omitempty"`
+	Resources []string `json:"resources,omitempty"`
+}
+
+// WebhookConfig is an HTTP alert sink configuration parameters struct
+type WebhookConfig struct {
+	Url        string             `json:"url,omitempty"`
+	Secret     string             `json:"secret,omitempty"`
+	MaxRetries int32              `json:"max_retries,omitempty"`
+	Filter     *AlertFilterConfig `json:"filter,omitempty"`
+}
+
+// SyslogConfig is a syslog alert sink configuration parameters struct
+type SyslogConfig struct {
+	Network string             `json:"network,omitempty"`
+	Address string             `json:"address,omitempty"`
+	AppName string             `json:"app_name,omitempty"`
+	Filter  *AlertFilterConfig `json:"filter,omitempty"`
 }
 
 // NetworkConfig is a network configuration parameters struct
@@ -0,0 +1,151 @@
+// Package auth issues and validates the bearer tokens used to authenticate
+// callers of the openstorage REST API. Tokens are JSON Web Tokens signed with
+// HMAC-SHA256 using a key shared by the servers of a cluster.
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Role grants access to a class of API calls.
+type Role string
+
+const (
+	// RoleReadOnly allows inspecting volumes and the cluster.
+	RoleReadOnly Role = "read-only"
+	// RoleVolumeAdmin allows creating, modifying and deleting volumes and
+	// snapshots in addition to RoleReadOnly.
+	RoleVolumeAdmin Role = "volume-admin"
+	// RoleClusterAdmin allows every call, including node management and
+	// access to cloud credentials.
+	RoleClusterAdmin Role = "cluster-admin"
+)
+
+const (
+	algorithm = "HS256"
+	tokenType = "JWT"
+)
+
+var (
+	// ErrInvalidToken is returned when a token is malformed or its
+	// signature does not match.
+	ErrInvalidToken = errors.New("Invalid token")
+	// ErrTokenExpired is returned when a token is past its expiry time.
+	ErrTokenExpired = errors.New("Token has expired")
+)
+
+var roleLevel = map[Role]int{
+	RoleReadOnly:     1,
+	RoleVolumeAdmin:  2,
+	RoleClusterAdmin: 3,
+}
+
+// Allows returns true if r grants at least the access of required.
+func (r Role) Allows(required Role) bool {
+	level, ok := roleLevel[r]
+	return ok && level >= roleLevel[required]
+}
+
+// Claims identify the caller presenting a token.
+type Claims struct {
+	// Subject is the identity of the caller.
+	Subject string `json:"sub"`
+	// Issuer is the service that issued the token.
+	Issuer string `json:"iss,omitempty"`
+	// Roles held by the caller.
+	Roles []Role `json:"roles"`
+	// IssuedAt is the issue time in seconds since the epoch.
+	IssuedAt int64 `json:"iat,omitempty"`
+	// ExpiresAt is the expiry time in seconds since the epoch. Zero means
+	// the token does not expire.
+	ExpiresAt int64 `json:"exp,omitempty"`
+}
+
+// HasRole returns true if one of the roles of the caller allows required.
+func (c *Claims) HasRole(required Role) bool {
+	for _, r := range c.Roles {
+		if r.Allows(required) {
+			return true
+		}
+	}
+	return false
+}
+
+type header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+}
+
+// NewToken returns a token for claims signed with key.
+func NewToken(claims *Claims, key []byte) (string, error) {
+	if len(key) == 0 {
+		return "", fmt.Errorf("Signing key cannot be empty")
+	}
+	h, err := json.Marshal(&header{Algorithm: algorithm, Type: tokenType})
+	if err != nil {
+		return "", err
+	}
+	c, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	payload := encode(h) + "." + encode(c)
+	return payload + "." + encode(sign(payload, key)), nil
+}
+
+// Verify checks the signature and expiry of token and returns its claims.
+func Verify(token string, key []byte) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+	sig, err := decode(parts[2])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	if !hmac.Equal(sig, sign(parts[0]+"."+parts[1], key)) {
+		return nil, ErrInvalidToken
+	}
+
+	var h header
+	if err := unmarshal(parts[0], &h); err != nil || h.Algorithm != algorithm {
+		return nil, ErrInvalidToken
+	}
+	claims := &Claims{}
+	if err := unmarshal(parts[1], claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if claims.ExpiresAt != 0 && time.Now().Unix() >= claims.ExpiresAt {
+		return nil, ErrTokenExpired
+	}
+	return claims, nil
+}
+
+func sign(payload string, key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decode(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+func unmarshal(s string, v interface{}) error {
+	b, err := decode(s)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testKey = []byte("secret")
+
+func TestTokenVerify(t *testing.T) {
+	token, err := NewToken(&Claims{
+		Subject:   "alice",
+		Roles:     []Role{RoleVolumeAdmin},
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}, testKey)
+	require.NoError(t, err)
+
+	claims, err := Verify(token, testKey)
+	require.NoError(t, err)
+	require.Equal(t, "alice", claims.Subject)
+	require.True(t, claims.HasRole(RoleReadOnly))
+	require.True(t, claims.HasRole(RoleVolumeAdmin))
+	require.False(t, claims.HasRole(RoleClusterAdmin))
+
+	_, err = Verify(token, []byte("other"))
+	require.Equal(t, ErrInvalidToken, err)
+
+	// Changing the claims invalidates the signature.
+	parts := strings.Split(token, ".")
+	forged, err := NewToken(&Claims{
+		Subject: "alice",
+		Roles:   []Role{RoleClusterAdmin},
+	}, []byte("other"))
+	require.NoError(t, err)
+	parts[1] = strings.Split(forged, ".")[1]
+	_, err = Verify(strings.Join(parts, "."), testKey)
+	require.Equal(t, ErrInvalidToken, err)
+
+	_, err = Verify("not.a-token", testKey)
+	require.Equal(t, ErrInvalidToken, err)
+}
+
+func TestTokenExpired(t *testing.T) {
+	token, err := NewToken(&Claims{
+		Subject:   "alice",
+		Roles:     []Role{RoleReadOnly},
+		ExpiresAt: time.Now().Add(-time.Minute).Unix(),
+	}, testKey)
+	require.NoError(t, err)
+	_, err = Verify(token, testKey)
+	require.Equal(t, ErrTokenExpired, err)
+
+	_, err = NewToken(&Claims{Subject: "alice"}, nil)
+	require.Error(t, err)
+}
+
+func TestRoleAllows(t *testing.T) {
+	require.True(t, RoleClusterAdmin.Allows(RoleVolumeAdmin))
+	require.True(t, RoleReadOnly.Allows(RoleReadOnly))
+	require.False(t, RoleReadOnly.Allows(RoleVolumeAdmin))
+	require.False(t, Role("unknown").Allows(RoleReadOnly))
+}
@@ -0,0 +1,228 @@
+// Package clone copies directory trees for file based snapshots. Files are
+// cloned with reflinks on filesystems that share extents between files, such
+// as btrfs and xfs, so a snapshot takes no time and no space until either
+// copy is modified. Hard links and full copies are available as fallbacks.
+package clone
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// Method is how the files of a tree are cloned.
+type Method string
+
+const (
+	// Auto uses reflinks if the filesystem supports them and copies the
+	// files otherwise.
+	Auto Method = "auto"
+	// Reflink shares the extents of the files. It fails on filesystems
+	// without reflink support.
+	Reflink Method = "reflink"
+	// Hardlink links the files into a new directory tree. The clone shares
+	// the files with the source, so a file modified in place changes in
+	// both; only files that are replaced are copy-on-write.
+	Hardlink Method = "hardlink"
+	// Copy copies the data of the files.
+	Copy Method = "copy"
+)
+
+const (
+	// Defined in <linux/fs.h>. Other systems reject the ioctl and fall
+	// back to copies.
+	ficlone = 0x40049409
+)
+
+var (
+	// ErrNotSupported is returned when the filesystem does not support
+	// reflinks between the source and the destination.
+	ErrNotSupported = errors.New("Reflinks are not supported on this filesystem")
+)
+
+// Progress is called with the number of bytes cloned so far and the total
+// size of the files to clone.
+type Progress func(done, total uint64)
+
+// ParseMethod returns the method called name. An empty name is Auto.
+func ParseMethod(name string) (Method, error) {
+	switch m := Method(name); m {
+	case "":
+		return Auto, nil
+	case Auto, Reflink, Hardlink, Copy:
+		return m, nil
+	}
+	return "", fmt.Errorf("Unknown clone method %q", name)
+}
+
+// Tree clones the directory tree at source into dest with method and returns
+// the method that was used, which for Auto is Reflink or Copy. File modes,
+// ownership and symbolic links are kept; devices, sockets and pipes are
+// skipped. progress, if not nil, is called after every file.
+func Tree(source, dest string, method Method, progress Progress) (Method, error) {
+	source = filepath.Clean(source)
+	dest = filepath.Clean(dest)
+	if dest == source || strings.HasPrefix(dest, source+string(os.PathSeparator)) {
+		return "", fmt.Errorf("Cannot clone %s into itself", source)
+	}
+	if progress == nil {
+		progress = func(done, total uint64) {}
+	}
+	total, err := size(source)
+	if err != nil {
+		return "", err
+	}
+
+	c := &cloner{method: method, total: total, progress: progress}
+	progress(0, total)
+	if err := filepath.Walk(source, c.walk(source, dest)); err != nil {
+		return "", err
+	}
+	// Directories are made writable while their contents are cloned.
+	for i := len(c.dirs) - 1; i >= 0; i-- {
+		if err := os.Chmod(c.dirs[i].path, c.dirs[i].mode); err != nil {
+			return "", err
+		}
+	}
+	if c.method == Auto {
+		// No file was cloned, so no data is shared.
+		return Copy, nil
+	}
+	return c.method, nil
+}
+
+// size returns the total size of the regular files in the tree at dir.
+func size(dir string) (uint64, error) {
+	var total uint64
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			total += uint64(info.Size())
+		}
+		return nil
+	})
+	return total, err
+}
+
+type dirMode struct {
+	path string
+	mode os.FileMode
+}
+
+type cloner struct {
+	method   Method
+	done     uint64
+	total    uint64
+	progress Progress
+	dirs     []dirMode
+}
+
+func (c *cloner) walk(source, dest string) filepath.WalkFunc {
+	return func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(source, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, rel)
+		switch {
+		case info.IsDir():
+			if err := os.MkdirAll(target, 0700); err != nil {
+				return err
+			}
+			c.dirs = append(c.dirs, dirMode{target, info.Mode() & os.ModePerm})
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			if err := os.Symlink(link, target); err != nil {
+				return err
+			}
+		case info.Mode().IsRegular():
+			if err := c.file(p, target, info); err != nil {
+				return fmt.Errorf("Failed to clone %s: %v", p, err)
+			}
+			c.done += uint64(info.Size())
+			c.progress(c.done, c.total)
+			if c.method == Hardlink {
+				// The link shares the mode and owner of the source.
+				return nil
+			}
+		default:
+			return nil
+		}
+		return chown(target, info)
+	}
+}
+
+// file clones the regular file source into dest.
+func (c *cloner) file(source, dest string, info os.FileInfo) error {
+	if c.method == Hardlink {
+		return os.Link(source, dest)
+	}
+	src, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_EXCL,
+		info.Mode()&os.ModePerm)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	if c.method != Copy {
+		err := reflink(src, dst)
+		switch {
+		case err == nil:
+			if c.method == Auto {
+				c.method = Reflink
+			}
+			return dst.Chmod(info.Mode() & os.ModePerm)
+		case err != ErrNotSupported || c.method != Auto:
+			return err
+		}
+		c.method = Copy
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		return err
+	}
+	// The mode passed to open is masked by the umask.
+	return dst.Chmod(info.Mode() & os.ModePerm)
+}
+
+// reflink makes dst share the extents of src.
+func reflink(src, dst *os.File) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, dst.Fd(), ficlone, src.Fd())
+	switch errno {
+	case 0:
+		return nil
+	case syscall.EOPNOTSUPP, syscall.ENOTTY, syscall.EINVAL, syscall.EXDEV:
+		return ErrNotSupported
+	}
+	return errno
+}
+
+// chown gives p the owner of info. Without the privilege to change owners
+// the clone belongs to the caller.
+func chown(p string, info os.FileInfo) error {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+	if err := os.Lchown(p, int(st.Uid), int(st.Gid)); err != nil &&
+		!os.IsPermission(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package clone
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var treeFiles = map[string]string{
+	"top":        "top level file",
+	"dir/nested": "nested file",
+}
+
+func makeTree(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "clone-src")
+	require.NoError(t, err)
+	for name, data := range treeFiles {
+		p := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, ioutil.WriteFile(p, []byte(data), 0600))
+	}
+	require.NoError(t, os.Symlink("top", filepath.Join(dir, "link")))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "ro"), 0500))
+	return dir
+}
+
+func TestParseMethod(t *testing.T) {
+	for name, method := range map[string]Method{
+		"":         Auto,
+		"auto":     Auto,
+		"reflink":  Reflink,
+		"hardlink": Hardlink,
+		"copy":     Copy,
+	} {
+		m, err := ParseMethod(name)
+		require.NoError(t, err)
+		assert.Equal(t, method, m)
+	}
+	_, err := ParseMethod("rsync")
+	assert.Error(t, err)
+}
+
+func TestTree(t *testing.T) {
+	src := makeTree(t)
+	defer os.RemoveAll(src)
+
+	_, err := Tree(src, filepath.Join(src, "dir", "clone"), Copy, nil)
+	assert.Error(t, err)
+
+	var total uint64
+	for _, data := range treeFiles {
+		total += uint64(len(data))
+	}
+	for _, method := range []Method{Auto, Hardlink, Copy} {
+		dir, err := ioutil.TempDir("", "clone-dest")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+		dest := filepath.Join(dir, "clone")
+
+		var done []uint64
+		used, err := Tree(src, dest, method, func(d, tot uint64) {
+			assert.Equal(t, total, tot)
+			done = append(done, d)
+		})
+		require.NoError(t, err, string(method))
+		require.Len(t, done, len(treeFiles)+1)
+		assert.Equal(t, total, done[len(done)-1])
+		if method == Auto {
+			assert.Contains(t, []Method{Reflink, Copy}, used)
+		} else {
+			assert.Equal(t, method, used)
+		}
+
+		for name, data := range treeFiles {
+			b, err := ioutil.ReadFile(filepath.Join(dest, name))
+			require.NoError(t, err)
+			assert.Equal(t, data, string(b))
+			info, err := os.Stat(filepath.Join(dest, name))
+			require.NoError(t, err)
+			assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		}
+		link, err := os.Readlink(filepath.Join(dest, "link"))
+		require.NoError(t, err)
+		assert.Equal(t, "top", link)
+		info, err := os.Stat(filepath.Join(dest, "ro"))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0500), info.Mode().Perm())
+
+		// Only hard links share the data with the source.
+		srcInfo, err := os.Stat(filepath.Join(src, "top"))
+		require.NoError(t, err)
+		info, err = os.Stat(filepath.Join(dest, "top"))
+		require.NoError(t, err)
+		assert.Equal(t, method == Hardlink, os.SameFile(srcInfo, info))
+	}
+}
@@ -0,0 +1,238 @@
+// +build linux
+
+// Package quota manages filesystem project quotas. A project quota limits
+// the space used by a directory tree regardless of file ownership, which
+// lets file based volume drivers enforce a volume size.
+package quota
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	// Defined in <linux/fs.h>:
+	fsIocFsGetXattr    = 0x801c581f
+	fsIocFsSetXattr    = 0x401c5820
+	fsXflagProjInherit = 0x00000200
+	// Defined in <linux/quota.h>:
+	qGetQuota    = 0x800007
+	qSetQuota    = 0x800008
+	prjQuota     = 2
+	qifBlimits   = 1
+	qifDqblkSize = 1024
+	subCmdShift  = 8
+	subCmdMask   = 0xff
+)
+
+const (
+	procMounts = "/proc/mounts"
+	// minProjectID is the lowest project ID handed out for volumes.
+	minProjectID = 1 << 16
+)
+
+var (
+	// ErrNotSupported is returned when the filesystem does not support
+	// project quotas or they are not enabled.
+	ErrNotSupported = errors.New("Project quotas are not supported or not " +
+		"enabled on this filesystem")
+)
+
+// fsxattr mirrors struct fsxattr in <linux/fs.h>.
+type fsxattr struct {
+	xflags     uint32
+	extsize    uint32
+	nextents   uint32
+	projid     uint32
+	cowextsize uint32
+	pad        [8]byte
+}
+
+// dqblk mirrors struct if_dqblk in <linux/quota.h>.
+type dqblk struct {
+	bhardlimit uint64
+	bsoftlimit uint64
+	curspace   uint64
+	ihardlimit uint64
+	isoftlimit uint64
+	curinodes  uint64
+	btime      uint64
+	itime      uint64
+	valid      uint32
+}
+
+// ProjectID returns the preferred project ID for the volume. IDs below
+// 65536 are left for the administrator. Two volumes can hash to the same
+// ID, so callers must reserve the ID and move on with NextProjectID if it
+// is taken.
+func ProjectID(volumeID string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(volumeID))
+	id := h.Sum32()
+	if id < minProjectID {
+		id += minProjectID
+	}
+	return id
+}
+
+// NextProjectID returns the project ID to try after id.
+func NextProjectID(id uint32) uint32 {
+	if id == math.MaxUint32 {
+		return minProjectID
+	}
+	return id + 1
+}
+
+// Supported returns true if the filesystem containing dir enforces project
+// quotas.
+func Supported(dir string) bool {
+	_, err := GetLimit(dir, 0)
+	return err == nil
+}
+
+// SetLimit assigns projectID to dir and limits the space used by the
+// project to size bytes. It returns the limit enforced by the filesystem,
+// which is size rounded up to the quota block size. ErrNotSupported is
+// returned, and dir left alone, if the filesystem has no project quotas.
+func SetLimit(dir string, projectID uint32, size uint64) (uint64, error) {
+	dev, err := deviceOf(dir)
+	if err != nil {
+		return 0, err
+	}
+	var q dqblk
+	if err := quotactl(qGetQuota, dev, projectID, &q); err != nil {
+		return 0, err
+	}
+	if err := setProject(dir, projectID); err != nil {
+		return 0, err
+	}
+
+	q = dqblk{
+		bhardlimit: (size + qifDqblkSize - 1) / qifDqblkSize,
+		bsoftlimit: (size + qifDqblkSize - 1) / qifDqblkSize,
+		valid:      qifBlimits,
+	}
+	if err := quotactl(qSetQuota, dev, projectID, &q); err != nil {
+		return 0, err
+	}
+	return GetLimit(dir, projectID)
+}
+
+// GetLimit returns the space limit in bytes for projectID on the
+// filesystem containing dir.
+func GetLimit(dir string, projectID uint32) (uint64, error) {
+	dev, err := deviceOf(dir)
+	if err != nil {
+		return 0, err
+	}
+	var q dqblk
+	if err := quotactl(qGetQuota, dev, projectID, &q); err != nil {
+		return 0, err
+	}
+	return q.bhardlimit * qifDqblkSize, nil
+}
+
+// setProject sets the project ID of dir and marks it so that new files
+// and directories inherit the ID.
+func setProject(dir string, projectID uint32) error {
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var attr fsxattr
+	if err := ioctl(f.Fd(), fsIocFsGetXattr, uintptr(unsafe.Pointer(&attr))); err == ErrNotSupported {
+		return err
+	} else if err != nil {
+		return fmt.Errorf("Failed to get project of %s: %v", dir, err)
+	}
+	attr.projid = projectID
+	attr.xflags |= fsXflagProjInherit
+	if err := ioctl(f.Fd(), fsIocFsSetXattr, uintptr(unsafe.Pointer(&attr))); err == ErrNotSupported {
+		return err
+	} else if err != nil {
+		return fmt.Errorf("Failed to set project of %s: %v", dir, err)
+	}
+	return nil
+}
+
+// deviceOf returns the block device of the filesystem containing dir.
+func deviceOf(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	if dir, err = filepath.EvalSymlinks(dir); err != nil {
+		return "", err
+	}
+	f, err := os.Open(procMounts)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	device, mountPoint := "", ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		mp := fields[1]
+		if (dir == mp || mp == "/" || strings.HasPrefix(dir, mp+"/")) &&
+			len(mp) >= len(mountPoint) {
+			device, mountPoint = fields[0], mp
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(device, "/dev/") {
+		return "", ErrNotSupported
+	}
+	return device, nil
+}
+
+func quotactl(cmd int, device string, id uint32, q *dqblk) error {
+	dev, err := syscall.BytePtrFromString(device)
+	if err != nil {
+		return err
+	}
+	_, _, errno := syscall.Syscall6(
+		syscall.SYS_QUOTACTL,
+		uintptr(cmd<<subCmdShift|prjQuota&subCmdMask),
+		uintptr(unsafe.Pointer(dev)),
+		uintptr(id),
+		uintptr(unsafe.Pointer(q)),
+		0,
+		0,
+	)
+	switch errno {
+	case 0:
+		return nil
+	case syscall.ENOSYS, syscall.ESRCH, syscall.ENOTSUP, syscall.EINVAL:
+		return ErrNotSupported
+	default:
+		return fmt.Errorf("quotactl on %s failed: %v", device, errno)
+	}
+}
+
+func ioctl(fd, req, arg uintptr) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, req, arg)
+	if errno == syscall.ENOTTY || errno == syscall.ENOTSUP {
+		return ErrNotSupported
+	}
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+// +build linux
+
+package quota
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectID(t *testing.T) {
+	id := ProjectID("vol1")
+	require.Equal(t, id, ProjectID("vol1"), "Project IDs should be stable")
+	require.NotEqual(t, id, ProjectID("vol2"), "Project IDs should differ")
+	require.True(t, id >= minProjectID, "Project ID in reserved range")
+}
+
+func TestNextProjectID(t *testing.T) {
+	require.Equal(t, uint32(minProjectID+1), NextProjectID(minProjectID))
+	require.Equal(t, uint32(minProjectID), NextProjectID(math.MaxUint32),
+		"Project IDs should wrap around to the first volume ID")
+}
+
+func TestSetLimitNotSupported(t *testing.T) {
+	// /proc is not backed by a block device.
+	_, err := SetLimit("/proc", ProjectID("vol1"), 1024)
+	require.Equal(t, ErrNotSupported, err)
+	require.False(t, Supported("/proc"))
+
+	dir, err := ioutil.TempDir("", "quota")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	if !Supported(dir) {
+		_, err = SetLimit(dir, ProjectID("vol1"), 1024)
+		require.Equal(t, ErrNotSupported, err)
+	}
+}
@@ -0,0 +1,281 @@
+package sched
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// cronZoneSeparator separates the expression from the time zone in
+	// the CLI form of a cron schedule: expr[@zone][,retain].
+	cronZoneSeparator = "@"
+	// cronMaxYears bounds the search for the next matching time.
+	cronMaxYears = 5
+)
+
+// cronField is the set of values allowed for a field of a cron expression.
+type cronField uint64
+
+func (f cronField) has(v int) bool {
+	return f&(1<<uint(v)) != 0
+}
+
+// cronRange describes the values of a cron expression field.
+type cronRange struct {
+	name  string
+	min   int
+	max   int
+	names []string
+}
+
+var (
+	cronMinutes = cronRange{name: "minute", min: 0, max: 59}
+	cronHours   = cronRange{name: "hour", min: 0, max: 23}
+	cronDays    = cronRange{name: "day of month", min: 1, max: 31}
+	cronMonths  = cronRange{name: "month", min: 1, max: 12,
+		names: []string{"", "jan", "feb", "mar", "apr", "may", "jun", "jul",
+			"aug", "sep", "oct", "nov", "dec"}}
+	// Sunday is both 0 and 7.
+	cronWeekdays = cronRange{name: "day of week", min: 0, max: 7,
+		names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}}
+)
+
+// cron is an interval described by a standard 5 field cron expression
+// evaluated in a time zone. Wall clock times that occur twice when clocks
+// are set back run on their first occurrence only. Times skipped when
+// clocks are set forward run at the first instant after the gap.
+type cron struct {
+	expr     string
+	zone     string
+	location *time.Location
+	minutes  cronField
+	hours    cronField
+	days     cronField
+	months   cronField
+	weekdays cronField
+	// anyDay and anyWeekday are set when the field is *. When both day
+	// fields are restricted a time matches if either of them matches.
+	anyDay     bool
+	anyWeekday bool
+}
+
+// Cron returns an Interval for the 5 field cron expression expr evaluated
+// in the IANA time zone zone. An empty zone is the local time zone.
+func Cron(expr string, zone string) (Interval, error) {
+	c := cron{expr: expr, zone: zone, location: time.Local}
+	if zone != "" {
+		loc, err := time.LoadLocation(zone)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid time zone %v: %v", zone, err)
+		}
+		c.location = loc
+	}
+
+	fields := strings.FieldsFunc(expr, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '_'
+	})
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("Invalid cron expression %q: expected 5 fields",
+			expr)
+	}
+	var err error
+	if c.minutes, err = parseCronField(fields[0], cronMinutes); err != nil {
+		return nil, err
+	}
+	if c.hours, err = parseCronField(fields[1], cronHours); err != nil {
+		return nil, err
+	}
+	if c.days, err = parseCronField(fields[2], cronDays); err != nil {
+		return nil, err
+	}
+	if c.months, err = parseCronField(fields[3], cronMonths); err != nil {
+		return nil, err
+	}
+	if c.weekdays, err = parseCronField(fields[4], cronWeekdays); err != nil {
+		return nil, err
+	}
+	if c.weekdays.has(7) {
+		c.weekdays |= 1
+	}
+	c.anyDay = strings.HasPrefix(fields[2], "*")
+	c.anyWeekday = strings.HasPrefix(fields[4], "*")
+	c.expr = strings.Join(fields, " ")
+
+	if c.nextAfter(time.Now()).IsZero() {
+		return nil, fmt.Errorf("Cron expression %q never matches", expr)
+	}
+	return c, nil
+}
+
+// parseCronField parses a comma separated list of *, values, ranges and
+// steps such as "*/15", "1-5" or "jan,jul".
+func parseCronField(field string, r cronRange) (cronField, error) {
+	var f cronField
+	for _, item := range strings.Split(field, ",") {
+		step := 1
+		if parts := strings.SplitN(item, "/", 2); len(parts) == 2 {
+			s, err := strconv.Atoi(parts[1])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("Invalid %s step %q", r.name, item)
+			}
+			item, step = parts[0], s
+		}
+
+		lo, hi := r.min, r.max
+		if item != "*" {
+			bounds := strings.SplitN(item, "-", 2)
+			var err error
+			if lo, err = r.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = r.value(bounds[1]); err != nil {
+					return 0, err
+				}
+			} else if step > 1 {
+				// a/n is a shorthand for a-max/n.
+				hi = r.max
+			}
+			if hi < lo {
+				return 0, fmt.Errorf("Invalid %s range %q", r.name, item)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			f |= 1 << uint(v)
+		}
+	}
+	return f, nil
+}
+
+// value parses a single value of the field, either a number or a name.
+func (r cronRange) value(s string) (int, error) {
+	for i, name := range r.names {
+		if name != "" && strings.EqualFold(s, name) {
+			return i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < r.min || v > r.max {
+		return 0, fmt.Errorf("Invalid %s %q", r.name, s)
+	}
+	return v, nil
+}
+
+// dayMatches returns true if the date matches the day fields.
+func (c cron) dayMatches(t time.Time) bool {
+	day := c.days.has(t.Day())
+	weekday := c.weekdays.has(int(t.Weekday()))
+	if c.anyDay || c.anyWeekday {
+		return day && weekday
+	}
+	return day || weekday
+}
+
+func (c cron) nextAfter(t time.Time) time.Time {
+	t = t.In(c.location)
+	// Search wall clock times. Calendar arithmetic is done in UTC, which
+	// has no DST, and the match is converted to the schedule's zone.
+	cur := wallClock(t.Truncate(time.Minute).Add(time.Minute))
+	end := cur.AddDate(cronMaxYears, 0, 0)
+	for cur.Before(end) {
+		y, m, d := cur.Date()
+		if !c.months.has(int(m)) {
+			cur = time.Date(y, m+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.dayMatches(cur) {
+			cur = time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.hours.has(cur.Hour()) {
+			cur = time.Date(y, m, d, cur.Hour()+1, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.minutes.has(cur.Minute()) {
+			cur = cur.Add(time.Minute)
+			continue
+		}
+		next := time.Date(y, m, d, cur.Hour(), cur.Minute(), 0, 0, c.location)
+		if !wallClock(next).Equal(cur) {
+			next = gapEnd(next, cur)
+		}
+		if next.After(t) {
+			return next
+		}
+		// The wall clock time already occurred before t.
+		cur = cur.Add(time.Minute)
+	}
+	return time.Time{}
+}
+
+// gapEnd returns the first instant after the gap that skipped the wall
+// clock time cur, the time when clocks were set forward. near is an instant
+// close to the gap: time.Date may normalize a skipped time to either side
+// of it.
+func gapEnd(near, cur time.Time) time.Time {
+	for !wallClock(near).After(cur) {
+		near = near.Add(time.Minute)
+	}
+	for wallClock(near.Add(-time.Minute)).After(cur) {
+		near = near.Add(-time.Minute)
+	}
+	return near
+}
+
+// wallClock returns the wall clock time of t as a UTC time.
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(),
+		t.Second(), t.Nanosecond(), time.UTC)
+}
+
+func (c cron) String() string {
+	if c.zone == "" {
+		return fmt.Sprintf("%s %s", CronType, c.expr)
+	}
+	return fmt.Sprintf("%s %s%s%s", CronType, c.expr, cronZoneSeparator, c.zone)
+}
+
+func (c cron) IntervalType() string {
+	return CronType
+}
+
+func (c cron) Spec() IntervalSpec {
+	return IntervalSpec{Freq: CronType, Cron: c.expr, TimeZone: c.zone}
+}
+
+// parseCron item expr[@zone][,r]. Without a time zone, a number after the
+// last comma is the retain count, so a numeric list in the day of week field
+// has to be followed by a retain count or a time zone.
+func parseCron(cronStr string) (RetainIntervalSpec, error) {
+	parts := strings.SplitN(cronStr, cronZoneSeparator, 2)
+	expr, zone := parts[0], ""
+	r := RetainIntervalSpec{}
+	var err error
+	if len(parts) == 2 {
+		if r, zone, err = parseRetainNumber(parts[1]); err != nil {
+			return r, err
+		}
+		if zone == "" {
+			return r, fmt.Errorf("Time zone is missing")
+		}
+	} else if i := strings.LastIndex(expr, retainSeparator); i >= 0 {
+		if _, err := strconv.Atoi(expr[i+1:]); err == nil {
+			if r, _, err = parseRetainNumber(expr[i:]); err != nil {
+				return r, err
+			}
+			expr = expr[:i]
+		}
+	}
+	if strings.TrimSpace(expr) == "" {
+		return r, fmt.Errorf("Cron schedule is missing")
+	}
+	c, err := Cron(expr, zone)
+	if err != nil {
+		return RetainIntervalSpec{}, err
+	}
+	r.IntervalSpec = c.Spec()
+	return r, nil
+}
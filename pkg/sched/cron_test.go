@@ -0,0 +1,162 @@
+package sched
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustLocation(t *testing.T, zone string) *time.Location {
+	loc, err := time.LoadLocation(zone)
+	require.NoError(t, err)
+	return loc
+}
+
+func TestCronParse(t *testing.T) {
+	invalid := []string{"", "* * * *", "* * * * * *", "60 * * * *",
+		"* 24 * * *", "* * 0 * *", "* * * 13 * ", "* * * * 8", "5-1 * * * *",
+		"*/0 * * * *", "x * * * *", "* * 30 feb *"}
+	for _, expr := range invalid {
+		_, err := Cron(expr, "")
+		require.Error(t, err, "%q parsed as valid", expr)
+	}
+	_, err := Cron("* * * * *", "Invalid/Zone")
+	require.Error(t, err, "invalid time zone")
+
+	valid := []string{"* * * * *", "*/15 0-6,22 1,15 * mon-fri",
+		"0 2 * jan,jul sun", "30_4_*_*_7", "0 0 29 feb *"}
+	for _, expr := range valid {
+		_, err := Cron(expr, "UTC")
+		require.NoError(t, err, "%q parsed as invalid", expr)
+	}
+}
+
+func TestCronNextAfter(t *testing.T) {
+	loc := mustLocation(t, "America/New_York")
+	tests := []struct {
+		expr string
+		from time.Time
+		next time.Time
+	}{
+		{"*/15 * * * *", time.Date(2018, 5, 1, 10, 7, 30, 0, loc),
+			time.Date(2018, 5, 1, 10, 15, 0, 0, loc)},
+		{"0 2 * * mon-fri", time.Date(2018, 5, 4, 2, 0, 0, 0, loc),
+			time.Date(2018, 5, 7, 2, 0, 0, 0, loc)},
+		{"0 0 1 */3 *", time.Date(2018, 5, 4, 0, 0, 0, 0, loc),
+			time.Date(2018, 7, 1, 0, 0, 0, 0, loc)},
+		// Either day field matches when both are restricted.
+		{"0 0 13 * fri", time.Date(2018, 5, 4, 0, 0, 0, 0, loc),
+			time.Date(2018, 5, 11, 0, 0, 0, 0, loc)},
+		{"0 0 29 feb *", time.Date(2018, 3, 1, 0, 0, 0, 0, loc),
+			time.Date(2020, 2, 29, 0, 0, 0, 0, loc)},
+		// Daily runs keep their wall clock time across DST changes.
+		{"30 1 * * *", time.Date(2018, 3, 10, 12, 0, 0, 0, loc),
+			time.Date(2018, 3, 11, 1, 30, 0, 0, loc)},
+		{"30 3 * * *", time.Date(2018, 3, 11, 0, 0, 0, 0, loc),
+			time.Date(2018, 3, 11, 3, 30, 0, 0, loc)},
+		// Skipped times run right after the gap.
+		{"30 2 * * *", time.Date(2018, 3, 11, 0, 0, 0, 0, loc),
+			time.Date(2018, 3, 11, 3, 0, 0, 0, loc)},
+		{"30 2 * * *", time.Date(2018, 3, 11, 3, 0, 0, 0, loc),
+			time.Date(2018, 3, 12, 2, 30, 0, 0, loc)},
+		{"45 2 * * *", time.Date(2018, 3, 11, 1, 50, 0, 0, loc),
+			time.Date(2018, 3, 11, 3, 0, 0, 0, loc)},
+		// Several skipped times run once.
+		{"*/15 2 * * *", time.Date(2018, 3, 11, 0, 0, 0, 0, loc),
+			time.Date(2018, 3, 11, 3, 0, 0, 0, loc)},
+		{"*/15 2 * * *", time.Date(2018, 3, 11, 3, 0, 0, 0, loc),
+			time.Date(2018, 3, 12, 2, 0, 0, 0, loc)},
+	}
+	for _, test := range tests {
+		c, err := Cron(test.expr, "America/New_York")
+		require.NoError(t, err)
+		next := c.nextAfter(test.from)
+		require.True(t, test.next.Equal(next), "%q after %v: expected %v, got %v",
+			test.expr, test.from, test.next, next)
+	}
+
+	// Clocks move forward by 30 minutes on Lord Howe Island, from 2:00 to
+	// 2:30.
+	lordHowe := mustLocation(t, "Australia/Lord_Howe")
+	c, err := Cron("15,45 2 * * *", "Australia/Lord_Howe")
+	require.NoError(t, err)
+	next := c.nextAfter(time.Date(2018, 10, 7, 1, 0, 0, 0, lordHowe))
+	require.True(t, next.Equal(time.Date(2018, 10, 7, 2, 30, 0, 0, lordHowe)),
+		"expected the end of the gap, got %v", next)
+	next = c.nextAfter(next)
+	require.True(t, next.Equal(time.Date(2018, 10, 7, 2, 45, 0, 0, lordHowe)),
+		"expected 2:45, got %v", next)
+
+	// Repeated times run once when clocks are set back.
+	c, err = Cron("30 1 * * *", "America/New_York")
+	require.NoError(t, err)
+	first := c.nextAfter(time.Date(2018, 11, 4, 0, 0, 0, 0, loc))
+	require.Equal(t, 1, first.Hour())
+	second := c.nextAfter(first)
+	require.Equal(t, 24*time.Hour+time.Hour, second.Sub(first))
+	require.Equal(t, 1, second.Hour())
+	require.Equal(t, 30, second.Minute())
+}
+
+func TestGapEnd(t *testing.T) {
+	loc := mustLocation(t, "America/New_York")
+	skipped := time.Date(2018, 3, 11, 2, 30, 0, 0, time.UTC)
+	end := time.Date(2018, 3, 11, 3, 0, 0, 0, loc)
+	// The skipped time may be normalized to either side of the gap.
+	for _, near := range []time.Time{
+		time.Date(2018, 3, 11, 1, 30, 0, 0, loc),
+		time.Date(2018, 3, 11, 3, 30, 0, 0, loc),
+	} {
+		require.True(t, end.Equal(gapEnd(near, skipped)),
+			"from %v: expected %v, got %v", near, end, gapEnd(near, skipped))
+	}
+}
+
+func TestCronSchedule(t *testing.T) {
+	schedule := CronType + nonYamlTypeSeparator + "0 2 * * 1-5@Europe/Paris,7"
+	intvs, err := ParseSchedule(schedule)
+	require.NoError(t, err)
+	require.Len(t, intvs, 1)
+	require.Equal(t, CronType, intvs[0].IntervalType())
+	require.Equal(t, uint32(7), intvs[0].RetainNumber())
+	require.Equal(t, "cron 0 2 * * 1-5@Europe/Paris,keep last 7",
+		ScheduleSummary(intvs, nil))
+
+	str, err := ScheduleStringRetainInv(intvs, nil)
+	require.NoError(t, err)
+	parsed, _, err := ParseScheduleAndPolicies(str)
+	require.NoError(t, err)
+	require.Len(t, parsed, 1)
+	require.Equal(t, intvs[0].RetainIntervalSpec(), parsed[0].RetainIntervalSpec())
+
+	_, err = ParseSchedule(CronType + nonYamlTypeSeparator + "0 2 * * *@,7")
+	require.Error(t, err, "missing time zone")
+	_, err = ParseSchedule(CronType + nonYamlTypeSeparator + "@UTC")
+	require.Error(t, err, "missing expression")
+
+	// The retain count is kept without a time zone.
+	intvs, err = ParseSchedule(CronType + nonYamlTypeSeparator + "0 0 * * *,5")
+	require.NoError(t, err)
+	require.Len(t, intvs, 1)
+	require.Equal(t, uint32(5), intvs[0].RetainNumber())
+	require.Equal(t, "0 0 * * *", intvs[0].Spec().Cron)
+	require.Equal(t, "", intvs[0].Spec().TimeZone)
+	str, err = ScheduleStringRetainInv(intvs, nil)
+	require.NoError(t, err)
+	parsed, _, err = ParseScheduleAndPolicies(str)
+	require.NoError(t, err)
+	require.Len(t, parsed, 1)
+	require.Equal(t, intvs[0].RetainIntervalSpec(), parsed[0].RetainIntervalSpec())
+	intvs, err = ParseSchedule(CronType + nonYamlTypeSeparator + "0 0 * * mon,fri")
+	require.NoError(t, err)
+	require.Equal(t, "0 0 * * mon,fri", intvs[0].Spec().Cron)
+	_, err = ParseSchedule(CronType + nonYamlTypeSeparator + "0 0 * * *,0")
+	require.Error(t, err, "zero retain count")
+
+	intvs, err = ParseSchedule(CronType + nonYamlTypeSeparator + "0 2 * * *")
+	require.NoError(t, err)
+	intvs = SetupIntvWithDefaults(intvs)
+	require.Equal(t, uint32(WeeklyRetain), intvs[0].RetainNumber())
+	require.Equal(t, "", intvs[0].Spec().TimeZone)
+}
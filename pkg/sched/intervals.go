@@ -17,6 +17,7 @@ const (
 	MonthlyType          = "monthly"
 	WeeklyType           = "weekly"
 	PeriodicType         = "periodic"
+	CronType             = "cron"
 	retainSeparator      = ","
 	MonthlyRetain        = 12
 	WeeklyRetain         = 5
@@ -45,6 +46,10 @@ type IntervalSpec struct {
 	Day     int    `yaml:"day,omitempty"`
 	Hour    int    `yaml:"hour,omitempty"`
 	Minute  int    `yaml:"minute,omitempty"`
+	// Cron is the 5 field expression of cron intervals.
+	Cron string `yaml:"cron,omitempty"`
+	// TimeZone is the IANA time zone of cron intervals, local if empty.
+	TimeZone string `yaml:"timezone,omitempty"`
 }
 
 type Interval interface {
@@ -189,6 +194,8 @@ func parseSpec(spec *IntervalSpec) (Interval, error) {
 			spec.Day = 1
 		}
 		return Monthly(spec.Day, spec.Hour, spec.Minute), nil
+	case CronType:
+		return Cron(spec.Cron, spec.TimeZone)
 	}
 	return nil, fmt.Errorf("Invalid schedule spec")
 }
@@ -481,6 +488,12 @@ var ParseCLI = map[string]func(string) (RetainIntervalSpec, error){
 	DailyType:   parseDaily,
 	WeeklyType:  parseWeekly,
 	MonthlyType: parseMonthly,
+	CronType:    parseCron,
+}
+
+// NextAfter returns the first time after t at which interval is due.
+func NextAfter(interval Interval, t time.Time) time.Time {
+	return interval.nextAfter(t)
 }
 
 func IntervalType(interval Interval) string {
@@ -488,7 +501,8 @@ func IntervalType(interval Interval) string {
 }
 
 func IsIntervalType(t string) bool {
-	knownTypes := []string{PeriodicType, DailyType, WeeklyType, MonthlyType}
+	knownTypes := []string{PeriodicType, DailyType, WeeklyType, MonthlyType,
+		CronType}
 	for _, p := range knownTypes {
 		if p == t {
 			return true
@@ -558,7 +572,7 @@ func SetupIntvWithDefaults(intvs []RetainInterval) []RetainInterval {
 			switch intv.IntervalType() {
 			case DailyType:
 				p.retain = DailyRetain
-			case WeeklyType, PeriodicType:
+			case WeeklyType, PeriodicType, CronType:
 				p.retain = WeeklyRetain
 			case MonthlyType:
 				p.retain = MonthlyRetain
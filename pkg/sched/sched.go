@@ -3,10 +3,14 @@ package sched
 import (
 	"container/list"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
+	"go.pedge.io/dlog"
+
 	"github.com/libopenstorage/openstorage/pkg/dbg"
+	"github.com/portworx/kvdb"
 )
 
 type TaskID uint64
@@ -20,6 +24,37 @@ func ValidTaskID(t TaskID) bool { return t != TaskNone }
 
 type ScheduleTask func(Interval)
 
+// CatchUpPolicy decides what happens to the runs of a persistent task that
+// were missed while the scheduler was not running.
+type CatchUpPolicy int
+
+const (
+	// CatchUpSkip drops missed runs, the task next runs at its next interval.
+	CatchUpSkip CatchUpPolicy = iota
+	// CatchUpOnce runs the task once for all of its missed runs.
+	CatchUpOnce
+	// CatchUpAll runs the task once for every missed run, one run per tick.
+	CatchUpAll
+)
+
+// Task describes a scheduled task.
+type Task struct {
+	// ID unique task identifier
+	ID TaskID
+	// Name of the registered task function, empty if not persistent
+	Name string
+	// Interval at which the task is scheduled
+	Interval RetainIntervalSpec
+	// RunAt is the next time at which the task is going to be scheduled
+	RunAt time.Time
+	// OnlyOnce one time execution only
+	OnlyOnce bool
+	// Owner is the owner of the scheduler the task belongs to
+	Owner string
+	// CatchUp policy for runs missed while the scheduler was not running
+	CatchUp CatchUpPolicy
+}
+
 type Scheduler interface {
 	// Schedule given task at given interval.
 	// Returns associated task id if scheduled successfully,
@@ -30,6 +65,9 @@ type Scheduler interface {
 	// Cancel given task.
 	Cancel(taskID TaskID) error
 
+	// Enumerate returns the tasks that are pending.
+	Enumerate() ([]*Task, error)
+
 	// Restart scheduling.
 	Start()
 
@@ -37,6 +75,23 @@ type Scheduler interface {
 	Stop()
 }
 
+// PersistentScheduler is a Scheduler that can save tasks to kvdb. Since
+// functions cannot be saved, persistent tasks refer to a function that is
+// registered by name. Saved tasks are reloaded when scheduling starts and
+// run once their function is registered.
+type PersistentScheduler interface {
+	Scheduler
+
+	// Register binds name to task for persistent tasks.
+	Register(name string, task ScheduleTask) error
+
+	// SchedulePersistent schedules the task registered as name at given
+	// interval and saves it. Runs missed while the scheduler was not
+	// running are handled as specified by catchUp.
+	SchedulePersistent(name string, interval Interval,
+		runAt time.Time, onlyOnce bool, catchUp CatchUpPolicy) (TaskID, error)
+}
+
 var instance Scheduler
 
 type taskInfo struct {
@@ -44,6 +99,10 @@ type taskInfo struct {
 	ID TaskID
 	// task function to run
 	task ScheduleTask
+	// name of the registered task function, for persistent tasks
+	name string
+	// catchUp policy for missed runs of persistent tasks
+	catchUp CatchUpPolicy
 	// interval at which task is scheduled
 	interval Interval
 	// runtAt is next time at which task is going to be scheduled
@@ -76,6 +135,12 @@ type manager struct {
 	cv *sync.Cond
 	// enqueuedTasks is list of tasks that must be run now
 	enqueuedTasks *list.List
+	// kv stores persistent tasks, nil if tasks are not persisted
+	kv kvdb.Kvdb
+	// owner of the persisted tasks
+	owner string
+	// handlers are task functions registered by name
+	handlers map[string]ScheduleTask
 }
 
 func (s *manager) Schedule(
@@ -90,9 +155,8 @@ func (s *manager) Schedule(
 	if task == nil {
 		return TaskNone, fmt.Errorf("Invalid task specified")
 	}
-	now := time.Now()
-	if interval.nextAfter(now).Sub(now) < time.Second {
-		return TaskNone, fmt.Errorf("Minimum interval is a second")
+	if err := checkInterval(interval); err != nil {
+		return TaskNone, err
 	}
 
 	s.currTaskID++
@@ -109,6 +173,33 @@ func (s *manager) Schedule(
 	return t.ID, nil
 }
 
+func (s *manager) Enumerate() ([]*Task, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	tasks := make([]*Task, 0, s.tasks.Len())
+	for e := s.tasks.Front(); e != nil; e = e.Next() {
+		tasks = append(tasks, s.toTask(e.Value.(*taskInfo)))
+	}
+	return tasks, nil
+}
+
+// toTask returns the description of t.
+func (s *manager) toTask(t *taskInfo) *Task {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return &Task{
+		ID:       t.ID,
+		Name:     t.name,
+		Interval: intervalSpec(t.interval),
+		RunAt:    t.runAt,
+		OnlyOnce: t.onlyOnce,
+		Owner:    s.owner,
+		CatchUp:  t.catchUp,
+	}
+}
+
 func (s *manager) Cancel(
 	taskID TaskID,
 ) error {
@@ -120,6 +211,9 @@ func (s *manager) Cancel(
 		if t.ID == taskID {
 			t.valid = false
 			s.tasks.Remove(e)
+			if t.name != "" {
+				return s.deleteTask(t.ID)
+			}
 			return nil
 		}
 	}
@@ -145,6 +239,11 @@ func (s *manager) Start() {
 	if !s.started {
 		s.ticker = time.NewTicker(s.minimumInterval)
 	}
+	if s.kv != nil {
+		if err := s.load(); err != nil {
+			dlog.Errorf("Failed to load scheduled tasks: %v", err)
+		}
+	}
 }
 
 func (s *manager) scheduleTasks() {
@@ -154,10 +253,15 @@ func (s *manager) scheduleTasks() {
 			now := time.Now()
 			s.Lock()
 			tasksReady := make([]*taskInfo, 0)
-			for e := s.tasks.Front(); e != nil; e = e.Next() {
+			for e := s.tasks.Front(); e != nil; {
 				t := e.Value.(*taskInfo)
+				next := e.Next()
 				t.lock.Lock()
-				if !t.enqueued &&
+				if t.name != "" {
+					// Persistent tasks run once their function is registered.
+					t.task = s.handlers[t.name]
+				}
+				if !t.enqueued && t.task != nil &&
 					(now.Equal(t.runAt) || now.After(t.runAt)) {
 					tasksReady = append(tasksReady, t)
 					t.enqueued = true
@@ -166,6 +270,7 @@ func (s *manager) scheduleTasks() {
 					}
 				}
 				t.lock.Unlock()
+				e = next
 			}
 			s.Unlock()
 			s.enqueuedTasksLock.Lock()
@@ -191,32 +296,91 @@ func (s *manager) runTasks() {
 		}
 		s.cv.L.Unlock()
 		if t != nil && t.valid {
-			t.task(t.interval)
 			t.lock.Lock()
-			t.runAt = t.interval.nextAfter(time.Now())
+			task := t.task
+			t.lock.Unlock()
+			task(t.interval)
+			t.lock.Lock()
+			t.runAt = t.nextRunAt(time.Now())
 			t.enqueued = false
 			t.lock.Unlock()
+			if t.name != "" {
+				s.saveRun(t)
+			}
 		}
 	}
 }
 
-func New(minimumInterval time.Duration) Scheduler {
+// nextRunAt returns the time of the next run after a run that finished
+// at now. Tasks that catch up on all missed runs advance from their
+// previous run time until they are caught up.
+func (t *taskInfo) nextRunAt(now time.Time) time.Time {
+	if t.catchUp == CatchUpAll {
+		if next := t.interval.nextAfter(t.runAt); !next.After(now) {
+			return next
+		}
+	}
+	return t.interval.nextAfter(now)
+}
+
+func checkInterval(interval Interval) error {
+	now := time.Now()
+	if interval.nextAfter(now).Sub(now) < time.Second {
+		return fmt.Errorf("Minimum interval is a second")
+	}
+	return nil
+}
+
+func newManager(minimumInterval time.Duration) *manager {
 	m := &manager{
 		tasks:             list.New(),
 		currTaskID:        0,
 		minimumInterval:   minimumInterval,
 		ticker:            time.NewTicker(minimumInterval),
 		enqueuedTasksLock: sync.Mutex{},
-		enqueuedTasks:     list.New()}
+		enqueuedTasks:     list.New(),
+		handlers:          make(map[string]ScheduleTask)}
 	m.cv = sync.NewCond(&m.enqueuedTasksLock)
+	return m
+}
+
+func (s *manager) run() {
 	for i := 0; i < numGoRoutines; i++ {
-		go m.runTasks()
+		go s.runTasks()
 	}
-	m.Start()
-	go m.scheduleTasks()
+	s.Start()
+	go s.scheduleTasks()
+}
+
+func New(minimumInterval time.Duration) Scheduler {
+	m := newManager(minimumInterval)
+	m.run()
 	return m
 }
 
+// NewPersistent returns a PersistentScheduler that saves tasks in kv under
+// owner. Tasks saved earlier by the same owner are reloaded.
+func NewPersistent(
+	minimumInterval time.Duration,
+	kv kvdb.Kvdb,
+	owner string,
+) (PersistentScheduler, error) {
+	if kv == nil {
+		return nil, fmt.Errorf("Invalid kvdb specified")
+	}
+	if owner == "" || strings.Contains(owner, "/") {
+		return nil, fmt.Errorf("Invalid owner specified: %q", owner)
+	}
+	m := newManager(minimumInterval)
+	m.kv = kv
+	m.owner = owner
+	if err := m.load(); err != nil {
+		return nil, err
+	}
+	m.run()
+	return m, nil
+}
+
 func Init(minimumInterval time.Duration) {
 	dbg.Assert(instance == nil, "Scheduler already initialized")
 	instance = New(minimumInterval)
@@ -0,0 +1,174 @@
+package sched
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.pedge.io/dlog"
+)
+
+const (
+	// tasksKey is the kvdb prefix for persistent tasks. Tasks are stored
+	// as tasksKey/<owner>/<task id>.
+	tasksKey = "sched/tasks"
+)
+
+func (s *manager) Register(name string, task ScheduleTask) error {
+	if name == "" {
+		return fmt.Errorf("Invalid task name specified")
+	}
+	if task == nil {
+		return fmt.Errorf("Invalid task specified")
+	}
+	s.Lock()
+	defer s.Unlock()
+
+	s.handlers[name] = task
+	return nil
+}
+
+func (s *manager) SchedulePersistent(
+	name string,
+	interval Interval,
+	runAt time.Time,
+	onlyOnce bool,
+	catchUp CatchUpPolicy,
+) (TaskID, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.kv == nil {
+		return TaskNone, fmt.Errorf("Scheduler does not persist tasks")
+	}
+	if name == "" {
+		return TaskNone, fmt.Errorf("Invalid task name specified")
+	}
+	if catchUp < CatchUpSkip || catchUp > CatchUpAll {
+		return TaskNone, fmt.Errorf("Invalid catch up policy: %v", catchUp)
+	}
+	if err := checkInterval(interval); err != nil {
+		return TaskNone, err
+	}
+
+	s.currTaskID++
+	t := &taskInfo{ID: s.currTaskID,
+		name:     name,
+		catchUp:  catchUp,
+		interval: interval,
+		runAt:    interval.nextAfter(runAt),
+		valid:    true,
+		onlyOnce: onlyOnce}
+	if err := s.saveTask(s.toTask(t)); err != nil {
+		return TaskNone, err
+	}
+	s.tasks.PushBack(t)
+	return t.ID, nil
+}
+
+// load adds the tasks saved in kvdb that are not scheduled yet. Missed
+// runs are handled according to the catch up policy of each task.
+// Must be called with the manager lock held.
+func (s *manager) load() error {
+	kvps, err := s.kv.Enumerate(s.ownerKey())
+	if err != nil {
+		return err
+	}
+
+	scheduled := make(map[TaskID]bool)
+	for e := s.tasks.Front(); e != nil; e = e.Next() {
+		scheduled[e.Value.(*taskInfo).ID] = true
+	}
+	now := time.Now()
+	for _, kvp := range kvps {
+		var task Task
+		if err := json.Unmarshal(kvp.Value, &task); err != nil {
+			return fmt.Errorf("Invalid task %s: %v", kvp.Key, err)
+		}
+		if task.ID > s.currTaskID {
+			s.currTaskID = task.ID
+		}
+		if scheduled[task.ID] {
+			continue
+		}
+		interval, err := parseRetainSpec(&task.Interval)
+		if err != nil {
+			return fmt.Errorf("Invalid task %s: %v", kvp.Key, err)
+		}
+		t := &taskInfo{ID: task.ID,
+			name:     task.Name,
+			catchUp:  task.CatchUp,
+			interval: interval,
+			runAt:    task.RunAt,
+			valid:    true,
+			onlyOnce: task.OnlyOnce}
+
+		// Tasks that catch up keep their past runAt and run on the next tick.
+		if !t.runAt.After(now) && t.catchUp == CatchUpSkip {
+			if t.onlyOnce {
+				dlog.Infof("Dropping missed task %v (%v)", t.ID, t.name)
+				if err := s.deleteTask(t.ID); err != nil {
+					return err
+				}
+				continue
+			}
+			t.runAt = interval.nextAfter(now)
+			if err := s.saveTask(s.toTask(t)); err != nil {
+				return err
+			}
+		}
+		s.tasks.PushBack(t)
+	}
+	return nil
+}
+
+// saveRun records a completed run of the persistent task t.
+func (s *manager) saveRun(t *taskInfo) {
+	s.Lock()
+	defer s.Unlock()
+
+	if !t.valid {
+		// Cancelled while running.
+		return
+	}
+	var err error
+	if t.onlyOnce {
+		err = s.deleteTask(t.ID)
+	} else {
+		err = s.saveTask(s.toTask(t))
+	}
+	if err != nil {
+		dlog.Errorf("Failed to save run of task %v (%v): %v", t.ID, t.name, err)
+	}
+}
+
+func (s *manager) saveTask(task *Task) error {
+	if _, err := s.kv.Put(s.taskKey(task.ID), task, 0); err != nil {
+		return fmt.Errorf("Failed to save task %v: %v", task.ID, err)
+	}
+	return nil
+}
+
+func (s *manager) deleteTask(id TaskID) error {
+	if _, err := s.kv.Delete(s.taskKey(id)); err != nil {
+		return fmt.Errorf("Failed to delete task %v: %v", id, err)
+	}
+	return nil
+}
+
+func (s *manager) ownerKey() string {
+	return tasksKey + "/" + s.owner + "/"
+}
+
+func (s *manager) taskKey(id TaskID) string {
+	return s.ownerKey() + strconv.FormatUint(uint64(id), 10)
+}
+
+// intervalSpec returns the serialized form of interval.
+func intervalSpec(interval Interval) RetainIntervalSpec {
+	if ri, ok := interval.(RetainInterval); ok {
+		return ri.RetainIntervalSpec()
+	}
+	return RetainIntervalSpec{IntervalSpec: interval.Spec()}
+}
@@ -0,0 +1,163 @@
+package sched
+
+import (
+	"testing"
+	"time"
+
+	"github.com/portworx/kvdb"
+	"github.com/portworx/kvdb/mem"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKvdb(t *testing.T) kvdb.Kvdb {
+	kv, err := kvdb.New(mem.Name, "sched_test", []string{}, nil, nil)
+	require.NoError(t, err)
+	return kv
+}
+
+func newTestPersistent(t *testing.T, kv kvdb.Kvdb, owner string) PersistentScheduler {
+	s, err := NewPersistent(100*time.Millisecond, kv, owner)
+	require.NoError(t, err)
+	return s
+}
+
+func TestPersistentReload(t *testing.T) {
+	kv := newTestKvdb(t)
+
+	_, err := NewPersistent(time.Second, kv, "")
+	require.Error(t, err, "empty owner")
+
+	s := newTestPersistent(t, kv, "node1")
+	_, err = s.SchedulePersistent("task", Periodic(time.Second), time.Now(),
+		false, CatchUpPolicy(10))
+	require.Error(t, err, "invalid catch up policy")
+
+	interval := SetupIntvWithDefaults([]RetainInterval{
+		NewRetainInterval(Periodic(time.Hour)),
+	})[0]
+	taskID, err := s.SchedulePersistent("task", interval, time.Now(),
+		false, CatchUpOnce)
+	require.NoError(t, err)
+	tasks, err := s.Enumerate()
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	require.Equal(t, taskID, tasks[0].ID)
+	require.Equal(t, "task", tasks[0].Name)
+	require.Equal(t, "node1", tasks[0].Owner)
+	require.Equal(t, uint32(WeeklyRetain), tasks[0].Interval.Retain)
+	s.Stop()
+
+	// The task is reloaded by the same owner only.
+	s = newTestPersistent(t, kv, "node1")
+	reloaded, err := s.Enumerate()
+	require.NoError(t, err)
+	require.Len(t, reloaded, 1)
+	require.Equal(t, taskID, reloaded[0].ID)
+	require.Equal(t, tasks[0].Interval, reloaded[0].Interval)
+	require.True(t, tasks[0].RunAt.Equal(reloaded[0].RunAt))
+
+	other := newTestPersistent(t, kv, "node2")
+	tasks, err = other.Enumerate()
+	require.NoError(t, err)
+	require.Len(t, tasks, 0)
+	other.Stop()
+
+	// New tasks do not reuse reloaded task IDs.
+	newID, err := s.SchedulePersistent("task", Periodic(time.Hour), time.Now(),
+		false, CatchUpOnce)
+	require.NoError(t, err)
+	require.True(t, newID > taskID)
+
+	require.NoError(t, s.Cancel(taskID))
+	require.NoError(t, s.Cancel(newID))
+	s.Stop()
+
+	s = newTestPersistent(t, kv, "node1")
+	tasks, err = s.Enumerate()
+	require.NoError(t, err)
+	require.Len(t, tasks, 0, "cancelled tasks are not reloaded")
+	s.Stop()
+}
+
+func TestPersistentCatchUp(t *testing.T) {
+	kv := newTestKvdb(t)
+	s := newTestPersistent(t, kv, "node1")
+
+	// Save tasks that missed their runs of the last 2.5 hours.
+	now := time.Now()
+	policies := map[string]CatchUpPolicy{
+		"skip": CatchUpSkip,
+		"once": CatchUpOnce,
+		"all":  CatchUpAll,
+	}
+	save := func(name string, onlyOnce bool, catchUp CatchUpPolicy) {
+		id, err := s.SchedulePersistent(name, Periodic(time.Hour), now,
+			onlyOnce, catchUp)
+		require.NoError(t, err)
+		_, err = kv.Put(s.(*manager).taskKey(id), &Task{
+			ID:       id,
+			Name:     name,
+			Interval: intervalSpec(Periodic(time.Hour)),
+			RunAt:    now.Add(-150 * time.Minute),
+			OnlyOnce: onlyOnce,
+			CatchUp:  catchUp,
+		}, 0)
+		require.NoError(t, err)
+	}
+	for name, p := range policies {
+		save(name, false, p)
+	}
+	save("missed", true, CatchUpSkip)
+	s.Stop()
+
+	s = newTestPersistent(t, kv, "node1")
+	defer s.Stop()
+	tasks, err := s.Enumerate()
+	require.NoError(t, err)
+	require.Len(t, tasks, 3, "missed one time task is dropped")
+	for _, task := range tasks {
+		require.Equal(t, task.CatchUp == CatchUpSkip, task.RunAt.After(now),
+			"runAt of %v", task.Name)
+	}
+
+	// Missed runs wait for the task to be registered.
+	counts := make(map[string]*testCounter)
+	time.Sleep(300 * time.Millisecond)
+	for name := range policies {
+		tc := &testCounter{}
+		counts[name] = tc
+		require.NoError(t, s.Register(name, func(Interval) {
+			tc.incr()
+		}))
+	}
+	time.Sleep(time.Second)
+	require.Equal(t, 0, counts["skip"].count)
+	require.Equal(t, 1, counts["once"].count)
+	require.Equal(t, 3, counts["all"].count)
+
+	tasks, err = s.Enumerate()
+	require.NoError(t, err)
+	for _, task := range tasks {
+		require.True(t, task.RunAt.After(now), "%v caught up", task.Name)
+	}
+}
+
+func TestPersistentRunOnce(t *testing.T) {
+	kv := newTestKvdb(t)
+	s := newTestPersistent(t, kv, "node1")
+	defer s.Stop()
+
+	tc := &testCounter{}
+	require.NoError(t, s.Register("task", func(Interval) {
+		tc.incr()
+	}))
+	_, err := s.SchedulePersistent("task", Periodic(time.Second),
+		time.Now().Add(-time.Second), true, CatchUpSkip)
+	require.NoError(t, err)
+	time.Sleep(500 * time.Millisecond)
+	require.Equal(t, 1, tc.count)
+
+	kvps, err := kv.Enumerate(tasksKey)
+	require.NoError(t, err)
+	require.Len(t, kvps, 0, "one time task is deleted after running")
+}
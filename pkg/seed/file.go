@@ -0,0 +1,138 @@
+package seed
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+// FileRoots are the directories whose subdirectories can be copied by
+// file:// sources. Other local paths are refused.
+var FileRoots = []string{volume.VolumeBase}
+
+// File is a source that copies a local directory.
+type File struct {
+	uri   string
+	dir   string
+	ready bool
+}
+
+// String representation of this source
+func (f *File) String() string {
+	return f.uri
+}
+
+// Load copies the directory into dest. File modes and symbolic links are
+// kept.
+func (f *File) Load(dest string) error {
+	f.ready = false
+	dir, err := filepath.EvalSymlinks(f.dir)
+	if err != nil {
+		return err
+	}
+	if !allowedDir(dir) {
+		return fmt.Errorf("Seed source %s is not a volume directory", f.uri)
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("Seed source %s is not a directory", f.uri)
+	}
+	if within(f.dir, dest) || within(dir, dest) {
+		return fmt.Errorf("Cannot seed %s from %s into itself", dest, f.uri)
+	}
+	err = filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		target, err := destPath(dest, rel)
+		if err != nil {
+			return err
+		}
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case info.Mode().IsRegular():
+			src, err := os.Open(p)
+			if err != nil {
+				return err
+			}
+			defer src.Close()
+			return writeFile(target, info.Mode().Perm(), src)
+		}
+		// Devices, sockets and pipes are skipped.
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to copy %s into %s: %v", f.uri, dest, err)
+	}
+	f.ready = true
+	return nil
+}
+
+// Metadata for this source.
+func (f *File) MetadataRead(mdDir string) (string, error) {
+	return readMetadata(mdDir)
+}
+
+// MetadataWrite for this source.
+func (f *File) MetadataWrite(mdDir string) error {
+	if !f.ready {
+		return ErrNotLoaded
+	}
+	return writeMetadata(mdDir, &Metadata{
+		Source: f.uri,
+		Time:   time.Now(),
+	})
+}
+
+// NewFileSource returns a source for file:// URIs of volume directories, the
+// directories in one of FileRoots.
+func NewFileSource(uri string, options map[string]string) (Source, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "file" || (u.Host != "" && u.Host != "localhost") ||
+		!filepath.IsAbs(u.Path) {
+		return nil, ErrUnsupported
+	}
+	dir := filepath.Clean(u.Path)
+	if !allowedDir(dir) {
+		return nil, fmt.Errorf("Seed source %s is not a volume directory", uri)
+	}
+	return &File{uri: uri, dir: dir}, nil
+}
+
+// allowedDir returns true if dir is in one of FileRoots. The roots
+// themselves cannot be copied.
+func allowedDir(dir string) bool {
+	for _, root := range FileRoots {
+		roots := []string{root}
+		if resolved, err := filepath.EvalSymlinks(root); err == nil {
+			roots = append(roots, resolved)
+		}
+		for _, r := range roots {
+			if within(r, dir) && filepath.Clean(r) != dir {
+				return true
+			}
+		}
+	}
+	return false
+}
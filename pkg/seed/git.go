@@ -5,44 +5,80 @@ import (
 	"net/url"
 	"os/exec"
 	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 )
 
-const GitRevision = "revision"
+const (
+	// GitRevision is the option that selects the revision to check out.
+	GitRevision = "revision"
+	// GitDepth is the option that limits the history cloned to the given
+	// number of commits. The revision must then be a branch or a tag.
+	GitDepth = "depth"
+
+	gitSchemePrefix = "git+"
+)
 
 type Git struct {
+	uri      string
 	host     string
 	revision string
+	depth    int
+	dir      string
+	commit   string
 	ready    bool
 }
 
 // String representation of this source
 func (g *Git) String() string {
-	return "github://" + g.host
+	return g.uri
 }
 
 // Load from URI into dest.
 func (g *Git) Load(dest string) error {
-
 	g.ready = false
-	cmd := exec.Command("git", "clone", g.host, dest)
-
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("'wd: %s git clone %s': %s: %s", dest, g.host, output, err)
+	if len(g.dir) != 0 {
+		dir, err := filepath.EvalSymlinks(g.dir)
+		if err != nil {
+			return err
+		}
+		if !allowedDir(dir) {
+			return fmt.Errorf("Seed source %s is not a volume directory", g.uri)
+		}
 	}
-	if len(g.revision) == 0 {
-		g.ready = true
-		return nil
+	args := []string{"clone"}
+	if g.depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(g.depth))
+		if len(g.revision) != 0 {
+			args = append(args, "--branch", g.revision)
+		}
 	}
-	cmd = exec.Command("git", "checkout", g.revision)
-	cmd.Dir = dest
+	args = append(args, g.host, dest)
+	cmd := exec.Command("git", args...)
 	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("wd %v 'git checkout %s': %s: %s", cmd.Dir, g.revision, output, err)
+		return fmt.Errorf("'wd: %s git clone %s': %s: %s", dest, g.host, output, err)
+	}
+	if len(g.revision) != 0 && g.depth == 0 {
+		cmd = exec.Command("git", "checkout", g.revision)
+		cmd.Dir = dest
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("wd %v 'git checkout %s': %s: %s", cmd.Dir, g.revision, output, err)
+		}
+		cmd = exec.Command("git", "reset", "--hard")
+		cmd.Dir = dest
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("wd %v 'git reset --hard  %s': %s: %s", cmd.Dir, g.revision, output, err)
+		}
 	}
-	cmd = exec.Command("git", "reset", "--hard")
+	cmd = exec.Command("git", "rev-parse", "HEAD")
 	cmd.Dir = dest
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("wd %v 'git reset --hard  %s': %s: %s", cmd.Dir, g.revision, output, err)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("wd %v 'git rev-parse HEAD': %s: %s", cmd.Dir, output, err)
 	}
+	g.commit = strings.TrimSpace(string(output))
 
 	g.ready = true
 	return nil
@@ -50,24 +86,60 @@ func (g *Git) Load(dest string) error {
 
 // Metadata for this source.
 func (g *Git) MetadataRead(mdDir string) (string, error) {
-	return "", nil
+	return readMetadata(mdDir)
 }
 
 // MetadataWrite for this source.
 func (g *Git) MetadataWrite(mdDir string) error {
-	return nil
+	if !g.ready {
+		return ErrNotLoaded
+	}
+	return writeMetadata(mdDir, &Metadata{
+		Source:   g.uri,
+		Revision: g.commit,
+		Time:     time.Now(),
+	})
 }
 
+// NewGitSource returns a source for github:// URIs and for git URIs with a
+// git+ prefix, e.g. git+https:// and git+ssh://. github:// URIs are cloned
+// over https. Like file:// sources, git+file:// sources are limited to the
+// directories in one of FileRoots.
 func NewGitSource(uri string, options map[string]string) (Source, error) {
 	u, err := url.Parse(uri)
 	if err != nil {
 		return nil, err
 	}
-	if u.Scheme != "github" {
+	g := &Git{revision: options[GitRevision]}
+	switch {
+	case u.Scheme == "github":
+		g.host = "https://" + path.Join(u.Host, u.Path)
+	case strings.HasPrefix(u.Scheme, gitSchemePrefix):
+		switch transport := strings.TrimPrefix(u.Scheme, gitSchemePrefix); transport {
+		case "file":
+			if (u.Host != "" && u.Host != "localhost") || !filepath.IsAbs(u.Path) {
+				return nil, ErrUnsupported
+			}
+			g.dir = filepath.Clean(u.Path)
+			if !allowedDir(g.dir) {
+				return nil, fmt.Errorf("Seed source %s is not a volume directory", uri)
+			}
+			g.host = g.dir
+		case "http", "https", "ssh":
+			r := *u
+			r.Scheme = transport
+			g.host = r.String()
+		default:
+			return nil, ErrUnsupported
+		}
+	default:
 		return nil, ErrUnsupported
 	}
-	return &Git{
-		host:     "http://" + path.Join(u.Host, u.Path),
-		revision: options[GitRevision],
-	}, nil
+	if depth, ok := options[GitDepth]; ok {
+		if g.depth, err = strconv.Atoi(depth); err != nil || g.depth < 0 {
+			return nil, fmt.Errorf("Invalid %s %q", GitDepth, depth)
+		}
+	}
+	g.uri = redact(u)
+	return g, nil
 }
@@ -1,10 +1,15 @@
 package seed
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -31,3 +36,71 @@ func TestLoad(t *testing.T) {
 	assert.NoError(t, s.Load(dest), "Failed in load")
 	os.RemoveAll(dest)
 }
+
+// gitRepo creates a bare repository with two commits, the first tagged v1,
+// and returns its path.
+func gitRepo(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "seed-git")
+	require.NoError(t, err)
+	work := filepath.Join(dir, "work")
+	bare := filepath.Join(dir, "repo.git")
+	git := func(dir string, args ...string) {
+		cmd := exec.Command("git", append([]string{
+			"-c", "user.name=test", "-c", "user.email=test@example.com",
+		}, args...)...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, "git %v: %s", args, output)
+	}
+	require.NoError(t, os.MkdirAll(work, 0755))
+	git(work, "init")
+	require.NoError(t, ioutil.WriteFile(filepath.Join(work, "data"), []byte("v1"), 0644))
+	git(work, "add", "data")
+	git(work, "commit", "-m", "v1")
+	git(work, "tag", "v1")
+	require.NoError(t, ioutil.WriteFile(filepath.Join(work, "data"), []byte("v2"), 0644))
+	git(work, "commit", "-am", "v2")
+	git(dir, "clone", "--bare", work, bare)
+	return bare
+}
+
+func TestGitSource(t *testing.T) {
+	repo := gitRepo(t)
+	defer os.RemoveAll(filepath.Dir(repo))
+
+	_, err := New("git+ftp://"+repo, nil)
+	assert.Equal(t, ErrUnsupported, err)
+	_, err = New("git+file://"+repo, nil)
+	assert.Error(t, err, "Repository outside the file roots accepted")
+
+	defer func(roots []string) { FileRoots = roots }(FileRoots)
+	FileRoots = []string{filepath.Dir(repo)}
+	_, err = New("git+file://"+repo, map[string]string{GitDepth: "shallow"})
+	assert.Error(t, err)
+
+	for _, options := range []map[string]string{
+		{GitRevision: "v1"},
+		{GitRevision: "v1", GitDepth: "1"},
+	} {
+		dir, err := ioutil.TempDir("", "seed-dest")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+		dest := filepath.Join(dir, "data")
+
+		src, err := New("git+file://"+repo, options)
+		require.NoError(t, err)
+		assert.Equal(t, ErrNotLoaded, src.MetadataWrite(dir))
+		require.NoError(t, src.Load(dest), "%v", options)
+		data, err := ioutil.ReadFile(filepath.Join(dest, "data"))
+		require.NoError(t, err)
+		assert.Equal(t, "v1", string(data), "%v", options)
+
+		require.NoError(t, src.MetadataWrite(dir))
+		md, err := src.MetadataRead(dir)
+		require.NoError(t, err)
+		var m Metadata
+		require.NoError(t, json.Unmarshal([]byte(md), &m))
+		assert.Equal(t, "git+file://"+repo, m.Source)
+		assert.Len(t, m.Revision, 40)
+	}
+}
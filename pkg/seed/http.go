@@ -0,0 +1,263 @@
+package seed
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// HTTPSha256 is the option with the expected sha256 checksum of an archive,
+// in hex.
+const HTTPSha256 = "sha256"
+
+var (
+	// ErrChecksum is returned when an archive does not match its checksum.
+	ErrChecksum = errors.New("Seed archive checksum mismatch")
+
+	gzipMagic = []byte{0x1f, 0x8b}
+	zipMagic  = []byte("PK\x03\x04")
+)
+
+// HTTP is a source that loads a tar, tar.gz or zip archive over http(s).
+type HTTP struct {
+	uri      string
+	url      string
+	checksum string
+	loaded   string
+	ready    bool
+}
+
+// String representation of this source
+func (h *HTTP) String() string {
+	return h.uri
+}
+
+// Load downloads the archive and extracts it into dest.
+func (h *HTTP) Load(dest string) error {
+	h.ready = false
+	resp, err := http.Get(h.url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Failed to download %s: %s", h.uri, resp.Status)
+	}
+
+	// Archives are verified before anything is extracted, and zip archives
+	// need random access, so the archive is saved to a temporary file.
+	f, err := ioutil.TempFile("", "seed")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(f, hash), resp.Body)
+	if err != nil {
+		return fmt.Errorf("Failed to download %s: %v", h.uri, err)
+	}
+	sum := hex.EncodeToString(hash.Sum(nil))
+	if len(h.checksum) != 0 && !strings.EqualFold(sum, h.checksum) {
+		return ErrChecksum
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return err
+	}
+	r := bufio.NewReader(f)
+	magic, _ := r.Peek(len(zipMagic))
+	switch {
+	case bytes.HasPrefix(magic, zipMagic):
+		err = extractZip(f, size, dest)
+	case bytes.HasPrefix(magic, gzipMagic):
+		var gz *gzip.Reader
+		if gz, err = gzip.NewReader(r); err == nil {
+			err = extractTar(gz, dest)
+			gz.Close()
+		}
+	default:
+		err = extractTar(r, dest)
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to extract %s into %s: %v", h.uri, dest, err)
+	}
+	h.loaded = sum
+	h.ready = true
+	return nil
+}
+
+// Metadata for this source.
+func (h *HTTP) MetadataRead(mdDir string) (string, error) {
+	return readMetadata(mdDir)
+}
+
+// MetadataWrite for this source.
+func (h *HTTP) MetadataWrite(mdDir string) error {
+	if !h.ready {
+		return ErrNotLoaded
+	}
+	return writeMetadata(mdDir, &Metadata{
+		Source: h.uri,
+		Sha256: h.loaded,
+		Time:   time.Now(),
+	})
+}
+
+// NewHTTPSource returns a source for tar, tar.gz and zip archives served over
+// http(s). The archive is verified against the HTTPSha256 option if set.
+func NewHTTPSource(uri string, options map[string]string) (Source, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, ErrUnsupported
+	}
+	return &HTTP{
+		uri:      redact(u),
+		url:      uri,
+		checksum: options[HTTPSha256],
+	}, nil
+}
+
+// destPath returns the path of entry name in dest. Entries that would be
+// written outside of dest, or through a symbolic link already in dest, are
+// rejected: a chain of links that each stay in dest can still lead out of it.
+func destPath(dest, name string) (string, error) {
+	p := filepath.Join(dest, name)
+	if !within(dest, p) {
+		return "", fmt.Errorf("Entry %q is outside of %s", name, dest)
+	}
+	rel, err := filepath.Rel(dest, p)
+	if err != nil || rel == "." {
+		return p, err
+	}
+	cur := filepath.Clean(dest)
+	for _, c := range strings.Split(rel, string(os.PathSeparator)) {
+		cur = filepath.Join(cur, c)
+		info, err := os.Lstat(cur)
+		if os.IsNotExist(err) {
+			break
+		} else if err != nil {
+			return "", err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return "", fmt.Errorf("Entry %q is written through the symbolic "+
+				"link %s", name, cur)
+		}
+	}
+	return p, nil
+}
+
+// within returns true if p is dest or a path in dest.
+func within(dest, p string) bool {
+	dest = filepath.Clean(dest)
+	p = filepath.Clean(p)
+	return p == dest || strings.HasPrefix(p, dest+string(os.PathSeparator))
+}
+
+func extractTar(r io.Reader, dest string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		p, err := destPath(dest, hdr.Name)
+		if err != nil {
+			return err
+		}
+		mode := os.FileMode(hdr.Mode).Perm()
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(p, mode)
+		case tar.TypeReg:
+			err = writeFile(p, mode, tr)
+		case tar.TypeSymlink:
+			target := hdr.Linkname
+			if !filepath.IsAbs(target) {
+				target = filepath.Join(filepath.Dir(p), target)
+			}
+			if !within(dest, target) {
+				return fmt.Errorf("Archive link %q points outside of the archive",
+					hdr.Name)
+			}
+			if err = os.MkdirAll(filepath.Dir(p), 0755); err == nil {
+				err = os.Symlink(hdr.Linkname, p)
+			}
+		default:
+			// Devices, hard links and other special files are skipped.
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func extractZip(r io.ReaderAt, size int64, dest string) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return err
+	}
+	for _, zf := range zr.File {
+		p, err := destPath(dest, zf.Name)
+		if err != nil {
+			return err
+		}
+		if zf.FileInfo().IsDir() {
+			if err := os.MkdirAll(p, zf.Mode().Perm()|0700); err != nil {
+				return err
+			}
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return err
+		}
+		err = writeFile(p, zf.Mode().Perm(), rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFile creates the file p with the contents of r.
+func writeFile(p string, mode os.FileMode, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(p, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -1,8 +1,14 @@
 package seed
 
 import (
+	"encoding/json"
 	"errors"
+	"io/ioutil"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 )
 
 // Source defines the interface for keep track of volume driver mounts.
@@ -17,20 +23,74 @@ type Source interface {
 	MetadataWrite(mdDir string) error
 }
 
+// MetadataFile is the file in the metadata dir of a volume that records
+// where the volume was seeded from.
+const MetadataFile = ".seed"
+
 var (
 	// ErrUnsupported is returned for an unsupported seed source.
 	ErrUnsupported = errors.New("Not supported")
+	// ErrNotLoaded is returned when writing the metadata of a source that
+	// was not loaded.
+	ErrNotLoaded = errors.New("Seed source not loaded")
 )
 
+// Metadata is the provenance of the data in a seeded volume.
+type Metadata struct {
+	// Source the volume was seeded from.
+	Source string `json:"source"`
+	// Revision of the source that was loaded, if the source is versioned.
+	Revision string `json:"revision,omitempty"`
+	// Sha256 checksum of the loaded archive, if the source is an archive.
+	Sha256 string `json:"sha256,omitempty"`
+	// Time at which the source was loaded.
+	Time time.Time `json:"time"`
+}
+
 // New returns a new instance of Source
 func New(uri string, options map[string]string) (Source, error) {
 	u, err := url.Parse(uri)
 	if err != nil {
 		return nil, err
 	}
-	switch u.Scheme {
-	case "github":
+	switch {
+	case u.Scheme == "github" || strings.HasPrefix(u.Scheme, gitSchemePrefix):
 		return NewGitSource(uri, options)
+	case u.Scheme == "http" || u.Scheme == "https":
+		return NewHTTPSource(uri, options)
+	case u.Scheme == "file":
+		return NewFileSource(uri, options)
 	}
 	return nil, ErrUnsupported
 }
+
+// redact returns u without its password.
+func redact(u *url.URL) string {
+	if u.User == nil {
+		return u.String()
+	}
+	r := *u
+	r.User = url.User(u.User.Username())
+	return r.String()
+}
+
+// writeMetadata records md in mdDir.
+func writeMetadata(mdDir string, md *Metadata) error {
+	b, err := json.MarshalIndent(md, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(mdDir, 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(mdDir, MetadataFile), b, 0644)
+}
+
+// readMetadata returns the metadata recorded in mdDir.
+func readMetadata(mdDir string) (string, error) {
+	b, err := ioutil.ReadFile(filepath.Join(mdDir, MetadataFile))
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
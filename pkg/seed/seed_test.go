@@ -0,0 +1,211 @@
+package seed
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var archiveFiles = map[string]string{
+	"top":        "top level file",
+	"dir/nested": "nested file",
+}
+
+func tarArchive(t *testing.T, compress bool, extra ...*tar.Header) []byte {
+	var b bytes.Buffer
+	var tw *tar.Writer
+	var gz *gzip.Writer
+	if compress {
+		gz = gzip.NewWriter(&b)
+		tw = tar.NewWriter(gz)
+	} else {
+		tw = tar.NewWriter(&b)
+	}
+	require.NoError(t, tw.WriteHeader(&tar.Header{
+		Name: "dir/", Typeflag: tar.TypeDir, Mode: 0755,
+	}))
+	for name, data := range archiveFiles {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(data)),
+		}))
+		_, err := tw.Write([]byte(data))
+		require.NoError(t, err)
+	}
+	for _, hdr := range extra {
+		require.NoError(t, tw.WriteHeader(hdr))
+	}
+	require.NoError(t, tw.Close())
+	if gz != nil {
+		require.NoError(t, gz.Close())
+	}
+	return b.Bytes()
+}
+
+func zipArchive(t *testing.T) []byte {
+	var b bytes.Buffer
+	zw := zip.NewWriter(&b)
+	for name, data := range archiveFiles {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(data))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return b.Bytes()
+}
+
+func checkFiles(t *testing.T, dir string, msg string) {
+	for name, data := range archiveFiles {
+		b, err := ioutil.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err, msg)
+		assert.Equal(t, data, string(b), msg)
+	}
+}
+
+func TestHTTPSource(t *testing.T) {
+	archives := map[string][]byte{
+		"/seed.tar":    tarArchive(t, false),
+		"/seed.tar.gz": tarArchive(t, true),
+		"/seed.zip":    zipArchive(t),
+		"/escape.tar": tarArchive(t, false, &tar.Header{
+			Name: "../escape", Typeflag: tar.TypeReg, Mode: 0644,
+		}),
+		"/link.tar": tarArchive(t, false, &tar.Header{
+			Name: "link", Typeflag: tar.TypeSymlink, Linkname: "../../etc",
+		}),
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, ok := archives[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(b)
+	}))
+	defer ts.Close()
+
+	load := func(uri string, options map[string]string) (Source, string, error) {
+		dir, err := ioutil.TempDir("", "seed-http")
+		require.NoError(t, err)
+		src, err := New(uri, options)
+		require.NoError(t, err)
+		return src, dir, src.Load(filepath.Join(dir, "data"))
+	}
+
+	for _, name := range []string{"/seed.tar", "/seed.tar.gz", "/seed.zip"} {
+		sum := sha256.Sum256(archives[name])
+		checksum := hex.EncodeToString(sum[:])
+		src, dir, err := load(ts.URL+name, map[string]string{HTTPSha256: checksum})
+		defer os.RemoveAll(dir)
+		require.NoError(t, err, name)
+		checkFiles(t, filepath.Join(dir, "data"), name)
+
+		require.NoError(t, src.MetadataWrite(dir))
+		md, err := src.MetadataRead(dir)
+		require.NoError(t, err)
+		var m Metadata
+		require.NoError(t, json.Unmarshal([]byte(md), &m))
+		assert.Equal(t, ts.URL+name, m.Source)
+		assert.Equal(t, checksum, m.Sha256)
+	}
+
+	_, dir, err := load(ts.URL+"/seed.tar", map[string]string{HTTPSha256: "bad"})
+	defer os.RemoveAll(dir)
+	assert.Equal(t, ErrChecksum, err)
+	_, err = os.Stat(filepath.Join(dir, "data"))
+	assert.True(t, os.IsNotExist(err), "Archive extracted before verification")
+
+	for _, name := range []string{"/escape.tar", "/link.tar", "/missing.tar"} {
+		_, dir, err := load(ts.URL+name, nil)
+		defer os.RemoveAll(dir)
+		assert.Error(t, err, name)
+		_, err = os.Stat(filepath.Join(dir, "escape"))
+		assert.True(t, os.IsNotExist(err), "Archive extracted outside of dest")
+	}
+}
+
+func TestHTTPSourceSymlinkChain(t *testing.T) {
+	// Each link stays in dest, but d/x/y/evil is written to dest/../evil.
+	archive := tarArchive(t, false,
+		&tar.Header{Name: "d/", Typeflag: tar.TypeDir, Mode: 0755},
+		&tar.Header{Name: "d/x", Typeflag: tar.TypeSymlink, Linkname: ".."},
+		&tar.Header{Name: "d/x/y", Typeflag: tar.TypeSymlink, Linkname: ".."},
+		&tar.Header{Name: "d/x/y/evil", Typeflag: tar.TypeReg, Mode: 0644},
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	}))
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "seed-hostile")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	src, err := New(ts.URL+"/hostile.tar", nil)
+	require.NoError(t, err)
+	assert.Error(t, src.Load(filepath.Join(dir, "data")))
+	_, err = os.Lstat(filepath.Join(dir, "data", "y"))
+	assert.True(t, os.IsNotExist(err), "Link written through a link")
+	_, err = os.Lstat(filepath.Join(dir, "evil"))
+	assert.True(t, os.IsNotExist(err), "Archive extracted outside of dest")
+}
+
+func TestFileSource(t *testing.T) {
+	root, err := ioutil.TempDir("", "seed-root")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+	defer func(roots []string) { FileRoots = roots }(FileRoots)
+	FileRoots = []string{root}
+	src := filepath.Join(root, "volume")
+	for name, data := range archiveFiles {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(src, name)), 0755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(src, name), []byte(data), 0600))
+	}
+	require.NoError(t, os.Symlink("top", filepath.Join(src, "link")))
+
+	_, err = New("file://remote"+src, nil)
+	assert.Equal(t, ErrUnsupported, err)
+	_, err = New("file:///etc", nil)
+	assert.Error(t, err, "Only volume directories can be copied")
+	_, err = New("file://"+root, nil)
+	assert.Error(t, err, "The volume base cannot be copied")
+	require.NoError(t, os.Symlink("/etc", filepath.Join(root, "escape")))
+	escape, err := New("file://"+filepath.Join(root, "escape"), nil)
+	require.NoError(t, err)
+	assert.Error(t, escape.Load(filepath.Join(root, "copy")),
+		"Links out of the volume base should not be followed")
+
+	copier, err := New("file://"+src, nil)
+	require.NoError(t, err)
+	assert.Error(t, copier.Load(filepath.Join(src, "dir", "copy")))
+
+	dir, err := ioutil.TempDir("", "seed-dest")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	dest := filepath.Join(dir, "data")
+	require.NoError(t, copier.Load(dest))
+	checkFiles(t, dest, "file")
+	info, err := os.Stat(filepath.Join(dest, "top"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	link, err := os.Readlink(filepath.Join(dest, "link"))
+	require.NoError(t, err)
+	assert.Equal(t, "top", link)
+
+	require.NoError(t, copier.MetadataWrite(dir))
+	md, err := copier.MetadataRead(dir)
+	require.NoError(t, err)
+	assert.Contains(t, md, "file://"+src)
+}
@@ -2,9 +2,14 @@ package aws
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/opsworks"
 	"github.com/libopenstorage/openstorage/pkg/storageops"
@@ -89,3 +94,43 @@ func TestAwsGetPrefixFromRootDeviceName(t *testing.T) {
 		assert.Equal(t, test.expectedPrefix, prefix)
 	}
 }
+
+func TestModify(t *testing.T) {
+	states := []string{modificationStateModifying, modificationStateOptimizing}
+	var modified bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, modifyVolumeAPIVersion, r.Form.Get("Version"))
+		switch r.Form.Get("Action") {
+		case opModifyVolume:
+			assert.Equal(t, "vol-1", r.Form.Get("VolumeId"))
+			assert.Equal(t, "20", r.Form.Get("Size"))
+			assert.Equal(t, "io1", r.Form.Get("VolumeType"))
+			assert.Equal(t, "1000", r.Form.Get("Iops"))
+			modified = true
+			fmt.Fprint(w, `<ModifyVolumeResponse><volumeModification>`+
+				`<volumeId>vol-1</volumeId><modificationState>modifying</modificationState>`+
+				`</volumeModification></ModifyVolumeResponse>`)
+		case opDescribeVolumesModifications:
+			assert.Equal(t, "vol-1", r.Form.Get("VolumeId.1"))
+			fmt.Fprintf(w, `<DescribeVolumesModificationsResponse><volumeModificationSet>`+
+				`<item><volumeId>vol-1</volumeId><modificationState>%s</modificationState></item>`+
+				`</volumeModificationSet></DescribeVolumesModificationsResponse>`, states[0])
+			states = states[1:]
+		default:
+			t.Errorf("unexpected action %v", r.Form.Get("Action"))
+		}
+	}))
+	defer server.Close()
+
+	client := ec2.New(session.New(&aws.Config{
+		Region:      aws.String("us-east-1"),
+		Endpoint:    aws.String(server.URL),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+		MaxRetries:  aws.Int(0),
+	}))
+	d := NewEc2Storage("i-1", client).(Modifier)
+	assert.NoError(t, d.Modify("vol-1", 20, opsworks.VolumeTypeIo1, 1000))
+	assert.True(t, modified, "volume was not modified")
+	assert.Empty(t, states, "modification was not waited for")
+}
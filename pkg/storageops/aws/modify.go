@@ -0,0 +1,141 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/libopenstorage/openstorage/pkg/storageops"
+	"github.com/portworx/sched-ops/task"
+)
+
+// Modifier changes the size, type and provisioned IOPS of a volume in
+// place. Zero values leave the attribute unchanged.
+type Modifier interface {
+	// Modify volumeID to size GiB, volumeType and iops. It returns once
+	// the new size is available to the instance.
+	Modify(volumeID string, size int64, volumeType string, iops int64) error
+}
+
+// The vendored EC2 client predates elastic volumes, so ModifyVolume and
+// DescribeVolumesModifications are sent through the generic client with the
+// API version that introduced them.
+const (
+	opModifyVolume                 = "ModifyVolume"
+	opDescribeVolumesModifications = "DescribeVolumesModifications"
+	modifyVolumeAPIVersion         = "2016-11-15"
+)
+
+// Volume modification states.
+const (
+	modificationStateModifying  = "modifying"
+	modificationStateOptimizing = "optimizing"
+	modificationStateCompleted  = "completed"
+	modificationStateFailed     = "failed"
+)
+
+type modifyVolumeInput struct {
+	_ struct{} `type:"structure"`
+
+	VolumeId   *string `type:"string" required:"true"`
+	Size       *int64  `type:"integer"`
+	VolumeType *string `type:"string"`
+	Iops       *int64  `type:"integer"`
+}
+
+type modifyVolumeOutput struct {
+	_ struct{} `type:"structure"`
+
+	VolumeModification *volumeModification `locationName:"volumeModification" type:"structure"`
+}
+
+type describeVolumesModificationsInput struct {
+	_ struct{} `type:"structure"`
+
+	VolumeIds []*string `locationName:"VolumeId" locationNameList:"VolumeId" type:"list"`
+}
+
+type describeVolumesModificationsOutput struct {
+	_ struct{} `type:"structure"`
+
+	VolumesModifications []*volumeModification `locationName:"volumeModificationSet" locationNameList:"item" type:"list"`
+}
+
+type volumeModification struct {
+	_ struct{} `type:"structure"`
+
+	VolumeId          *string `locationName:"volumeId" type:"string"`
+	ModificationState *string `locationName:"modificationState" type:"string"`
+	StatusMessage     *string `locationName:"statusMessage" type:"string"`
+	TargetSize        *int64  `locationName:"targetSize" type:"integer"`
+	TargetVolumeType  *string `locationName:"targetVolumeType" type:"string"`
+	TargetIops        *int64  `locationName:"targetIops" type:"integer"`
+}
+
+func (s *ec2Ops) send(name string, input, output interface{}) error {
+	req := s.ec2.NewRequest(&request.Operation{
+		Name:       name,
+		HTTPMethod: "POST",
+		HTTPPath:   "/",
+	}, input, output)
+	req.ClientInfo.APIVersion = modifyVolumeAPIVersion
+	return req.Send()
+}
+
+func (s *ec2Ops) Modify(
+	volumeID string,
+	size int64,
+	volumeType string,
+	iops int64,
+) error {
+	input := &modifyVolumeInput{VolumeId: &volumeID}
+	if size > 0 {
+		input.Size = &size
+	}
+	if volumeType != "" {
+		input.VolumeType = &volumeType
+	}
+	if iops > 0 {
+		input.Iops = &iops
+	}
+	if err := s.send(opModifyVolume, input, &modifyVolumeOutput{}); err != nil {
+		return err
+	}
+	return s.waitModification(volumeID)
+}
+
+// waitModification waits until the modification of volumeID is optimizing,
+// at which point the new size can be used.
+func (s *ec2Ops) waitModification(volumeID string) error {
+	input := &describeVolumesModificationsInput{VolumeIds: []*string{&volumeID}}
+	_, err := task.DoRetryWithTimeout(
+		func() (interface{}, bool, error) {
+			output := &describeVolumesModificationsOutput{}
+			if err := s.send(opDescribeVolumesModifications, input, output); err != nil {
+				return nil, true, err
+			}
+			if len(output.VolumesModifications) != 1 ||
+				output.VolumesModifications[0].ModificationState == nil {
+				return nil, true, fmt.Errorf("no modification found for volume %v",
+					volumeID)
+			}
+
+			m := output.VolumesModifications[0]
+			switch *m.ModificationState {
+			case modificationStateOptimizing, modificationStateCompleted:
+				return nil, false, nil
+			case modificationStateFailed:
+				msg := ""
+				if m.StatusMessage != nil {
+					msg = *m.StatusMessage
+				}
+				return nil, false, storageops.NewStorageError(storageops.ErrVolInval,
+					fmt.Sprintf("modification of volume %v failed: %v", volumeID, msg),
+					s.instance)
+			}
+			return nil, true, fmt.Errorf("volume %v is still %v", volumeID,
+				*m.ModificationState)
+		},
+		storageops.ProviderOpsTimeout,
+		storageops.ProviderOpsRetryInterval)
+	return err
+}
@@ -0,0 +1,763 @@
+package azure
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/libopenstorage/openstorage/pkg/storageops"
+	"github.com/portworx/sched-ops/task"
+)
+
+const (
+	// apiVersion of the Azure compute API.
+	apiVersion = "2018-06-01"
+	// maxLuns is the number of LUNs of the data disk controller.
+	maxLuns = 64
+	// requestTimeout is the timeout of a single Azure API request.
+	requestTimeout = time.Minute
+)
+
+// Disk creation options.
+const (
+	// CreateOptionEmpty creates an empty disk.
+	CreateOptionEmpty = "Empty"
+	// CreateOptionCopy creates a disk or snapshot from another disk or
+	// snapshot.
+	CreateOptionCopy = "Copy"
+	// CreateOptionAttach attaches an existing disk to a virtual machine.
+	CreateOptionAttach = "Attach"
+)
+
+// Disk SKUs.
+const (
+	// SkuStandardLRS is a standard HDD disk.
+	SkuStandardLRS = "Standard_LRS"
+	// SkuStandardSSDLRS is a standard SSD disk.
+	SkuStandardSSDLRS = "StandardSSD_LRS"
+	// SkuPremiumLRS is a premium SSD disk.
+	SkuPremiumLRS = "Premium_LRS"
+)
+
+// Provisioning states of disks and snapshots.
+const (
+	// ProvisioningSucceeded is the state of a provisioned resource.
+	ProvisioningSucceeded = "Succeeded"
+	// ProvisioningFailed is the state of a resource that failed to
+	// provision.
+	ProvisioningFailed = "Failed"
+)
+
+// Disk is an Azure managed disk.
+type Disk struct {
+	ID         string            `json:"id,omitempty"`
+	Name       string            `json:"name,omitempty"`
+	Location   string            `json:"location,omitempty"`
+	ManagedBy  string            `json:"managedBy,omitempty"`
+	Tags       map[string]string `json:"tags,omitempty"`
+	Sku        *Sku              `json:"sku,omitempty"`
+	Properties *DiskProperties   `json:"properties,omitempty"`
+}
+
+// Snapshot is a snapshot of an Azure managed disk.
+type Snapshot struct {
+	ID         string            `json:"id,omitempty"`
+	Name       string            `json:"name,omitempty"`
+	Location   string            `json:"location,omitempty"`
+	Tags       map[string]string `json:"tags,omitempty"`
+	Sku        *Sku              `json:"sku,omitempty"`
+	Properties *DiskProperties   `json:"properties,omitempty"`
+}
+
+// Sku is the performance tier of a disk or snapshot.
+type Sku struct {
+	Name string `json:"name,omitempty"`
+}
+
+// DiskProperties are the properties of a disk or snapshot.
+type DiskProperties struct {
+	CreationData      *CreationData `json:"creationData,omitempty"`
+	DiskSizeGB        int32         `json:"diskSizeGB,omitempty"`
+	ProvisioningState string        `json:"provisioningState,omitempty"`
+	TimeCreated       string        `json:"timeCreated,omitempty"`
+}
+
+// CreationData describes the source of a disk or snapshot.
+type CreationData struct {
+	CreateOption string `json:"createOption"`
+	// SourceResourceID is the resource ID of the disk or snapshot to copy.
+	// A plain name is the name of a snapshot in the resource group.
+	SourceResourceID string `json:"sourceResourceId,omitempty"`
+}
+
+// VirtualMachine is an Azure virtual machine.
+type VirtualMachine struct {
+	ID         string                    `json:"id,omitempty"`
+	Name       string                    `json:"name,omitempty"`
+	Location   string                    `json:"location,omitempty"`
+	Properties *VirtualMachineProperties `json:"properties,omitempty"`
+}
+
+// VirtualMachineProperties are the properties of a virtual machine.
+type VirtualMachineProperties struct {
+	StorageProfile *StorageProfile `json:"storageProfile,omitempty"`
+}
+
+// StorageProfile lists the data disks of a virtual machine.
+type StorageProfile struct {
+	DataDisks []DataDisk `json:"dataDisks"`
+}
+
+// DataDisk is a disk attached to a virtual machine on a LUN.
+type DataDisk struct {
+	Lun          int32        `json:"lun"`
+	Name         string       `json:"name,omitempty"`
+	CreateOption string       `json:"createOption"`
+	ManagedDisk  *ManagedDisk `json:"managedDisk,omitempty"`
+}
+
+// ManagedDisk references a managed disk from a data disk.
+type ManagedDisk struct {
+	ID string `json:"id,omitempty"`
+}
+
+type diskList struct {
+	Value    []*Disk `json:"value"`
+	NextLink string  `json:"nextLink,omitempty"`
+}
+
+// apiError is an error returned by the Azure API.
+type apiError struct {
+	StatusCode int
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("azure API error %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+func isNotFound(err error) bool {
+	e, ok := err.(*apiError)
+	return ok && e.StatusCode == http.StatusNotFound
+}
+
+type azureOps struct {
+	cfg    Config
+	client *http.Client
+	token  *tokenSource
+	mutex  sync.Mutex
+}
+
+// IsDevMode checks if the pkg is invoked in developer mode where the Azure
+// instance details are set as env variables
+func IsDevMode() bool {
+	_, err := ConfigFromEnv()
+	return err == nil
+}
+
+// NewClient creates a new Azure operations client for the instance it runs
+// on, or for the instance set in the environment in developer mode.
+func NewClient() (storageops.Ops, error) {
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		cfg = Config{}
+	}
+	return NewClientFromConfig(cfg)
+}
+
+// NewClientFromConfig creates a new Azure operations client. Instance details
+// missing from cfg are fetched from the instance metadata service.
+func NewClientFromConfig(cfg Config) (storageops.Ops, error) {
+	cfg.setDefaults()
+	client := &http.Client{Timeout: requestTimeout}
+	if !cfg.hasInstance() {
+		if err := cfg.fromMetadata(client); err != nil {
+			return nil, fmt.Errorf("instance is not running on Azure: %v", err)
+		}
+	}
+	return &azureOps{
+		cfg:    cfg,
+		client: client,
+		token:  newTokenSource(&cfg, client),
+	}, nil
+}
+
+func (s *azureOps) Name() string { return "azure" }
+
+func (s *azureOps) ApplyTags(diskName string, labels map[string]string) error {
+	d, err := s.getDisk(diskName)
+	if err != nil {
+		return err
+	}
+	tags := make(map[string]string)
+	for k, v := range d.Tags {
+		tags[k] = v
+	}
+	for k, v := range labels {
+		tags[k] = v
+	}
+	return s.setTags(diskName, tags)
+}
+
+func (s *azureOps) Attach(diskName string) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	d, err := s.getDisk(diskName)
+	if err != nil {
+		return "", err
+	}
+	if d.ManagedBy != "" {
+		if s.managedHere(d) {
+			return s.DevicePath(diskName)
+		}
+		return "", fmt.Errorf("disk %s is already in use by %s", diskName, d.ManagedBy)
+	}
+
+	vm, err := s.describeinstance()
+	if err != nil {
+		return "", err
+	}
+	disks := dataDisks(vm)
+	lun, err := freeLun(disks)
+	if err != nil {
+		return "", err
+	}
+	disks = append(disks, DataDisk{
+		Lun:          lun,
+		Name:         d.Name,
+		CreateOption: CreateOptionAttach,
+		ManagedDisk:  &ManagedDisk{ID: d.ID},
+	})
+	if err := s.updateDataDisks(disks); err != nil {
+		return "", err
+	}
+
+	return s.waitForAttach(diskName)
+}
+
+func (s *azureOps) Create(
+	template interface{},
+	labels map[string]string,
+) (interface{}, error) {
+	v, ok := template.(*Disk)
+	if !ok {
+		return nil, storageops.NewStorageError(storageops.ErrVolInval,
+			"Invalid volume template given", "")
+	}
+
+	newDisk := &Disk{
+		Location: v.Location,
+		Tags:     labels,
+		Sku:      v.Sku,
+		Properties: &DiskProperties{
+			CreationData: &CreationData{CreateOption: CreateOptionEmpty},
+		},
+	}
+	if newDisk.Location == "" {
+		newDisk.Location = s.cfg.Location
+	}
+	if v.Properties != nil {
+		newDisk.Properties.DiskSizeGB = v.Properties.DiskSizeGB
+		if v.Properties.CreationData != nil {
+			creationData := *v.Properties.CreationData
+			if creationData.SourceResourceID != "" &&
+				!strings.Contains(creationData.SourceResourceID, "/") {
+				creationData.SourceResourceID = s.cfg.SnapshotID(creationData.SourceResourceID)
+			}
+			newDisk.Properties.CreationData = &creationData
+		}
+	}
+
+	if err := s.do("PUT", s.cfg.DiskID(v.Name), newDisk, nil); err != nil {
+		return nil, err
+	}
+
+	d, err := s.waitForDisk(v.Name)
+	if err != nil {
+		return nil, s.rollbackCreate(v.Name, err)
+	}
+
+	return d, nil
+}
+
+func (s *azureOps) Delete(diskName string) error {
+	if err := s.do("DELETE", s.cfg.DiskID(diskName), nil, nil); err != nil {
+		return err
+	}
+	return s.waitForDelete(s.cfg.DiskID(diskName))
+}
+
+func (s *azureOps) Detach(diskName string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	vm, err := s.describeinstance()
+	if err != nil {
+		return err
+	}
+	disks := dataDisks(vm)
+	for i, d := range disks {
+		if strings.EqualFold(d.Name, diskName) {
+			disks = append(disks[:i], disks[i+1:]...)
+			if err := s.updateDataDisks(disks); err != nil {
+				return err
+			}
+			return s.waitForDetach(diskName)
+		}
+	}
+	return storageops.NewStorageError(storageops.ErrVolDetached,
+		fmt.Sprintf("Disk: %s is detached", diskName), s.cfg.InstanceName)
+}
+
+func (s *azureOps) DeviceMappings() (map[string]string, error) {
+	vm, err := s.describeinstance()
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]string)
+	for _, d := range dataDisks(vm) {
+		devicePath, err := s.lunDevicePath(d.Lun)
+		if err != nil {
+			logrus.Warnf("no device for disk %s on LUN %d: %v", d.Name, d.Lun, err)
+			continue
+		}
+		m[devicePath] = d.Name
+	}
+
+	return m, nil
+}
+
+func (s *azureOps) DevicePath(diskName string) (string, error) {
+	d, err := s.getDisk(diskName)
+	if err != nil {
+		return "", err
+	}
+
+	if d.ManagedBy == "" {
+		return "", storageops.NewStorageError(storageops.ErrVolDetached,
+			fmt.Sprintf("Disk: %s is detached", d.Name), s.cfg.InstanceName)
+	}
+	if !s.managedHere(d) {
+		return "", storageops.NewStorageError(
+			storageops.ErrVolAttachedOnRemoteNode,
+			fmt.Sprintf("disk %s is not attached on: %s (Attached on: %v)",
+				d.Name, s.cfg.InstanceName, d.ManagedBy),
+			s.cfg.InstanceName)
+	}
+
+	vm, err := s.describeinstance()
+	if err != nil {
+		return "", err
+	}
+	for _, dataDisk := range dataDisks(vm) {
+		if strings.EqualFold(dataDisk.Name, d.Name) {
+			return s.lunDevicePath(dataDisk.Lun)
+		}
+	}
+
+	return "", fmt.Errorf("disk %s is not a data disk of %s", d.Name, s.cfg.InstanceName)
+}
+
+func (s *azureOps) Enumerate(
+	volumeIds []*string,
+	labels map[string]string,
+	setIdentifier string,
+) (map[string][]interface{}, error) {
+	sets := make(map[string][]interface{})
+	ids := make(map[string]bool)
+	for _, id := range volumeIds {
+		ids[strings.ToLower(*id)] = true
+	}
+
+	next := s.cfg.resourceGroupID() + "/providers/Microsoft.Compute/disks"
+	for next != "" {
+		var page diskList
+		if err := s.do("GET", next, nil, &page); err != nil {
+			logrus.Errorf("failed to list disks: %v", err)
+			return nil, err
+		}
+		for _, disk := range page.Value {
+			if len(ids) != 0 && !ids[strings.ToLower(disk.Name)] {
+				continue
+			}
+			if !matchTags(disk.Tags, labels) {
+				continue
+			}
+			if _, ok := disk.Tags[setIdentifier]; ok && len(setIdentifier) != 0 {
+				storageops.AddElementToMap(sets, disk, setIdentifier)
+			} else {
+				storageops.AddElementToMap(sets, disk, storageops.SetIdentifierNone)
+			}
+		}
+		next = page.NextLink
+	}
+
+	return sets, nil
+}
+
+func (s *azureOps) FreeDevices(
+	blockDeviceMappings []interface{},
+	rootDeviceName string,
+) ([]string, error) {
+	return nil, fmt.Errorf("function not implemented")
+}
+
+func (s *azureOps) GetDeviceID(disk interface{}) (string, error) {
+	if d, ok := disk.(*Disk); ok {
+		return d.Name, nil
+	} else if d, ok := disk.(*Snapshot); ok {
+		return d.Name, nil
+	} else {
+		return "", fmt.Errorf("invalid type: %v given to GetDeviceID", disk)
+	}
+}
+
+func (s *azureOps) Inspect(diskNames []*string) ([]interface{}, error) {
+	var disks []interface{}
+
+	for _, id := range diskNames {
+		d, err := s.getDisk(*id)
+		if err != nil {
+			return nil, err
+		}
+
+		disks = append(disks, d)
+	}
+
+	return disks, nil
+}
+
+func (s *azureOps) RemoveTags(diskName string, labels map[string]string) error {
+	d, err := s.getDisk(diskName)
+	if err != nil {
+		return err
+	}
+	if len(d.Tags) == 0 {
+		return nil
+	}
+	for k := range labels {
+		delete(d.Tags, k)
+	}
+	return s.setTags(diskName, d.Tags)
+}
+
+func (s *azureOps) Snapshot(diskName string, readonly bool) (interface{}, error) {
+	d, err := s.getDisk(diskName)
+	if err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("%s-snap-%d", d.Name, time.Now().UnixNano())
+	snap := &Snapshot{
+		Location: d.Location,
+		Properties: &DiskProperties{
+			CreationData: &CreationData{
+				CreateOption:     CreateOptionCopy,
+				SourceResourceID: d.ID,
+			},
+		},
+	}
+	if err := s.do("PUT", s.cfg.SnapshotID(name), snap, nil); err != nil {
+		return nil, err
+	}
+
+	return s.waitForSnapshot(name)
+}
+
+func (s *azureOps) SnapshotDelete(snapID string) error {
+	if err := s.do("DELETE", s.cfg.SnapshotID(snapID), nil, nil); err != nil {
+		return err
+	}
+	return s.waitForDelete(s.cfg.SnapshotID(snapID))
+}
+
+func (s *azureOps) Tags(diskName string) (map[string]string, error) {
+	d, err := s.getDisk(diskName)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.Tags, nil
+}
+
+// Describe current instance.
+func (s *azureOps) Describe() (interface{}, error) {
+	return s.describeinstance()
+}
+
+func (s *azureOps) describeinstance() (*VirtualMachine, error) {
+	vm := &VirtualMachine{}
+	if err := s.do("GET", s.cfg.instanceID(), nil, vm); err != nil {
+		return nil, err
+	}
+	return vm, nil
+}
+
+func (s *azureOps) getDisk(diskName string) (*Disk, error) {
+	d := &Disk{}
+	if err := s.do("GET", s.cfg.DiskID(diskName), nil, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (s *azureOps) getSnapshot(snapName string) (*Snapshot, error) {
+	snap := &Snapshot{}
+	if err := s.do("GET", s.cfg.SnapshotID(snapName), nil, snap); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+func (s *azureOps) setTags(diskName string, tags map[string]string) error {
+	return s.do("PATCH", s.cfg.DiskID(diskName), map[string]interface{}{
+		"tags": tags,
+	}, nil)
+}
+
+func (s *azureOps) updateDataDisks(disks []DataDisk) error {
+	return s.do("PATCH", s.cfg.instanceID(), &VirtualMachine{
+		Properties: &VirtualMachineProperties{
+			StorageProfile: &StorageProfile{DataDisks: disks},
+		},
+	}, nil)
+}
+
+// managedHere returns true if d is attached to the local instance.
+func (s *azureOps) managedHere(d *Disk) bool {
+	return strings.EqualFold(d.ManagedBy, s.cfg.instanceID())
+}
+
+// lunDevicePath returns the device of the data disk on lun. The udev rules
+// of the Azure Linux agent link the data disks by LUN in the device dir.
+func (s *azureOps) lunDevicePath(lun int32) (string, error) {
+	devicePath := path.Join(s.cfg.DeviceDir, fmt.Sprintf("lun%d", lun))
+	if _, err := os.Stat(devicePath); err != nil {
+		return "", fmt.Errorf("no device found for LUN %d: %v", lun, err)
+	}
+	return devicePath, nil
+}
+
+func dataDisks(vm *VirtualMachine) []DataDisk {
+	if vm.Properties == nil || vm.Properties.StorageProfile == nil {
+		return nil
+	}
+	return vm.Properties.StorageProfile.DataDisks
+}
+
+// freeLun returns the lowest LUN without a data disk.
+func freeLun(disks []DataDisk) (int32, error) {
+	used := make(map[int32]bool)
+	for _, d := range disks {
+		used[d.Lun] = true
+	}
+	for lun := int32(0); lun < maxLuns; lun++ {
+		if !used[lun] {
+			return lun, nil
+		}
+	}
+	return 0, fmt.Errorf("no free LUN for a new data disk")
+}
+
+func matchTags(tags, labels map[string]string) bool {
+	for k, v := range labels {
+		if tags[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// do sends a request to the Azure resource manager. resource is the ID of
+// the resource, or the URL of the next page of a list. in is sent as the
+// JSON body and the response is decoded into out, if not nil.
+func (s *azureOps) do(method, resource string, in, out interface{}) error {
+	u := resource
+	if !strings.HasPrefix(resource, "http") {
+		u = strings.TrimSuffix(s.cfg.ResourceManagerEndpoint, "/") + resource +
+			"?api-version=" + apiVersion
+	}
+
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	token, err := s.token.get()
+	if err != nil {
+		return fmt.Errorf("failed to authenticate with azure api. Err: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		e := struct {
+			Error *apiError `json:"error"`
+		}{}
+		if json.Unmarshal(data, &e) != nil || e.Error == nil {
+			e.Error = &apiError{Message: strings.TrimSpace(string(data))}
+		}
+		e.Error.StatusCode = resp.StatusCode
+		return e.Error
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+func (s *azureOps) waitForDisk(diskName string) (*Disk, error) {
+	d, err := task.DoRetryWithTimeout(
+		func() (interface{}, bool, error) {
+			d, err := s.getDisk(diskName)
+			if err != nil {
+				return nil, true, err
+			}
+			retry, err := provisioning(diskName, d.Properties)
+			return d, retry, err
+		},
+		storageops.ProviderOpsTimeout,
+		storageops.ProviderOpsRetryInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.(*Disk), nil
+}
+
+func (s *azureOps) waitForSnapshot(snapName string) (*Snapshot, error) {
+	snap, err := task.DoRetryWithTimeout(
+		func() (interface{}, bool, error) {
+			snap, err := s.getSnapshot(snapName)
+			if err != nil {
+				return nil, true, err
+			}
+			retry, err := provisioning(snapName, snap.Properties)
+			return snap, retry, err
+		},
+		storageops.ProviderOpsTimeout,
+		storageops.ProviderOpsRetryInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	return snap.(*Snapshot), nil
+}
+
+// provisioning returns whether to retry and the error for a resource that
+// is not provisioned yet.
+func provisioning(name string, p *DiskProperties) (bool, error) {
+	if p == nil {
+		return true, fmt.Errorf("nil provisioning state for %v", name)
+	}
+	switch p.ProvisioningState {
+	case ProvisioningSucceeded:
+		return false, nil
+	case ProvisioningFailed:
+		return false, fmt.Errorf("failed to provision %v", name)
+	}
+	return true, fmt.Errorf("invalid provisioning state: %s for %s. expected: %s",
+		p.ProvisioningState, name, ProvisioningSucceeded)
+}
+
+func (s *azureOps) waitForDelete(resource string) error {
+	_, err := task.DoRetryWithTimeout(
+		func() (interface{}, bool, error) {
+			err := s.do("GET", resource, nil, nil)
+			if isNotFound(err) {
+				return nil, false, nil
+			}
+			if err != nil {
+				return nil, true, err
+			}
+			return nil, true, fmt.Errorf("%s is still being deleted", path.Base(resource))
+		},
+		storageops.ProviderOpsTimeout,
+		storageops.ProviderOpsRetryInterval)
+
+	return err
+}
+
+// waitForAttach checks if given disk is attached to the local instance
+func (s *azureOps) waitForAttach(diskName string) (string, error) {
+	devicePath, err := task.DoRetryWithTimeout(
+		func() (interface{}, bool, error) {
+			devicePath, err := s.DevicePath(diskName)
+			if err != nil {
+				return "", true, err
+			}
+
+			return devicePath, false, nil
+		},
+		storageops.ProviderOpsTimeout,
+		storageops.ProviderOpsRetryInterval)
+	if err != nil {
+		return "", err
+	}
+
+	return devicePath.(string), nil
+}
+
+// waitForDetach checks if given disk is detached from the local instance
+func (s *azureOps) waitForDetach(diskName string) error {
+	_, err := task.DoRetryWithTimeout(
+		func() (interface{}, bool, error) {
+			d, err := s.getDisk(diskName)
+			if err != nil {
+				return nil, true, err
+			}
+			if d.ManagedBy != "" {
+				return nil, true,
+					fmt.Errorf("disk: %s is still attached to instance: %s",
+						diskName, d.ManagedBy)
+			}
+
+			return nil, false, nil
+		},
+		storageops.ProviderOpsTimeout,
+		storageops.ProviderOpsRetryInterval)
+
+	return err
+}
+
+func (s *azureOps) rollbackCreate(id string, createErr error) error {
+	logrus.Warnf("Rollback create volume %v, Error %v", id, createErr)
+	err := s.Delete(id)
+	if err != nil {
+		logrus.Warnf("Rollback failed volume %v, Error %v", id, err)
+	}
+	return createErr
+}
+
+// escape returns name escaped for a resource ID.
+func escape(name string) string {
+	return url.PathEscape(name)
+}
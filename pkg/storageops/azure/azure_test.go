@@ -0,0 +1,146 @@
+package azure_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/libopenstorage/openstorage/pkg/storageops"
+	"github.com/libopenstorage/openstorage/pkg/storageops/azure"
+	"github.com/libopenstorage/openstorage/pkg/storageops/azure/azuretest"
+	"github.com/libopenstorage/openstorage/pkg/storageops/test"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	newDiskSizeInGB = 10
+	newDiskPrefix   = "openstorage-test"
+)
+
+var diskName = fmt.Sprintf("%s-%s", newDiskPrefix, uuid.NewV4())
+
+func newServer(t *testing.T) (*azuretest.Server, func()) {
+	dir, err := ioutil.TempDir("", "azure-devices")
+	require.NoError(t, err)
+	s := azuretest.NewServer(dir)
+	return s, func() {
+		s.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func template(name string) *azure.Disk {
+	return &azure.Disk{
+		Name:       name,
+		Sku:        &azure.Sku{Name: azure.SkuStandardLRS},
+		Properties: &azure.DiskProperties{DiskSizeGB: newDiskSizeInGB},
+	}
+}
+
+func TestAll(t *testing.T) {
+	drivers := make(map[string]storageops.Ops)
+	diskTemplates := make(map[string]map[string]interface{})
+
+	if azure.IsDevMode() {
+		d, err := azure.NewClient()
+		require.NoError(t, err, "failed to instantiate storage ops driver")
+		drivers[d.Name()] = d
+		diskTemplates[d.Name()] = map[string]interface{}{
+			diskName: template(diskName),
+		}
+	} else {
+		s, cleanup := newServer(t)
+		defer cleanup()
+		d, err := azure.NewClientFromConfig(s.Config())
+		require.NoError(t, err, "failed to instantiate storage ops driver")
+		drivers[d.Name()] = d
+		diskTemplates[d.Name()] = map[string]interface{}{
+			diskName: template(diskName),
+		}
+	}
+
+	test.RunTest(drivers, diskTemplates, t)
+}
+
+func TestStandIn(t *testing.T) {
+	s, cleanup := newServer(t)
+	defer cleanup()
+
+	// Instance details and tokens come from the instance metadata service
+	// without a service principal.
+	cfg := azure.Config{
+		ResourceManagerEndpoint: s.URL + "/",
+		MetadataEndpoint:        s.URL + "/metadata/",
+		DeviceDir:               s.DeviceDir,
+	}
+	d, err := azure.NewClientFromConfig(cfg)
+	require.NoError(t, err)
+
+	names := []string{"disk-a", "disk-b", "disk-c"}
+	for _, name := range names {
+		_, err := d.Create(template(name), map[string]string{"set": "test"})
+		require.NoError(t, err)
+	}
+	sets, err := d.Enumerate(nil, map[string]string{"set": "test"}, "set")
+	require.NoError(t, err)
+	require.Len(t, sets["set"], len(names), "disks missing from paged list")
+
+	// Disks are attached on the lowest free LUN.
+	for i, name := range names[:2] {
+		devicePath, err := d.Attach(name)
+		require.NoError(t, err)
+		require.Equal(t, path.Join(s.DeviceDir, fmt.Sprintf("lun%d", i)), devicePath)
+	}
+	require.NoError(t, d.Detach(names[0]))
+	devicePath, err := d.Attach(names[2])
+	require.NoError(t, err)
+	require.Equal(t, path.Join(s.DeviceDir, "lun0"), devicePath)
+	mappings, err := d.DeviceMappings()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		path.Join(s.DeviceDir, "lun0"): names[2],
+		path.Join(s.DeviceDir, "lun1"): names[1],
+	}, mappings)
+
+	_, err = d.DevicePath(names[0])
+	require.IsType(t, &storageops.StorageError{}, err)
+	require.Equal(t, storageops.ErrVolDetached, err.(*storageops.StorageError).Code)
+	require.Error(t, d.Detach(names[0]))
+
+	require.NoError(t, s.AttachRemote(names[0], "other-vm"))
+	_, err = d.DevicePath(names[0])
+	require.IsType(t, &storageops.StorageError{}, err)
+	require.Equal(t, storageops.ErrVolAttachedOnRemoteNode,
+		err.(*storageops.StorageError).Code)
+	_, err = d.Attach(names[0])
+	require.Error(t, err)
+
+	// Disks can be created from snapshots by name.
+	snap, err := d.Snapshot(names[1], true)
+	require.NoError(t, err)
+	snapName, err := d.GetDeviceID(snap)
+	require.NoError(t, err)
+	restored := &azure.Disk{
+		Name: "disk-restored",
+		Properties: &azure.DiskProperties{
+			CreationData: &azure.CreationData{
+				CreateOption:     azure.CreateOptionCopy,
+				SourceResourceID: snapName,
+			},
+		},
+	}
+	disk, err := d.Create(restored, nil)
+	require.NoError(t, err)
+	require.Equal(t, int32(newDiskSizeInGB), disk.(*azure.Disk).Properties.DiskSizeGB)
+	require.NoError(t, d.SnapshotDelete(snapName))
+
+	// Attached disks cannot be deleted.
+	require.Error(t, d.Delete(names[1]))
+	require.NoError(t, d.Detach(names[1]))
+	require.NoError(t, d.Delete(names[1]))
+	_, err = d.Inspect([]*string{&names[1]})
+	require.Error(t, err)
+}
@@ -0,0 +1,394 @@
+// Package azuretest provides a local stand-in for the Azure compute API to
+// test the Azure storage operations without an Azure subscription.
+package azuretest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/libopenstorage/openstorage/pkg/storageops/azure"
+)
+
+const (
+	subscriptionID = "00000000-0000-0000-0000-000000000000"
+	resourceGroup  = "openstorage-test"
+	instanceName   = "openstorage-vm"
+	location       = "westus"
+	tenantID       = "openstorage-tenant"
+	clientID       = "openstorage-client"
+	clientSecret   = "openstorage-secret"
+	accessToken    = "openstorage-token"
+	// pageSize is the number of disks in a page of a disk list.
+	pageSize = 2
+)
+
+// Server is a stand-in for the Azure resource manager, Active Directory
+// and instance metadata service of a single virtual machine. Disks attached
+// to the virtual machine get a sparse file of their size on their LUN in
+// DeviceDir.
+type Server struct {
+	*httptest.Server
+	// DeviceDir is the directory of the data disk devices by LUN.
+	DeviceDir string
+
+	mutex     sync.Mutex
+	disks     map[string]*azure.Disk
+	snapshots map[string]*azure.Snapshot
+	dataDisks []azure.DataDisk
+}
+
+// NewServer starts a server that links attached disks in deviceDir.
+func NewServer(deviceDir string) *Server {
+	s := &Server{
+		DeviceDir: deviceDir,
+		disks:     make(map[string]*azure.Disk),
+		snapshots: make(map[string]*azure.Snapshot),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Config returns the configuration of a client of the virtual machine that
+// authenticates with a service principal.
+func (s *Server) Config() azure.Config {
+	return azure.Config{
+		SubscriptionID:          subscriptionID,
+		ResourceGroup:           resourceGroup,
+		InstanceName:            instanceName,
+		Location:                location,
+		TenantID:                tenantID,
+		ClientID:                clientID,
+		ClientSecret:            clientSecret,
+		ResourceManagerEndpoint: s.URL + "/",
+		ActiveDirectoryEndpoint: s.URL + "/",
+		MetadataEndpoint:        s.URL + "/metadata/",
+		DeviceDir:               s.DeviceDir,
+	}
+}
+
+// AttachRemote marks diskName as attached to another virtual machine.
+func (s *Server) AttachRemote(diskName, vm string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	d, ok := s.disks[strings.ToLower(diskName)]
+	if !ok {
+		return fmt.Errorf("disk %s not found", diskName)
+	}
+	d.ManagedBy = fmt.Sprintf(
+		"/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/virtualMachines/%s",
+		subscriptionID, resourceGroup, vm)
+	return nil
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch {
+	case r.URL.Path == "/"+tenantID+"/oauth2/token":
+		r.ParseForm()
+		if r.PostForm.Get("client_id") != clientID ||
+			r.PostForm.Get("client_secret") != clientSecret {
+			writeError(w, http.StatusUnauthorized, "InvalidClient", "bad credentials")
+			return
+		}
+		writeToken(w)
+		return
+	case r.URL.Path == "/metadata/identity/oauth2/token":
+		if r.Header.Get("Metadata") != "true" {
+			writeError(w, http.StatusBadRequest, "BadRequest", "missing Metadata header")
+			return
+		}
+		writeToken(w)
+		return
+	case r.URL.Path == "/metadata/instance/compute":
+		writeJSON(w, http.StatusOK, map[string]string{
+			"name":              instanceName,
+			"location":          location,
+			"resourceGroupName": resourceGroup,
+			"subscriptionId":    subscriptionID,
+		})
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+accessToken {
+		writeError(w, http.StatusUnauthorized, "AuthenticationFailed", "invalid token")
+		return
+	}
+	prefix := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/",
+		subscriptionID, resourceGroup)
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		writeError(w, http.StatusNotFound, "ResourceGroupNotFound", r.URL.Path)
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	name := ""
+	if len(parts) > 1 {
+		name = strings.ToLower(parts[1])
+	}
+	switch {
+	case parts[0] == "disks" && name == "" && r.Method == "GET":
+		s.listDisks(w, r)
+	case parts[0] == "disks" && name != "":
+		s.disk(w, r, name)
+	case parts[0] == "snapshots" && name != "":
+		s.snapshot(w, r, name)
+	case parts[0] == "virtualMachines" && name == strings.ToLower(instanceName):
+		s.virtualMachine(w, r)
+	default:
+		writeError(w, http.StatusNotFound, "ResourceNotFound", r.URL.Path)
+	}
+}
+
+func (s *Server) listDisks(w http.ResponseWriter, r *http.Request) {
+	names := make([]string, 0, len(s.disks))
+	for name := range s.disks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	skip, _ := strconv.Atoi(r.URL.Query().Get("$skiptoken"))
+	page := struct {
+		Value    []*azure.Disk `json:"value"`
+		NextLink string        `json:"nextLink,omitempty"`
+	}{Value: []*azure.Disk{}}
+	for i := skip; i < len(names) && i < skip+pageSize; i++ {
+		page.Value = append(page.Value, s.disks[names[i]])
+	}
+	if skip+pageSize < len(names) {
+		page.NextLink = fmt.Sprintf("%s%s?api-version=%s&$skiptoken=%d",
+			s.URL, r.URL.Path, r.URL.Query().Get("api-version"), skip+pageSize)
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (s *Server) disk(w http.ResponseWriter, r *http.Request, name string) {
+	d, ok := s.disks[name]
+	switch r.Method {
+	case "GET":
+		if !ok {
+			writeError(w, http.StatusNotFound, "ResourceNotFound", name)
+			return
+		}
+		writeJSON(w, http.StatusOK, d)
+	case "PUT":
+		d := &azure.Disk{}
+		if err := json.NewDecoder(r.Body).Decode(d); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+			return
+		}
+		if d.Properties == nil || d.Properties.CreationData == nil {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", "missing creationData")
+			return
+		}
+		if d.Properties.CreationData.CreateOption == azure.CreateOptionCopy {
+			source := d.Properties.CreationData.SourceResourceID
+			var size int32
+			if snap, ok := s.snapshots[strings.ToLower(path.Base(source))]; ok &&
+				strings.Contains(source, "/snapshots/") {
+				size = snap.Properties.DiskSizeGB
+			} else if disk, ok := s.disks[strings.ToLower(path.Base(source))]; ok &&
+				strings.Contains(source, "/disks/") {
+				size = disk.Properties.DiskSizeGB
+			} else {
+				writeError(w, http.StatusNotFound, "NotFound", "source not found: "+source)
+				return
+			}
+			if d.Properties.DiskSizeGB == 0 {
+				d.Properties.DiskSizeGB = size
+			}
+		}
+		if d.Properties.DiskSizeGB == 0 {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", "missing diskSizeGB")
+			return
+		}
+		d.ID = r.URL.Path
+		d.Name = path.Base(r.URL.Path)
+		d.Properties.ProvisioningState = azure.ProvisioningSucceeded
+		d.Properties.TimeCreated = time.Now().UTC().Format(time.RFC3339)
+		s.disks[name] = d
+		writeJSON(w, http.StatusCreated, d)
+	case "PATCH":
+		if !ok {
+			writeError(w, http.StatusNotFound, "ResourceNotFound", name)
+			return
+		}
+		update := &azure.Disk{}
+		if err := json.NewDecoder(r.Body).Decode(update); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+			return
+		}
+		d.Tags = update.Tags
+		writeJSON(w, http.StatusOK, d)
+	case "DELETE":
+		if !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if d.ManagedBy != "" {
+			writeError(w, http.StatusConflict, "OperationNotAllowed",
+				"disk is attached to "+d.ManagedBy)
+			return
+		}
+		delete(s.disks, name)
+		w.WriteHeader(http.StatusOK)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", r.Method)
+	}
+}
+
+func (s *Server) snapshot(w http.ResponseWriter, r *http.Request, name string) {
+	snap, ok := s.snapshots[name]
+	switch r.Method {
+	case "GET":
+		if !ok {
+			writeError(w, http.StatusNotFound, "ResourceNotFound", name)
+			return
+		}
+		writeJSON(w, http.StatusOK, snap)
+	case "PUT":
+		snap := &azure.Snapshot{}
+		if err := json.NewDecoder(r.Body).Decode(snap); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+			return
+		}
+		if snap.Properties == nil || snap.Properties.CreationData == nil {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", "missing creationData")
+			return
+		}
+		source, ok := s.disks[strings.ToLower(path.Base(
+			snap.Properties.CreationData.SourceResourceID))]
+		if !ok {
+			writeError(w, http.StatusNotFound, "NotFound", "source disk not found")
+			return
+		}
+		snap.ID = r.URL.Path
+		snap.Name = path.Base(r.URL.Path)
+		snap.Properties.DiskSizeGB = source.Properties.DiskSizeGB
+		snap.Properties.ProvisioningState = azure.ProvisioningSucceeded
+		snap.Properties.TimeCreated = time.Now().UTC().Format(time.RFC3339)
+		s.snapshots[name] = snap
+		writeJSON(w, http.StatusCreated, snap)
+	case "DELETE":
+		delete(s.snapshots, name)
+		w.WriteHeader(http.StatusOK)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", r.Method)
+	}
+}
+
+func (s *Server) virtualMachine(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+	case "PATCH":
+		update := &azure.VirtualMachine{}
+		if err := json.NewDecoder(r.Body).Decode(update); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+			return
+		}
+		if update.Properties != nil && update.Properties.StorageProfile != nil {
+			if status, code, err := s.setDataDisks(
+				update.Properties.StorageProfile.DataDisks, r.URL.Path,
+			); err != nil {
+				writeError(w, status, code, err.Error())
+				return
+			}
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", r.Method)
+		return
+	}
+	writeJSON(w, http.StatusOK, &azure.VirtualMachine{
+		ID:       r.URL.Path,
+		Name:     instanceName,
+		Location: location,
+		Properties: &azure.VirtualMachineProperties{
+			StorageProfile: &azure.StorageProfile{DataDisks: s.dataDisks},
+		},
+	})
+}
+
+// setDataDisks attaches and detaches disks to match dataDisks.
+func (s *Server) setDataDisks(dataDisks []azure.DataDisk, vmID string) (int, string, error) {
+	attached := make(map[string]azure.DataDisk)
+	luns := make(map[int32]bool)
+	for _, dd := range dataDisks {
+		name := strings.ToLower(dd.Name)
+		d, ok := s.disks[name]
+		if !ok {
+			return http.StatusNotFound, "NotFound", fmt.Errorf("disk %s not found", dd.Name)
+		}
+		if d.ManagedBy != "" && !strings.EqualFold(d.ManagedBy, vmID) {
+			return http.StatusConflict, "AttachDiskWhileBeingDetached",
+				fmt.Errorf("disk %s is attached to %s", dd.Name, d.ManagedBy)
+		}
+		if luns[dd.Lun] {
+			return http.StatusConflict, "InvalidParameter",
+				fmt.Errorf("LUN %d is used by more than one disk", dd.Lun)
+		}
+		luns[dd.Lun] = true
+		attached[name] = dd
+	}
+
+	for _, dd := range s.dataDisks {
+		if _, ok := attached[strings.ToLower(dd.Name)]; ok {
+			continue
+		}
+		if d, ok := s.disks[strings.ToLower(dd.Name)]; ok {
+			d.ManagedBy = ""
+		}
+		os.Remove(s.devicePath(dd.Lun))
+	}
+	for name, dd := range attached {
+		d := s.disks[name]
+		if d.ManagedBy != "" {
+			continue
+		}
+		if err := os.MkdirAll(s.DeviceDir, 0755); err != nil {
+			return http.StatusInternalServerError, "InternalError", err
+		}
+		f, err := os.Create(s.devicePath(dd.Lun))
+		if err != nil {
+			return http.StatusInternalServerError, "InternalError", err
+		}
+		err = f.Truncate(int64(d.Properties.DiskSizeGB) << 30)
+		f.Close()
+		if err != nil {
+			return http.StatusInternalServerError, "InternalError", err
+		}
+		d.ManagedBy = vmID
+	}
+	s.dataDisks = dataDisks
+	return 0, "", nil
+}
+
+func (s *Server) devicePath(lun int32) string {
+	return path.Join(s.DeviceDir, fmt.Sprintf("lun%d", lun))
+}
+
+func writeToken(w http.ResponseWriter) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"access_token": accessToken,
+		"expires_on":   strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10),
+	})
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]string{"code": code, "message": message},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
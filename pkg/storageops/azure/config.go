@@ -0,0 +1,160 @@
+package azure
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// Environment variables with the details of the instance in developer
+// mode and the credentials of the client.
+const (
+	EnvSubscriptionID = "AZURE_SUBSCRIPTION_ID"
+	EnvResourceGroup  = "AZURE_RESOURCE_GROUP"
+	EnvInstanceName   = "AZURE_INSTANCE_NAME"
+	EnvLocation       = "AZURE_LOCATION"
+	EnvTenantID       = "AZURE_TENANT_ID"
+	EnvClientID       = "AZURE_CLIENT_ID"
+	EnvClientSecret   = "AZURE_CLIENT_SECRET"
+)
+
+// Default endpoints of the Azure public cloud.
+const (
+	DefaultResourceManagerEndpoint = "https://management.azure.com/"
+	DefaultActiveDirectoryEndpoint = "https://login.microsoftonline.com/"
+	DefaultMetadataEndpoint        = "http://169.254.169.254/metadata/"
+	// DefaultDeviceDir is where the udev rules of the Azure Linux agent
+	// link the data disks by LUN.
+	DefaultDeviceDir = "/dev/disk/azure/scsi1"
+)
+
+// Config is the configuration of an Azure operations client.
+type Config struct {
+	// SubscriptionID, ResourceGroup, InstanceName and Location identify the
+	// virtual machine the client attaches disks to.
+	SubscriptionID string
+	ResourceGroup  string
+	InstanceName   string
+	Location       string
+	// TenantID, ClientID and ClientSecret are the credentials of a service
+	// principal. The managed identity of the instance is used without them.
+	TenantID     string
+	ClientID     string
+	ClientSecret string
+	// ResourceManagerEndpoint, ActiveDirectoryEndpoint and MetadataEndpoint
+	// default to the endpoints of the Azure public cloud.
+	ResourceManagerEndpoint string
+	ActiveDirectoryEndpoint string
+	MetadataEndpoint        string
+	// DeviceDir is the directory with the data disk devices by LUN.
+	DeviceDir string
+}
+
+// ConfigFromEnv returns the configuration set in the environment. The
+// instance details are required, the credentials are optional.
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		TenantID:     os.Getenv(EnvTenantID),
+		ClientID:     os.Getenv(EnvClientID),
+		ClientSecret: os.Getenv(EnvClientSecret),
+	}
+	for key, val := range map[string]*string{
+		EnvSubscriptionID: &cfg.SubscriptionID,
+		EnvResourceGroup:  &cfg.ResourceGroup,
+		EnvInstanceName:   &cfg.InstanceName,
+		EnvLocation:       &cfg.Location,
+	} {
+		if *val = os.Getenv(key); len(*val) == 0 {
+			return Config{}, fmt.Errorf("env variable %s is not set", key)
+		}
+	}
+	return cfg, nil
+}
+
+// DiskID returns the resource ID of the managed disk called name.
+func (c *Config) DiskID(name string) string {
+	return c.resourceGroupID() + "/providers/Microsoft.Compute/disks/" + escape(name)
+}
+
+// SnapshotID returns the resource ID of the snapshot called name.
+func (c *Config) SnapshotID(name string) string {
+	return c.resourceGroupID() + "/providers/Microsoft.Compute/snapshots/" + escape(name)
+}
+
+func (c *Config) instanceID() string {
+	return c.resourceGroupID() + "/providers/Microsoft.Compute/virtualMachines/" +
+		escape(c.InstanceName)
+}
+
+func (c *Config) resourceGroupID() string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s",
+		escape(c.SubscriptionID), escape(c.ResourceGroup))
+}
+
+func (c *Config) setDefaults() {
+	if c.ResourceManagerEndpoint == "" {
+		c.ResourceManagerEndpoint = DefaultResourceManagerEndpoint
+	}
+	if c.ActiveDirectoryEndpoint == "" {
+		c.ActiveDirectoryEndpoint = DefaultActiveDirectoryEndpoint
+	}
+	if c.MetadataEndpoint == "" {
+		c.MetadataEndpoint = DefaultMetadataEndpoint
+	}
+	if c.DeviceDir == "" {
+		c.DeviceDir = DefaultDeviceDir
+	}
+}
+
+func (c *Config) hasInstance() bool {
+	return c.SubscriptionID != "" && c.ResourceGroup != "" &&
+		c.InstanceName != "" && c.Location != ""
+}
+
+// fromMetadata fills in the instance details from the instance metadata
+// service.
+func (c *Config) fromMetadata(client *http.Client) error {
+	req, err := http.NewRequest("GET",
+		c.MetadataEndpoint+"instance/compute?api-version=2017-08-01&format=json", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Metadata", "true")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("code %d returned by the instance metadata service",
+			resp.StatusCode)
+	}
+
+	md := struct {
+		Name              string `json:"name"`
+		Location          string `json:"location"`
+		ResourceGroupName string `json:"resourceGroupName"`
+		SubscriptionID    string `json:"subscriptionId"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&md); err != nil {
+		return err
+	}
+	for _, field := range []struct {
+		val *string
+		md  string
+	}{
+		{&c.SubscriptionID, md.SubscriptionID},
+		{&c.ResourceGroup, md.ResourceGroupName},
+		{&c.InstanceName, md.Name},
+		{&c.Location, md.Location},
+	} {
+		if *field.val == "" {
+			*field.val = field.md
+		}
+	}
+	if !c.hasInstance() {
+		return fmt.Errorf("incomplete instance metadata")
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package azure
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenRefresh is how long before it expires a token is refreshed.
+const tokenRefresh = 5 * time.Minute
+
+// token is an Azure Active Directory access token.
+type token struct {
+	AccessToken string `json:"access_token"`
+	// ExpiresOn is the expiry time in seconds since the epoch.
+	ExpiresOn string `json:"expires_on"`
+	expiry    time.Time
+}
+
+// tokenSource caches the access token of the client for the resource
+// manager. Tokens are requested for the service principal of the config,
+// or for the managed identity of the instance if there is none.
+type tokenSource struct {
+	sync.Mutex
+	cfg     *Config
+	client  *http.Client
+	current *token
+}
+
+func newTokenSource(cfg *Config, client *http.Client) *tokenSource {
+	return &tokenSource{cfg: cfg, client: client}
+}
+
+// get returns a valid access token.
+func (t *tokenSource) get() (string, error) {
+	t.Lock()
+	defer t.Unlock()
+	if t.current != nil && time.Now().Add(tokenRefresh).Before(t.current.expiry) {
+		return t.current.AccessToken, nil
+	}
+
+	var req *http.Request
+	var err error
+	resource := t.cfg.ResourceManagerEndpoint
+	if t.cfg.ClientID != "" {
+		form := url.Values{
+			"grant_type":    {"client_credentials"},
+			"client_id":     {t.cfg.ClientID},
+			"client_secret": {t.cfg.ClientSecret},
+			"resource":      {resource},
+		}
+		req, err = http.NewRequest("POST",
+			t.cfg.ActiveDirectoryEndpoint+url.PathEscape(t.cfg.TenantID)+"/oauth2/token",
+			strings.NewReader(form.Encode()))
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		req, err = http.NewRequest("GET", t.cfg.MetadataEndpoint+
+			"identity/oauth2/token?api-version=2018-02-01&resource="+
+			url.QueryEscape(resource), nil)
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("Metadata", "true")
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("code %d returned for token request", resp.StatusCode)
+	}
+	tok := &token{}
+	if err := json.NewDecoder(resp.Body).Decode(tok); err != nil {
+		return "", err
+	}
+	expiresOn, err := strconv.ParseInt(tok.ExpiresOn, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid token expiry %q: %v", tok.ExpiresOn, err)
+	}
+	tok.expiry = time.Unix(expiresOn, 0)
+	t.current = tok
+	return tok.AccessToken, nil
+}
@@ -0,0 +1,652 @@
+// Package fake is an in-memory storage provider for running the storage
+// operations of cloud drivers without a cloud. A Cloud holds the disks and
+// snapshots shared by its instances, and each instance gets its own view of
+// the Cloud through storageops.Ops, so disks attached by one instance are
+// attached on a remote node for the others. The data of each disk is kept
+// in a sparse image file, and attached disks are exposed as loop devices
+// over their images so they can be formatted and mounted.
+package fake
+
+import (
+	"fmt"
+	"os/exec"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/libopenstorage/openstorage/pkg/storageops"
+)
+
+// Disk is a disk of the fake provider.
+type Disk struct {
+	// ID of the disk.
+	ID string
+	// Size of the disk in GiB.
+	Size int64
+	// Type and Iops describe the performance of the disk. They are kept
+	// for the driver and have no effect.
+	Type string
+	Iops int64
+	// Encrypted is set for encrypted disks, with the key KMSKey.
+	Encrypted bool
+	KMSKey    string
+	// SnapshotID is the snapshot the disk was created from.
+	SnapshotID string
+	// Labels of the disk.
+	Labels map[string]string
+	// Instance the disk is attached to, empty if the disk is detached.
+	Instance string
+	// DevicePath of the disk on Instance.
+	DevicePath string
+	// Ctime is when the disk was created.
+	Ctime time.Time
+}
+
+// Snapshot is a snapshot of a disk of the fake provider.
+type Snapshot struct {
+	// ID of the snapshot.
+	ID string
+	// DiskID is the disk the snapshot was taken of.
+	DiskID string
+	// Size of the snapshot in GiB.
+	Size int64
+	// Readonly is set for read only snapshots.
+	Readonly bool
+	// Ctime is when the snapshot was taken.
+	Ctime time.Time
+}
+
+// Instance is the description of an instance of the fake provider.
+type Instance struct {
+	// ID of the instance.
+	ID string
+	// Disks attached to the instance.
+	Disks []string
+}
+
+// Codec translates the templates, disks and snapshots of a provider to and
+// from those of the fake, so drivers written against the provider types can
+// run against the fake.
+type Codec interface {
+	// Template returns the disk described by a template passed to Create.
+	Template(template interface{}) (*Disk, error)
+	// Disk returns the provider representation of d.
+	Disk(d *Disk) interface{}
+	// Snapshot returns the provider representation of s.
+	Snapshot(s *Snapshot) interface{}
+	// ID returns the ID of a disk or snapshot returned by Disk or Snapshot.
+	ID(v interface{}) (string, error)
+}
+
+// fault is injected into an operation.
+type fault struct {
+	err     error
+	latency time.Duration
+}
+
+// Cloud is the shared state of the fake provider.
+type Cloud struct {
+	sync.Mutex
+	dir       string
+	loop      bool
+	next      int
+	disks     map[string]*Disk
+	snapshots map[string]*Snapshot
+	faults    map[string]*fault
+}
+
+// NewCloud returns a Cloud that keeps its disk images in dir. Attached
+// disks are exposed as loop devices if loop is set, which requires root,
+// and as their image files otherwise.
+func NewCloud(dir string, loop bool) *Cloud {
+	return &Cloud{
+		dir:       dir,
+		loop:      loop,
+		disks:     make(map[string]*Disk),
+		snapshots: make(map[string]*Snapshot),
+		faults:    make(map[string]*fault),
+	}
+}
+
+// LoopAvailable returns true if loop devices can be set up by this process.
+func LoopAvailable() bool {
+	if _, err := exec.LookPath("losetup"); err != nil {
+		return false
+	}
+	out, err := exec.Command("losetup", "--find").Output()
+	return err == nil && len(strings.TrimSpace(string(out))) > 0
+}
+
+// Ops returns the storage operations of instance. Templates, disks and
+// snapshots are *Disk and *Snapshot if codec is nil. Like the EBS
+// operations, they also change disks in place with Modify.
+func (c *Cloud) Ops(instance string, codec Codec) storageops.Ops {
+	if codec == nil {
+		codec = nativeCodec{}
+	}
+	return &fakeOps{cloud: c, instance: instance, codec: codec}
+}
+
+// InjectError makes every call of op fail with err until it is called again
+// with a nil error. op is the name of a storageops.Ops method, such as
+// "Attach".
+func (c *Cloud) InjectError(op string, err error) {
+	c.Lock()
+	defer c.Unlock()
+	c.fault(op).err = err
+}
+
+// InjectLatency delays every call of op by latency until it is called
+// again with no latency.
+func (c *Cloud) InjectLatency(op string, latency time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+	c.fault(op).latency = latency
+}
+
+// Disk returns a copy of the disk called id.
+func (c *Cloud) Disk(id string) (*Disk, error) {
+	c.Lock()
+	defer c.Unlock()
+	d, err := c.disk(id)
+	if err != nil {
+		return nil, err
+	}
+	return copyDisk(d), nil
+}
+
+// Close detaches all disks and releases their loop devices. The images are
+// left in the directory of the Cloud.
+func (c *Cloud) Close() error {
+	c.Lock()
+	defer c.Unlock()
+	var lastErr error
+	for _, d := range c.disks {
+		if d.Instance == "" {
+			continue
+		}
+		if err := c.release(d); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+func (c *Cloud) fault(op string) *fault {
+	f, ok := c.faults[op]
+	if !ok {
+		f = &fault{}
+		c.faults[op] = f
+	}
+	return f
+}
+
+// enter applies the faults injected into op.
+func (c *Cloud) enter(op string) error {
+	c.Lock()
+	f := *c.fault(op)
+	c.Unlock()
+	if f.latency > 0 {
+		time.Sleep(f.latency)
+	}
+	return f.err
+}
+
+func (c *Cloud) newID(prefix string) string {
+	c.next++
+	return fmt.Sprintf("%s-%d", prefix, c.next)
+}
+
+func (c *Cloud) image(id string) string {
+	return path.Join(c.dir, id+".img")
+}
+
+func (c *Cloud) disk(id string) (*Disk, error) {
+	d, ok := c.disks[id]
+	if !ok {
+		return nil, fmt.Errorf("disk %v not found", id)
+	}
+	return d, nil
+}
+
+// release removes the attachment of d.
+func (c *Cloud) release(d *Disk) error {
+	if c.loop {
+		if err := detachLoop(d.DevicePath); err != nil {
+			return err
+		}
+	}
+	d.Instance = ""
+	d.DevicePath = ""
+	return nil
+}
+
+func copyDisk(d *Disk) *Disk {
+	dup := *d
+	dup.Labels = make(map[string]string, len(d.Labels))
+	for k, v := range d.Labels {
+		dup.Labels[k] = v
+	}
+	return &dup
+}
+
+// fakeOps is the view of instance of the Cloud.
+type fakeOps struct {
+	cloud    *Cloud
+	instance string
+	codec    Codec
+}
+
+func (f *fakeOps) Name() string { return "fake" }
+
+func (f *fakeOps) Create(
+	template interface{},
+	labels map[string]string,
+) (interface{}, error) {
+	if err := f.cloud.enter("Create"); err != nil {
+		return nil, err
+	}
+	d, err := f.codec.Template(template)
+	if err != nil {
+		return nil, err
+	}
+	if d.Size <= 0 && d.SnapshotID == "" {
+		return nil, fmt.Errorf("invalid disk size %v", d.Size)
+	}
+
+	c := f.cloud
+	c.Lock()
+	defer c.Unlock()
+	source := ""
+	if d.SnapshotID != "" {
+		snap, ok := c.snapshots[d.SnapshotID]
+		if !ok {
+			return nil, fmt.Errorf("snapshot %v not found", d.SnapshotID)
+		}
+		if d.Size < snap.Size {
+			d.Size = snap.Size
+		}
+		source = c.image(snap.ID)
+	}
+	disk := copyDisk(d)
+	disk.ID = c.newID("disk")
+	disk.Instance = ""
+	disk.DevicePath = ""
+	disk.Ctime = time.Now()
+	for k, v := range labels {
+		disk.Labels[k] = v
+	}
+	if err := createImage(c.image(disk.ID), source, disk.Size); err != nil {
+		return nil, err
+	}
+	c.disks[disk.ID] = disk
+	return f.codec.Disk(copyDisk(disk)), nil
+}
+
+// Modify changes the size, type and IOPS of volumeID in place. Zero values
+// leave the attribute unchanged. Disks cannot shrink.
+func (f *fakeOps) Modify(
+	volumeID string,
+	size int64,
+	volumeType string,
+	iops int64,
+) error {
+	if err := f.cloud.enter("Modify"); err != nil {
+		return err
+	}
+	c := f.cloud
+	c.Lock()
+	defer c.Unlock()
+	d, err := c.disk(volumeID)
+	if err != nil {
+		return err
+	}
+	if size > 0 && size != d.Size {
+		if size < d.Size {
+			return fmt.Errorf("disk %v cannot shrink from %v to %v GiB",
+				volumeID, d.Size, size)
+		}
+		if err := createImage(c.image(d.ID), "", size); err != nil {
+			return err
+		}
+		if c.loop && d.Instance != "" {
+			if err := resizeLoop(d.DevicePath); err != nil {
+				return err
+			}
+		}
+		d.Size = size
+	}
+	if volumeType != "" {
+		d.Type = volumeType
+	}
+	if iops > 0 {
+		d.Iops = iops
+	}
+	return nil
+}
+
+func (f *fakeOps) GetDeviceID(template interface{}) (string, error) {
+	return f.codec.ID(template)
+}
+
+func (f *fakeOps) Attach(volumeID string) (string, error) {
+	if err := f.cloud.enter("Attach"); err != nil {
+		return "", err
+	}
+	c := f.cloud
+	c.Lock()
+	defer c.Unlock()
+	d, err := c.disk(volumeID)
+	if err != nil {
+		return "", err
+	}
+	switch d.Instance {
+	case f.instance:
+		return d.DevicePath, nil
+	case "":
+	default:
+		return "", storageops.NewStorageError(storageops.ErrVolAttachedOnRemoteNode,
+			fmt.Sprintf("disk %v is attached on %v", volumeID, d.Instance), d.Instance)
+	}
+
+	devicePath := c.image(d.ID)
+	if c.loop {
+		if devicePath, err = attachLoop(devicePath); err != nil {
+			return "", err
+		}
+	}
+	d.Instance = f.instance
+	d.DevicePath = devicePath
+	return devicePath, nil
+}
+
+func (f *fakeOps) Detach(volumeID string) error {
+	if err := f.cloud.enter("Detach"); err != nil {
+		return err
+	}
+	c := f.cloud
+	c.Lock()
+	defer c.Unlock()
+	d, err := f.attached(volumeID)
+	if err != nil {
+		return err
+	}
+	return c.release(d)
+}
+
+func (f *fakeOps) Delete(volumeID string) error {
+	if err := f.cloud.enter("Delete"); err != nil {
+		return err
+	}
+	c := f.cloud
+	c.Lock()
+	defer c.Unlock()
+	d, err := c.disk(volumeID)
+	if err != nil {
+		return err
+	}
+	if d.Instance != "" {
+		return fmt.Errorf("disk %v is attached on %v", volumeID, d.Instance)
+	}
+	if err := removeImage(c.image(d.ID)); err != nil {
+		return err
+	}
+	delete(c.disks, volumeID)
+	return nil
+}
+
+func (f *fakeOps) Describe() (interface{}, error) {
+	if err := f.cloud.enter("Describe"); err != nil {
+		return nil, err
+	}
+	c := f.cloud
+	c.Lock()
+	defer c.Unlock()
+	instance := &Instance{ID: f.instance}
+	for _, d := range c.disks {
+		if d.Instance == f.instance {
+			instance.Disks = append(instance.Disks, d.ID)
+		}
+	}
+	return instance, nil
+}
+
+func (f *fakeOps) FreeDevices(
+	blockDeviceMappings []interface{},
+	rootDeviceName string,
+) ([]string, error) {
+	return nil, fmt.Errorf("function not implemented")
+}
+
+func (f *fakeOps) Inspect(volumeIds []*string) ([]interface{}, error) {
+	if err := f.cloud.enter("Inspect"); err != nil {
+		return nil, err
+	}
+	c := f.cloud
+	c.Lock()
+	defer c.Unlock()
+	var disks []interface{}
+	for _, id := range volumeIds {
+		d, err := c.disk(*id)
+		if err != nil {
+			return nil, err
+		}
+		disks = append(disks, f.codec.Disk(copyDisk(d)))
+	}
+	return disks, nil
+}
+
+func (f *fakeOps) DeviceMappings() (map[string]string, error) {
+	if err := f.cloud.enter("DeviceMappings"); err != nil {
+		return nil, err
+	}
+	c := f.cloud
+	c.Lock()
+	defer c.Unlock()
+	m := make(map[string]string)
+	for _, d := range c.disks {
+		if d.Instance == f.instance {
+			m[d.DevicePath] = d.ID
+		}
+	}
+	return m, nil
+}
+
+func (f *fakeOps) Enumerate(
+	volumeIds []*string,
+	labels map[string]string,
+	setIdentifier string,
+) (map[string][]interface{}, error) {
+	if err := f.cloud.enter("Enumerate"); err != nil {
+		return nil, err
+	}
+	c := f.cloud
+	c.Lock()
+	defer c.Unlock()
+
+	var disks []*Disk
+	if volumeIds == nil {
+		for _, d := range c.disks {
+			disks = append(disks, d)
+		}
+	} else {
+		for _, id := range volumeIds {
+			d, err := c.disk(*id)
+			if err != nil {
+				return nil, err
+			}
+			disks = append(disks, d)
+		}
+	}
+
+	sets := make(map[string][]interface{})
+	for _, d := range disks {
+		if !hasLabels(d.Labels, labels) {
+			continue
+		}
+		set, ok := d.Labels[setIdentifier]
+		if len(setIdentifier) == 0 || !ok {
+			set = storageops.SetIdentifierNone
+		}
+		storageops.AddElementToMap(sets, f.codec.Disk(copyDisk(d)), set)
+	}
+	return sets, nil
+}
+
+func (f *fakeOps) DevicePath(volumeID string) (string, error) {
+	if err := f.cloud.enter("DevicePath"); err != nil {
+		return "", err
+	}
+	c := f.cloud
+	c.Lock()
+	defer c.Unlock()
+	d, err := f.attached(volumeID)
+	if err != nil {
+		return "", err
+	}
+	return d.DevicePath, nil
+}
+
+func (f *fakeOps) Snapshot(volumeID string, readonly bool) (interface{}, error) {
+	if err := f.cloud.enter("Snapshot"); err != nil {
+		return nil, err
+	}
+	c := f.cloud
+	c.Lock()
+	defer c.Unlock()
+	d, err := c.disk(volumeID)
+	if err != nil {
+		return nil, err
+	}
+	snap := &Snapshot{
+		ID:       c.newID("snap"),
+		DiskID:   d.ID,
+		Size:     d.Size,
+		Readonly: readonly,
+		Ctime:    time.Now(),
+	}
+	if err := createImage(c.image(snap.ID), c.image(d.ID), d.Size); err != nil {
+		return nil, err
+	}
+	c.snapshots[snap.ID] = snap
+	dup := *snap
+	return f.codec.Snapshot(&dup), nil
+}
+
+func (f *fakeOps) SnapshotDelete(snapID string) error {
+	if err := f.cloud.enter("SnapshotDelete"); err != nil {
+		return err
+	}
+	c := f.cloud
+	c.Lock()
+	defer c.Unlock()
+	if _, ok := c.snapshots[snapID]; !ok {
+		return fmt.Errorf("snapshot %v not found", snapID)
+	}
+	if err := removeImage(c.image(snapID)); err != nil {
+		return err
+	}
+	delete(c.snapshots, snapID)
+	return nil
+}
+
+func (f *fakeOps) ApplyTags(volumeID string, labels map[string]string) error {
+	if err := f.cloud.enter("ApplyTags"); err != nil {
+		return err
+	}
+	c := f.cloud
+	c.Lock()
+	defer c.Unlock()
+	d, err := c.disk(volumeID)
+	if err != nil {
+		return err
+	}
+	for k, v := range labels {
+		d.Labels[k] = v
+	}
+	return nil
+}
+
+func (f *fakeOps) RemoveTags(volumeID string, labels map[string]string) error {
+	if err := f.cloud.enter("RemoveTags"); err != nil {
+		return err
+	}
+	c := f.cloud
+	c.Lock()
+	defer c.Unlock()
+	d, err := c.disk(volumeID)
+	if err != nil {
+		return err
+	}
+	for k := range labels {
+		delete(d.Labels, k)
+	}
+	return nil
+}
+
+func (f *fakeOps) Tags(volumeID string) (map[string]string, error) {
+	if err := f.cloud.enter("Tags"); err != nil {
+		return nil, err
+	}
+	c := f.cloud
+	c.Lock()
+	defer c.Unlock()
+	d, err := c.disk(volumeID)
+	if err != nil {
+		return nil, err
+	}
+	return copyDisk(d).Labels, nil
+}
+
+// attached returns the disk volumeID if it is attached to the instance.
+func (f *fakeOps) attached(volumeID string) (*Disk, error) {
+	d, err := f.cloud.disk(volumeID)
+	if err != nil {
+		return nil, err
+	}
+	switch d.Instance {
+	case f.instance:
+		return d, nil
+	case "":
+		return nil, storageops.NewStorageError(storageops.ErrVolDetached,
+			fmt.Sprintf("disk %v is detached", volumeID), f.instance)
+	default:
+		return nil, storageops.NewStorageError(storageops.ErrVolAttachedOnRemoteNode,
+			fmt.Sprintf("disk %v is attached on %v", volumeID, d.Instance), d.Instance)
+	}
+}
+
+func hasLabels(set map[string]string, subset map[string]string) bool {
+	for k, v := range subset {
+		if set[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// nativeCodec passes disks and snapshots through unchanged.
+type nativeCodec struct{}
+
+func (nativeCodec) Template(template interface{}) (*Disk, error) {
+	d, ok := template.(*Disk)
+	if !ok {
+		return nil, fmt.Errorf("invalid disk template %v", template)
+	}
+	return d, nil
+}
+
+func (nativeCodec) Disk(d *Disk) interface{} { return d }
+
+func (nativeCodec) Snapshot(s *Snapshot) interface{} { return s }
+
+func (nativeCodec) ID(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case *Disk:
+		return v.ID, nil
+	case *Snapshot:
+		return v.ID, nil
+	}
+	return "", fmt.Errorf("invalid type: %v given to GetDeviceID", v)
+}
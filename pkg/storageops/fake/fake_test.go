@@ -0,0 +1,169 @@
+package fake_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/libopenstorage/openstorage/pkg/storageops"
+	"github.com/libopenstorage/openstorage/pkg/storageops/fake"
+	"github.com/libopenstorage/openstorage/pkg/storageops/test"
+	"github.com/stretchr/testify/require"
+)
+
+const newDiskSizeInGB = 10
+
+func newCloud(t *testing.T, loop bool) (*fake.Cloud, func()) {
+	dir, err := ioutil.TempDir("", "fake-disks")
+	require.NoError(t, err)
+	c := fake.NewCloud(dir, loop)
+	return c, func() {
+		c.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestAll(t *testing.T) {
+	c, cleanup := newCloud(t, false)
+	defer cleanup()
+	d := c.Ops("instance-1", nil)
+
+	test.RunTest(
+		map[string]storageops.Ops{d.Name(): d},
+		map[string]map[string]interface{}{
+			d.Name(): {"disk": &fake.Disk{Size: newDiskSizeInGB}},
+		},
+		t,
+	)
+}
+
+func TestRemote(t *testing.T) {
+	c, cleanup := newCloud(t, false)
+	defer cleanup()
+	local := c.Ops("instance-1", nil)
+	remote := c.Ops("instance-2", nil)
+
+	disk, err := local.Create(&fake.Disk{Size: 1}, nil)
+	require.NoError(t, err)
+	id := disk.(*fake.Disk).ID
+
+	_, err = local.DevicePath(id)
+	require.IsType(t, &storageops.StorageError{}, err)
+	require.Equal(t, storageops.ErrVolDetached, err.(*storageops.StorageError).Code)
+
+	_, err = remote.Attach(id)
+	require.NoError(t, err)
+	for _, err := range []error{
+		func() error { _, err := local.Attach(id); return err }(),
+		func() error { _, err := local.DevicePath(id); return err }(),
+		local.Detach(id),
+	} {
+		require.IsType(t, &storageops.StorageError{}, err)
+		require.Equal(t, storageops.ErrVolAttachedOnRemoteNode,
+			err.(*storageops.StorageError).Code)
+	}
+	require.Error(t, local.Delete(id), "attached disks cannot be deleted")
+	mappings, err := local.DeviceMappings()
+	require.NoError(t, err)
+	require.Empty(t, mappings)
+
+	require.NoError(t, remote.Detach(id))
+	devicePath, err := local.Attach(id)
+	require.NoError(t, err)
+	mappings, err = local.DeviceMappings()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{devicePath: id}, mappings)
+	require.NoError(t, local.Detach(id))
+	require.NoError(t, local.Delete(id))
+}
+
+func TestFaults(t *testing.T) {
+	c, cleanup := newCloud(t, false)
+	defer cleanup()
+	d := c.Ops("instance-1", nil)
+
+	injected := errors.New("injected")
+	c.InjectError("Create", injected)
+	_, err := d.Create(&fake.Disk{Size: 1}, nil)
+	require.Equal(t, injected, err)
+	c.InjectError("Create", nil)
+	disk, err := d.Create(&fake.Disk{Size: 1}, nil)
+	require.NoError(t, err)
+	id := disk.(*fake.Disk).ID
+
+	c.InjectLatency("Attach", 100*time.Millisecond)
+	start := time.Now()
+	_, err = d.Attach(id)
+	require.NoError(t, err)
+	require.True(t, time.Since(start) >= 100*time.Millisecond, "latency not injected")
+	c.InjectLatency("Attach", 0)
+	require.NoError(t, d.Detach(id))
+	require.NoError(t, d.Delete(id))
+}
+
+func TestSnapshotData(t *testing.T) {
+	c, cleanup := newCloud(t, false)
+	defer cleanup()
+	d := c.Ops("instance-1", nil)
+
+	disk, err := d.Create(&fake.Disk{Size: 1, Labels: map[string]string{"set": "a"}}, nil)
+	require.NoError(t, err)
+	id := disk.(*fake.Disk).ID
+	devicePath, err := d.Attach(id)
+	require.NoError(t, err)
+	f, err := os.OpenFile(devicePath, os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.WriteAt([]byte("snapshot"), 512*1024*1024)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	snap, err := d.Snapshot(id, true)
+	require.NoError(t, err)
+	snapID, err := d.GetDeviceID(snap)
+	require.NoError(t, err)
+	clone, err := d.Create(&fake.Disk{SnapshotID: snapID}, map[string]string{"set": "b"})
+	require.NoError(t, err)
+	cloneID := clone.(*fake.Disk).ID
+	require.Equal(t, int64(1), clone.(*fake.Disk).Size)
+	require.NoError(t, d.SnapshotDelete(snapID))
+
+	sets, err := d.Enumerate(nil, nil, "set")
+	require.NoError(t, err)
+	require.Len(t, sets["a"], 1)
+	require.Len(t, sets["b"], 1)
+
+	devicePath, err = d.Attach(cloneID)
+	require.NoError(t, err)
+	f, err = os.Open(devicePath)
+	require.NoError(t, err)
+	defer f.Close()
+	buf := make([]byte, len("snapshot"))
+	_, err = f.ReadAt(buf, 512*1024*1024)
+	require.NoError(t, err)
+	require.Equal(t, "snapshot", string(buf))
+}
+
+func TestLoop(t *testing.T) {
+	if !fake.LoopAvailable() {
+		t.Skip("loop devices are not available")
+	}
+	c, cleanup := newCloud(t, true)
+	defer cleanup()
+	d := c.Ops("instance-1", nil)
+
+	disk, err := d.Create(&fake.Disk{Size: 1}, nil)
+	require.NoError(t, err)
+	id := disk.(*fake.Disk).ID
+	devicePath, err := d.Attach(id)
+	require.NoError(t, err)
+	fi, err := os.Stat(devicePath)
+	require.NoError(t, err)
+	require.True(t, fi.Mode()&os.ModeDevice != 0, "%v is not a device", devicePath)
+	out, err := exec.Command("/sbin/mkfs.ext4", "-q", devicePath).CombinedOutput()
+	require.NoError(t, err, "mkfs: %s", out)
+	require.NoError(t, d.Detach(id))
+	require.NoError(t, d.Delete(id))
+}
@@ -0,0 +1,135 @@
+package fake
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+const (
+	gb = 1024 * 1024 * 1024
+	// Defined in <unistd.h> on Linux. Other systems reject them and the
+	// whole image is copied.
+	seekData = 3
+	seekHole = 4
+)
+
+// createImage creates a sparse image of size GiB at file, with the data of
+// the image source if it is set.
+func createImage(file, source string, size int64) error {
+	if source != "" {
+		if err := copyImage(file, source); err != nil {
+			os.Remove(file)
+			return err
+		}
+	}
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if fi.Size() < size*gb {
+		return f.Truncate(size * gb)
+	}
+	return nil
+}
+
+// copyImage copies the data extents of source to a new file, leaving the
+// holes of source as holes.
+func copyImage(file, source string) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	fi, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	size := fi.Size()
+	for offset := int64(0); offset < size; {
+		data, err := in.Seek(offset, seekData)
+		if isErrno(err, syscall.ENXIO) {
+			// No data after offset.
+			break
+		} else if err != nil {
+			data = offset
+		}
+		hole, err := in.Seek(data, seekHole)
+		if err != nil {
+			hole = size
+		}
+		if _, err := in.Seek(data, io.SeekStart); err != nil {
+			return err
+		}
+		if _, err := out.Seek(data, io.SeekStart); err != nil {
+			return err
+		}
+		if _, err := io.CopyN(out, in, hole-data); err != nil {
+			return err
+		}
+		offset = hole
+	}
+	if err := out.Truncate(size); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+func isErrno(err error, errno syscall.Errno) bool {
+	if pe, ok := err.(*os.PathError); ok {
+		return pe.Err == errno
+	}
+	return false
+}
+
+func removeImage(file string) error {
+	if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// attachLoop sets up a loop device over file and returns its path.
+func attachLoop(file string) (string, error) {
+	out, err := exec.Command("losetup", "--find", "--show", file).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to set up loop device for %v: %v: %s",
+			file, err, out)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// detachLoop releases the loop device at devicePath.
+func detachLoop(devicePath string) error {
+	out, err := exec.Command("losetup", "--detach", devicePath).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to release loop device %v: %v: %s",
+			devicePath, err, out)
+	}
+	return nil
+}
+
+// resizeLoop updates the size of the loop device at devicePath to that of
+// its image.
+func resizeLoop(devicePath string) error {
+	out, err := exec.Command("losetup", "--set-capacity", devicePath).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to resize loop device %v: %v: %s",
+			devicePath, err, out)
+	}
+	return nil
+}
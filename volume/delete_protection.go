@@ -0,0 +1,42 @@
+package volume
+
+import (
+	"github.com/libopenstorage/openstorage/api"
+)
+
+// CheckDelete returns the reason volumeID may not be deleted, or nil if it
+// may be. Sticky volumes and volumes attached or mounted on any node cannot
+// be deleted. Volumes with dependent clones can only be deleted if force is
+// set. Volumes that do not exist are left for the driver to report.
+//
+// Errors ErrVolSticky, ErrVolAttached and ErrVolHasClones may be returned,
+// as well as the error of the driver if volumeID cannot be inspected.
+func CheckDelete(e Enumerator, volumeID string, force bool) error {
+	vols, err := e.Inspect([]string{volumeID})
+	if err == ErrEnoEnt || (err == nil && len(vols) == 0) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	v := vols[0]
+	if v.Spec != nil && v.Spec.Sticky {
+		return ErrVolSticky
+	}
+	if v.AttachedOn != "" || len(v.AttachPath) > 0 ||
+		v.State == api.VolumeState_VOLUME_STATE_ATTACHED {
+		return ErrVolAttached
+	}
+	if force {
+		return nil
+	}
+	all, err := e.Enumerate(&api.VolumeLocator{}, nil)
+	if err != nil {
+		return err
+	}
+	for _, clone := range all {
+		if clone.IsClone() && clone.Source.Parent == volumeID {
+			return ErrVolHasClones
+		}
+	}
+	return nil
+}
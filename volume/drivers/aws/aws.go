@@ -1,11 +1,13 @@
 package aws
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"os"
 	"os/exec"
+	"sync"
 	"syscall"
 	"time"
 
@@ -14,7 +16,9 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/opsworks"
+	"github.com/golang/protobuf/proto"
 	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/osdconfig"
 	"github.com/libopenstorage/openstorage/pkg/chaos"
 	"github.com/libopenstorage/openstorage/pkg/proto/time"
 	"github.com/libopenstorage/openstorage/pkg/storageops"
@@ -36,6 +40,18 @@ const (
 	awsAccessKeyID = "AWS_ACCESS_KEY_ID"
 	// awsSecretAccessKey identifier for authentication.
 	awsSecretAccessKey = "AWS_SECRET_ACCESS_KEY"
+	// RuntimeVolumeID is the runtime state key of the EBS volume of a
+	// volume whose EBS volume was replaced by a restore.
+	RuntimeVolumeID = "aws_volume_id"
+	// volumeTypeSt1 is the throughput optimized HDD volume type.
+	volumeTypeSt1 = "st1"
+	// minSt1Size is the smallest st1 volume in GiB.
+	minSt1Size = 500
+	// io1 volumes are provisioned with at least minIops and at most
+	// maxIopsPerGB IOPS per GiB.
+	minIops      = 100
+	maxIopsPerGB = 50
+	freezebin    = "/usr/sbin/fsfreeze"
 )
 
 var (
@@ -53,12 +69,14 @@ type Metadata struct {
 type Driver struct {
 	volume.StatsDriver
 	volume.StoreEnumerator
-	volume.IODriver
-	volume.QuiesceDriver
 	volume.CredsDriver
 	volume.CloudBackupDriver
-	ops storageops.Ops
-	md  *Metadata
+	ops    storageops.Ops
+	md     *Metadata
+	states *common.StateMachine
+	// cmkLock protects cmk, the KMS key volumes are encrypted with.
+	cmkLock sync.Mutex
+	cmk     string
 }
 
 // Init aws volume driver metadata.
@@ -87,20 +105,38 @@ func Init(params map[string]string) (volume.VolumeDriver, error) {
 			},
 		),
 	)
-	d := &Driver{
-		StatsDriver: volume.StatsNotSupported,
-		ops:         aws_ops.NewEc2Storage(instance, ec2),
-		md: &Metadata{
+	d := newDriver(
+		aws_ops.NewEc2Storage(instance, ec2),
+		&Metadata{
 			zone:     zone,
 			instance: instance,
 		},
-		IODriver:          volume.IONotSupported,
-		QuiesceDriver:     volume.QuiesceNotSupported,
+	)
+	manager, err := osdconfig.NewManager(context.Background(), kvdb.Instance())
+	if err != nil {
+		dlog.Warnf("Failed to watch the cluster configuration: %v", err)
+	} else if err := d.watchConfig(manager); err != nil {
+		dlog.Warnf("Failed to watch the cluster configuration: %v", err)
+	}
+	return d, nil
+}
+
+// newDriver returns a driver that provisions volumes through ops.
+func newDriver(ops storageops.Ops, md *Metadata) *Driver {
+	store := common.NewDefaultStoreEnumerator(Name, kvdb.Instance())
+	d := &Driver{
+		ops:               ops,
+		md:                md,
 		CredsDriver:       volume.CredsNotSupported,
 		CloudBackupDriver: volume.CloudBackupNotSupported,
-		StoreEnumerator:   common.NewDefaultStoreEnumerator(Name, kvdb.Instance()),
+		StoreEnumerator:   store,
+		states:            common.NewStateMachine(store),
 	}
-	return d, nil
+	d.StatsDriver = common.NewStatsDriver(store, mountPath)
+	if err := common.NewReconciler(d.StoreEnumerator, d.probe, d.purge).Reconcile(); err != nil {
+		dlog.Warnf("Failed to recover volumes: %v", err)
+	}
+	return d
 }
 
 // authKeys return authentication keys for this instance.
@@ -128,19 +164,48 @@ func getAuthKey(key string, params map[string]string) (string, error) {
 	return val, nil
 }
 
-// mapCos translates a CoS specified in spec to a volume.
-func mapCos(cos uint32) (*int64, *string) {
+// mapCos translates the CoS and IO profile of a spec to the type of an
+// EBS volume of size GiB, and the IOPS to provision for io1 volumes.
+// Database profiles get provisioned IOPS at any CoS, and sequential
+// profiles get throughput optimized volumes at low CoS if they are large
+// enough.
+func mapCos(cos api.CosType, profile api.IoProfile, size int64) (string, int64) {
+	switch profile {
+	case api.IoProfile_IO_PROFILE_DB, api.IoProfile_IO_PROFILE_DB_REMOTE:
+		if cos < api.CosType_MEDIUM {
+			cos = api.CosType_MEDIUM
+		}
+	}
+
 	var iops int64
-	var volType string
-	switch {
-	case cos < 2:
-		iops, volType = 0, opsworks.VolumeTypeGp2
-	case cos < 7:
-		iops, volType = 10000, opsworks.VolumeTypeIo1
+	switch cos {
+	case api.CosType_HIGH:
+		iops = 20000
+	case api.CosType_MEDIUM:
+		iops = 10000
 	default:
-		iops, volType = 20000, opsworks.VolumeTypeIo1
+		if profile == api.IoProfile_IO_PROFILE_SEQUENTIAL && size >= minSt1Size {
+			return volumeTypeSt1, 0
+		}
+		return opsworks.VolumeTypeGp2, 0
 	}
-	return &iops, &volType
+	if max := size * maxIopsPerGB; iops > max {
+		iops = max
+	}
+	if iops < minIops {
+		iops = minIops
+	}
+	return opsworks.VolumeTypeIo1, iops
+}
+
+// sizeGB translates a spec size in bytes to whole GiB, rounding up.
+func sizeGB(size uint64) int64 {
+	const gb = 1024 * 1024 * 1024
+	sz := int64((size + gb - 1) / gb)
+	if sz == 0 {
+		sz = 1
+	}
+	return sz
 }
 
 // metadata retrieves instance metadata specified by key.
@@ -166,6 +231,59 @@ func metadata(key string) (string, error) {
 	return string(body), nil
 }
 
+// watchConfig picks up the KMS key from the cluster configuration and
+// whenever it changes.
+func (d *Driver) watchConfig(manager osdconfig.ConfigManager) error {
+	if config, err := manager.GetClusterConf(); err == nil {
+		d.configure(config)
+	}
+	return manager.WatchCluster(Name, d.configure)
+}
+
+// configure sets the KMS key encrypted volumes are created with. Volumes
+// are encrypted with the default EBS key if it is not set.
+func (d *Driver) configure(config *osdconfig.ClusterConfig) error {
+	cmk := ""
+	if config != nil && config.Secrets != nil && config.Secrets.Aws != nil {
+		cmk = config.Secrets.Aws.AwsCmk
+	}
+	d.cmkLock.Lock()
+	defer d.cmkLock.Unlock()
+	d.cmk = cmk
+	return nil
+}
+
+func (d *Driver) kmsKey() string {
+	d.cmkLock.Lock()
+	defer d.cmkLock.Unlock()
+	return d.cmk
+}
+
+// ebsID returns the ID of the EBS volume of v.
+func ebsID(v *api.Volume) string {
+	if len(v.RuntimeState) > 0 {
+		if id, ok := v.RuntimeState[0].RuntimeState[RuntimeVolumeID]; ok {
+			return id
+		}
+	}
+	return v.Id
+}
+
+// probe finds the attachment of the EBS volume of v.
+func (d *Driver) probe(v *api.Volume) (*common.Backing, error) {
+	ebs := proto.Clone(v).(*api.Volume)
+	ebs.Id = ebsID(v)
+	return common.StorageOpsProbe(d.ops)(ebs)
+}
+
+// mountPath returns where v is mounted.
+func mountPath(v *api.Volume) (string, error) {
+	if len(v.AttachPath) == 0 || len(v.AttachPath[0]) == 0 {
+		return "", fmt.Errorf("Volume %v is not mounted", v.Id)
+	}
+	return v.AttachPath[0], nil
+}
+
 // Name returns the name of the driver
 func (d *Driver) Name() string {
 	return Name
@@ -181,102 +299,115 @@ func (d *Driver) Status() [][2]string {
 	return [][2]string{}
 }
 
-// Create creates a new volume
+// Create creates a new volume. Volumes with a parent are created from the
+// snapshot of the parent, others are formatted.
 func (d *Driver) Create(
 	locator *api.VolumeLocator,
 	source *api.Source,
 	spec *api.VolumeSpec,
 ) (string, error) {
-	var snapID *string
-	// Spec size is in bytes, translate to GiB.
-	sz := int64(spec.Size / (1024 * 1024 * 1024))
-	iops, volType := mapCos(uint32(spec.Cos))
-	if source != nil && string(source.Parent) != "" {
-		id := string(source.Parent)
-		snapID = &id
-	}
+	sz := sizeGB(spec.Size)
+	volType, iops := mapCos(spec.Cos, spec.IoProfile, sz)
 	ec2Vol := &ec2.Volume{
 		AvailabilityZone: &d.md.zone,
-		VolumeType:       volType,
-		SnapshotId:       snapID,
+		VolumeType:       &volType,
 		Size:             &sz,
 	}
-
-	// Gp2 Volumes don't support the iops parameter
-	if *volType != opsworks.VolumeTypeGp2 {
-		ec2Vol.Iops = iops
+	// Only io1 volumes support the iops parameter
+	if volType == opsworks.VolumeTypeIo1 {
+		ec2Vol.Iops = &iops
+	}
+	if spec.Encrypted {
+		encrypted := true
+		ec2Vol.Encrypted = &encrypted
+		if cmk := d.kmsKey(); cmk != "" {
+			ec2Vol.KmsKeyId = &cmk
+		}
+	}
+	copied := source != nil && source.Parent != ""
+	if copied {
+		snapID, cleanup, err := d.parentSnapshot(source.Parent)
+		if err != nil {
+			return "", err
+		}
+		defer cleanup()
+		ec2Vol.SnapshotId = &snapID
+	}
+	if spec.Format == api.FSType_FS_TYPE_NONE {
+		spec.Format = api.FSType_FS_TYPE_EXT4
 	}
+
 	resp, err := d.ops.Create(ec2Vol, locator.VolumeLabels)
 	if err != nil {
 		dlog.Warnf("Failed in CreateVolumeRequest :%v", err)
 		return "", err
 	}
-
-	vol, ok := resp.(*ec2.Volume)
-	if !ok {
-		return "", storageops.NewStorageError(storageops.ErrVolInval,
-			"Invalid volume returned by create API",
-			fmt.Sprintf("template snapshot: %s", *snapID))
-	}
-
-	volume := common.NewVolume(
-		*vol.VolumeId,
-		api.FSType_FS_TYPE_EXT4,
-		locator,
-		source,
-		spec,
-	)
-	err = d.UpdateVol(volume)
+	id, err := d.ops.GetDeviceID(resp)
 	if err != nil {
 		return "", err
 	}
-	if _, err := d.Attach(volume.Id, nil); err != nil {
+
+	chaos.Now(koStrayCreate)
+	v := common.NewVolume(id, spec.Format, locator, source, spec)
+	err = d.states.Create(v, func(v *api.Volume) error {
+		if copied {
+			return nil
+		}
+		return d.format(v)
+	})
+	if err != nil {
+		if delErr := d.ops.Delete(id); delErr != nil {
+			dlog.Warnf("Failed to delete volume %v after failed create: %v",
+				id, delErr)
+		}
 		return "", err
 	}
+	return v.Id, nil
+}
 
-	dlog.Infof("aws preparing volume %s...", *vol.VolumeId)
-	if err := d.Format(volume.Id); err != nil {
-		return "", err
+// parentSnapshot returns the snapshot to create a copy of parent from.
+// Volumes that are not snapshots are snapshotted, and the snapshot is
+// deleted by cleanup.
+func (d *Driver) parentSnapshot(parent string) (string, func(), error) {
+	v, err := d.GetVol(parent)
+	if err != nil {
+		return "", nil, err
 	}
-	if err := d.Detach(volume.Id, nil); err != nil {
-		return "", err
+	if v.IsSnapshot() {
+		return v.Id, func() {}, nil
 	}
-
-	return volume.Id, err
+	resp, err := d.ops.Snapshot(ebsID(v), true)
+	if err != nil {
+		return "", nil, err
+	}
+	snapID, err := d.ops.GetDeviceID(resp)
+	if err != nil {
+		return "", nil, err
+	}
+	return snapID, func() {
+		if err := d.ops.SnapshotDelete(snapID); err != nil {
+			dlog.Warnf("Failed to delete snapshot %v of %v: %v", snapID, parent, err)
+		}
+	}, nil
 }
 
-// merge volume properties from aws into volume.
-func (d *Driver) merge(v *api.Volume, aws *ec2.Volume) {
-	v.AttachedOn = ""
-	v.State = api.VolumeState_VOLUME_STATE_DETACHED
-	v.DevicePath = ""
-
-	switch *aws.State {
-	case ec2.VolumeStateAvailable:
-		v.Status = api.VolumeStatus_VOLUME_STATUS_UP
-	case ec2.VolumeStateCreating, ec2.VolumeStateDeleting:
-		v.State = api.VolumeState_VOLUME_STATE_PENDING
-		v.Status = api.VolumeStatus_VOLUME_STATUS_DOWN
-	case ec2.VolumeStateDeleted:
-		v.State = api.VolumeState_VOLUME_STATE_DELETED
-		v.Status = api.VolumeStatus_VOLUME_STATUS_DOWN
-	case ec2.VolumeStateError:
-		v.State = api.VolumeState_VOLUME_STATE_ERROR
-		v.Status = api.VolumeStatus_VOLUME_STATUS_DOWN
-	case ec2.VolumeStateInUse:
-		v.Status = api.VolumeStatus_VOLUME_STATUS_UP
-		if aws.Attachments != nil && len(aws.Attachments) != 0 {
-			if aws.Attachments[0].InstanceId != nil {
-				v.AttachedOn = *aws.Attachments[0].InstanceId
-			}
-			if aws.Attachments[0].State != nil {
-				v.State = d.volumeState(aws.Attachments[0].State)
-			}
-			if aws.Attachments[0].Device != nil {
-				v.DevicePath = *aws.Attachments[0].Device
-			}
-		}
+// format attaches the EBS volume of v, makes a filesystem on it and
+// detaches it.
+func (d *Driver) format(v *api.Volume) error {
+	devicePath, err := d.ops.Attach(ebsID(v))
+	if err != nil {
+		return err
+	}
+	dlog.Infof("aws preparing volume %s...", v.Id)
+	cmd := "/sbin/mkfs." + v.Spec.Format.SimpleString()
+	o, err := exec.Command(cmd, devicePath).CombinedOutput()
+	if err != nil {
+		dlog.Warnf("Failed to run command %v %v: %s", cmd, devicePath, o)
 	}
+	if detachErr := d.ops.Detach(ebsID(v)); detachErr != nil && err == nil {
+		err = detachErr
+	}
+	return err
 }
 
 // Inspect insepcts a volume
@@ -285,215 +416,486 @@ func (d *Driver) Inspect(volumeIDs []string) ([]*api.Volume, error) {
 	if err != nil {
 		return nil, err
 	}
-	ids := make([]*string, len(vols))
-	for i, v := range vols {
-		id := v.Id
-		ids[i] = &id
+	var ids []*string
+	byID := make(map[string]*api.Volume)
+	for _, v := range vols {
+		if v.IsSnapshot() {
+			continue
+		}
+		id := ebsID(v)
+		ids = append(ids, &id)
+		byID[id] = v
+	}
+	if len(ids) == 0 {
+		return vols, nil
 	}
-	volumeMap, err := d.ops.Enumerate(ids, nil, "")
+	awsVols, err := d.ops.Inspect(ids)
 	if err != nil {
 		return nil, err
 	}
-	if len(volumeMap) != 1 {
-		return nil, fmt.Errorf("Inspect volumeMap mismatch")
-	}
-	for _, awsVols := range volumeMap {
-		if len(awsVols) != len(vols) {
-			return nil, fmt.Errorf("Inspect volume count mismatch")
+	for _, awsVol := range awsVols {
+		vol, ok := awsVol.(*ec2.Volume)
+		if !ok {
+			return nil, storageops.NewStorageError(storageops.ErrVolInval,
+				"Invalid volume returned by inspect API", "")
 		}
-		for i, v := range awsVols {
-			vol, ok := v.(*ec2.Volume)
-			if !ok {
-				return nil, storageops.NewStorageError(storageops.ErrVolInval,
-					"Invalid volume returned by inspect API", "")
-			}
-
-			if string(vols[i].Id) != *vol.VolumeId {
-				d.merge(vols[i], vol)
-			}
+		if v, ok := byID[*vol.VolumeId]; ok {
+			merge(v, vol)
 		}
 	}
 	return vols, nil
 }
 
+// merge the status and attachment of the EBS volume into v.
+func merge(v *api.Volume, vol *ec2.Volume) {
+	v.AttachedOn = ""
+	if vol.State == nil {
+		v.Status = api.VolumeStatus_VOLUME_STATUS_DOWN
+		return
+	}
+	switch *vol.State {
+	case ec2.VolumeStateAvailable, ec2.VolumeStateInUse:
+		v.Status = api.VolumeStatus_VOLUME_STATUS_UP
+	default:
+		v.Status = api.VolumeStatus_VOLUME_STATUS_DOWN
+	}
+	if len(vol.Attachments) != 0 && vol.Attachments[0].InstanceId != nil {
+		v.AttachedOn = *vol.Attachments[0].InstanceId
+	}
+}
+
+// Delete deletes the EBS volume or snapshot of a volume.
 func (d *Driver) Delete(volumeID string) error {
-	if err := d.ops.Delete(volumeID); err != nil {
-		return err
+	return d.states.Transition(volumeID, common.OpDelete, d.purge)
+}
+
+// purge deletes the EBS volume or snapshot backing v.
+func (d *Driver) purge(v *api.Volume) error {
+	var err error
+	if v.IsSnapshot() {
+		err = d.ops.SnapshotDelete(v.Id)
+	} else {
+		err = d.ops.Delete(ebsID(v))
 	}
-	return d.DeleteVol(volumeID)
+	chaos.Now(koStrayDelete)
+	return err
 }
 
+// Snapshot takes an EBS snapshot of volumeID. Snapshots cannot be
+// attached, new volumes are created from them instead.
 func (d *Driver) Snapshot(
 	volumeID string,
 	readonly bool,
 	locator *api.VolumeLocator,
 ) (string, error) {
-	vols, err := d.StoreEnumerator.Inspect([]string{volumeID})
+	v, err := d.GetVol(volumeID)
 	if err != nil {
 		return "", err
 	}
-	if len(vols) != 1 {
-		return "", fmt.Errorf("Failed to inspect %v len %v", volumeID, len(vols))
+	if v.IsSnapshot() {
+		return "", fmt.Errorf("Cannot snapshot snapshot %v", volumeID)
 	}
-	resp, err := d.ops.Snapshot(volumeID, readonly)
+	resp, err := d.ops.Snapshot(ebsID(v), readonly)
+	if err != nil {
+		return "", err
+	}
+	snapID, err := d.ops.GetDeviceID(resp)
 	if err != nil {
 		return "", err
 	}
-
-	snap := resp.(*ec2.Snapshot)
-	chaos.Now(koStrayCreate)
-	vols[0].Id = *snap.SnapshotId
-	vols[0].Source = &api.Source{Parent: volumeID}
-	vols[0].Locator = locator
-	vols[0].Ctime = prototime.Now()
 
 	chaos.Now(koStrayCreate)
-	if err = d.CreateVol(vols[0]); err != nil {
+	v.Id = snapID
+	v.Source = &api.Source{Parent: volumeID}
+	v.Locator = locator
+	v.Ctime = prototime.Now()
+	v.Readonly = true
+	v.State = api.VolumeState_VOLUME_STATE_AVAILABLE
+	v.AttachedOn = ""
+	v.AttachPath = nil
+	v.DevicePath = ""
+	v.RuntimeState = nil
+	if err = d.CreateVol(v); err != nil {
+		if delErr := d.ops.SnapshotDelete(snapID); delErr != nil {
+			dlog.Warnf("Failed to delete snapshot %v: %v", snapID, delErr)
+		}
 		return "", err
 	}
-	return vols[0].Id, nil
+	return v.Id, nil
 }
 
+// Restore restores volumeID to snapID. EBS volumes cannot be restored in
+// place, so a new EBS volume is created from the snapshot and replaces the
+// EBS volume of volumeID, which is deleted.
 func (d *Driver) Restore(volumeID string, snapID string) error {
-	// New volumes can be created from snapshot but existing volumes
-	// cannot be restored to same volumeID.
-	return volume.ErrNotSupported
+	snap, err := d.GetVol(snapID)
+	if err != nil {
+		return err
+	}
+	if !snap.IsSnapshot() {
+		return fmt.Errorf("Volume %v is not a snapshot", snapID)
+	}
+
+	var replaced string
+	err = d.states.Transition(volumeID, common.OpRestore, func(v *api.Volume) error {
+		replaced = ebsID(v)
+		awsVols, err := d.ops.Inspect([]*string{&replaced})
+		if err != nil {
+			return err
+		}
+		if len(awsVols) != 1 {
+			return fmt.Errorf("Failed to inspect volume %v", replaced)
+		}
+		old, ok := awsVols[0].(*ec2.Volume)
+		if !ok {
+			return storageops.NewStorageError(storageops.ErrVolInval,
+				"Invalid volume returned by inspect API",
+				fmt.Sprintf("volume to inspect: %s", replaced))
+		}
+		tags, err := d.ops.Tags(replaced)
+		if err != nil {
+			return err
+		}
+
+		ec2Vol := &ec2.Volume{
+			AvailabilityZone: old.AvailabilityZone,
+			VolumeType:       old.VolumeType,
+			Size:             old.Size,
+			Encrypted:        old.Encrypted,
+			KmsKeyId:         old.KmsKeyId,
+			SnapshotId:       &snapID,
+		}
+		if old.VolumeType != nil && *old.VolumeType == opsworks.VolumeTypeIo1 {
+			ec2Vol.Iops = old.Iops
+		}
+		resp, err := d.ops.Create(ec2Vol, tags)
+		if err != nil {
+			return err
+		}
+		restored, err := d.ops.GetDeviceID(resp)
+		if err != nil {
+			return err
+		}
+		dlog.Infof("aws restored volume %v from %v to %v", volumeID, snapID, restored)
+		common.SetRuntimeState(v, RuntimeVolumeID, restored)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := d.ops.Delete(replaced); err != nil {
+		dlog.Warnf("Failed to delete volume %v replaced by restore of %v: %v",
+			replaced, volumeID, err)
+	}
+	return nil
 }
 
+// Attach attaches the EBS volume of volumeID to this instance.
 func (d *Driver) Attach(
 	volumeID string,
 	attachOptions map[string]string,
 ) (string, error) {
-	volume, err := d.GetVol(volumeID)
-	if err != nil {
-		return "", fmt.Errorf("Volume %s could not be located", volumeID)
-	}
-	path, err := d.ops.Attach(volumeID)
+	err := d.states.Transition(volumeID, common.OpAttach, func(v *api.Volume) error {
+		if v.IsSnapshot() {
+			return fmt.Errorf("Snapshot %v cannot be attached", volumeID)
+		}
+		path, err := d.ops.Attach(ebsID(v))
+		if err != nil {
+			return err
+		}
+		v.DevicePath = path
+		return nil
+	})
 	if err != nil {
 		return "", err
 	}
-	volume.DevicePath = path
-	if err := d.UpdateVol(volume); err != nil {
-		d.ops.Detach(volumeID)
+	v, err := d.GetVol(volumeID)
+	if err != nil {
 		return "", err
 	}
-	return path, nil
+	return v.DevicePath, nil
 }
 
-func (d *Driver) volumeState(ec2VolState *string) api.VolumeState {
-	if ec2VolState == nil {
-		return api.VolumeState_VOLUME_STATE_DETACHED
-	}
-	switch *ec2VolState {
-	case ec2.VolumeAttachmentStateAttached:
-		return api.VolumeState_VOLUME_STATE_ATTACHED
-	case ec2.VolumeAttachmentStateDetached:
-		return api.VolumeState_VOLUME_STATE_DETACHED
-	case ec2.VolumeAttachmentStateAttaching, ec2.VolumeAttachmentStateDetaching:
-		return api.VolumeState_VOLUME_STATE_PENDING
-	default:
-		dlog.Warnf("Failed to translate EC2 volume status %v", ec2VolState)
-	}
-	return api.VolumeState_VOLUME_STATE_ERROR
+// Detach detaches the EBS volume of volumeID from this instance.
+func (d *Driver) Detach(volumeID string, options map[string]string) error {
+	return d.states.Transition(volumeID, common.OpDetach, func(v *api.Volume) error {
+		if err := d.ops.Detach(ebsID(v)); err != nil {
+			if se, ok := err.(*storageops.StorageError); !ok ||
+				se.Code != storageops.ErrVolDetached {
+				return err
+			}
+		}
+		v.DevicePath = ""
+		return nil
+	})
 }
 
-func (d *Driver) Format(volumeID string) error {
-	volume, err := d.GetVol(volumeID)
-	if err != nil {
-		return fmt.Errorf("Failed to locate volume %q", volumeID)
-	}
+// MountedAt returns the volume mounted at mountpath.
+func (d *Driver) MountedAt(mountpath string) string {
+	return ""
+}
 
-	// XXX: determine mount state
-	awsVols, err := d.ops.Inspect([]*string{&volumeID})
-	if err != nil {
-		return err
+// Mount mounts the attached EBS volume of volumeID at mountpath. The
+// filesystem is grown to the size of the volume if it was resized while
+// it was not mounted.
+func (d *Driver) Mount(volumeID string, mountpath string, options map[string]string) error {
+	return d.states.Transition(volumeID, common.OpMount, func(v *api.Volume) error {
+		if len(v.AttachPath) > 0 && len(v.AttachPath[0]) > 0 {
+			return fmt.Errorf("Volume %q already mounted at %q", volumeID, v.AttachPath[0])
+		}
+		devicePath, err := d.ops.DevicePath(ebsID(v))
+		if err != nil {
+			return err
+		}
+		if err := syscall.Mount(devicePath, mountpath, v.Spec.Format.SimpleString(), 0, ""); err != nil {
+			return fmt.Errorf("Failed to mount %v at %v: %v", devicePath, mountpath, err)
+		}
+		v.DevicePath = devicePath
+		v.AttachPath = []string{mountpath}
+		if err := growFilesystem(v); err != nil {
+			dlog.Warnf("Failed to grow filesystem of volume %v: %v", volumeID, err)
+		}
+		return nil
+	})
+}
+
+// Unmount unmounts volumeID.
+func (d *Driver) Unmount(volumeID string, mountpath string, options map[string]string) error {
+	return d.states.Transition(volumeID, common.OpUnmount, func(v *api.Volume) error {
+		if len(v.AttachPath) == 0 || len(v.AttachPath[0]) == 0 {
+			return fmt.Errorf("Device %v not mounted", volumeID)
+		}
+		if err := syscall.Unmount(v.AttachPath[0], 0); err != nil {
+			return err
+		}
+		v.AttachPath = nil
+		return nil
+	})
+}
+
+// growFilesystem grows the filesystem of the mounted volume v to the size
+// of its device.
+func growFilesystem(v *api.Volume) error {
+	var cmd *exec.Cmd
+	switch v.Spec.Format {
+	case api.FSType_FS_TYPE_EXT4:
+		cmd = exec.Command("/sbin/resize2fs", v.DevicePath)
+	case api.FSType_FS_TYPE_XFS:
+		cmd = exec.Command("/usr/sbin/xfs_growfs", v.AttachPath[0])
+	default:
+		return fmt.Errorf("Cannot grow %v filesystem on %s",
+			v.Spec.Format.SimpleString(), v.DevicePath)
 	}
-	if len(awsVols) != 1 {
-		return fmt.Errorf("Failed to inspect volume %v", volumeID)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("Failed to grow filesystem on %s: %s (%v)",
+			v.DevicePath, out, err)
 	}
+	return nil
+}
+
+// Set updates the locator of volumeID and the tags of its EBS volume, and
+// applies changes to the size, CoS and IO profile of its spec with
+// ModifyVolume.
+func (d *Driver) Set(volumeID string, locator *api.VolumeLocator, spec *api.VolumeSpec) error {
+	return d.states.Update(volumeID, func(v *api.Volume) error {
+		if spec != nil {
+			if v.IsSnapshot() {
+				return volume.ErrNotSupported
+			}
+			if err := d.modify(v, spec); err != nil {
+				return err
+			}
+		}
+		if locator != nil {
+			if !v.IsSnapshot() {
+				if err := d.tag(v, locator.VolumeLabels); err != nil {
+					return err
+				}
+			}
+			v.Locator = locator
+		}
+		return nil
+	})
+}
 
-	awsVol, ok := awsVols[0].(*ec2.Volume)
+// modify resizes and changes the performance of the EBS volume of v to
+// match spec. A zero CoS or IO profile in spec leaves that of v unchanged.
+// The filesystem of a mounted volume is grown right away, or the next time
+// it is mounted if that fails.
+func (d *Driver) modify(v *api.Volume, spec *api.VolumeSpec) error {
+	modifier, ok := d.ops.(aws_ops.Modifier)
 	if !ok {
-		return storageops.NewStorageError(storageops.ErrVolInval,
-			"Invalid volume returned by inspect API",
-			fmt.Sprintf("volume to inspect: %s", volumeID))
+		return volume.ErrNotSupported
+	}
+	resize := false
+	if spec.Size != 0 {
+		// The class of service and IO profile are changed below.
+		sized := proto.Clone(spec).(*api.VolumeSpec)
+		sized.Cos = api.CosType_NONE
+		sized.IoProfile = api.IoProfile_IO_PROFILE_SEQUENTIAL
+		var err error
+		if resize, err = common.NeedsResize(v, sized); err != nil {
+			return err
+		}
+	}
+	size := sizeGB(v.Spec.Size)
+	if resize {
+		size = sizeGB(spec.Size)
+	}
+	cos := spec.Cos
+	if cos == api.CosType_NONE {
+		cos = v.Spec.Cos
+	}
+	profile := spec.IoProfile
+	if profile == api.IoProfile_IO_PROFILE_SEQUENTIAL {
+		profile = v.Spec.IoProfile
+	}
+	volType, iops := mapCos(v.Spec.Cos, v.Spec.IoProfile, sizeGB(v.Spec.Size))
+	newType, newIops := mapCos(cos, profile, size)
+	if !resize && newType == volType && newIops == iops {
+		return nil
 	}
 
-	devicePath, err := d.ops.DevicePath(*awsVol.VolumeId)
-	if err != nil {
-		return err
+	modSize := int64(0)
+	if resize {
+		modSize = size
 	}
-	cmd := "/sbin/mkfs." + volume.Spec.Format.SimpleString()
-	o, err := exec.Command(cmd, devicePath).Output()
-	if err != nil {
-		dlog.Warnf("Failed to run command %v %v: %v", cmd, devicePath, o)
+	if err := modifier.Modify(ebsID(v), modSize, newType, newIops); err != nil {
 		return err
 	}
-	volume.Format = volume.Spec.Format
-	return d.UpdateVol(volume)
+	if resize {
+		v.Spec.Size = spec.Size
+	}
+	v.Spec.Cos = cos
+	v.Spec.IoProfile = profile
+	if resize && len(v.AttachPath) > 0 {
+		if err := growFilesystem(v); err != nil {
+			dlog.Warnf("Failed to grow filesystem of volume %v: %v", v.Id, err)
+		}
+	}
+	return nil
 }
 
-func (d *Driver) Detach(volumeID string, options map[string]string) error {
-	if err := d.ops.Detach(volumeID); err != nil {
-		return err
+// tag replaces the tags of the EBS volume of v set from its labels with
+// labels.
+func (d *Driver) tag(v *api.Volume, labels map[string]string) error {
+	removed := make(map[string]string)
+	if v.Locator != nil {
+		for k, val := range v.Locator.VolumeLabels {
+			if _, ok := labels[k]; !ok {
+				removed[k] = val
+			}
+		}
 	}
-	volume, err := d.GetVol(volumeID)
-	if err != nil {
-		dlog.Warnf("Volume %s could not be located, attempting to detach anyway", volumeID)
-	} else {
-		volume.DevicePath = ""
-		if err := d.UpdateVol(volume); err != nil {
-			dlog.Warnf("Failed to update volume", volumeID)
+	if len(removed) > 0 {
+		if err := d.ops.RemoveTags(ebsID(v), removed); err != nil {
+			return err
 		}
 	}
+	if len(labels) > 0 {
+		return d.ops.ApplyTags(ebsID(v), labels)
+	}
 	return nil
 }
 
-func (d *Driver) MountedAt(mountpath string) string {
-	return ""
+// Read reads from the attached EBS volume of volumeID.
+func (d *Driver) Read(volumeID string, buf []byte, sz uint64, offset int64) (int64, error) {
+	f, err := d.device(volumeID, os.O_RDONLY)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	if uint64(len(buf)) < sz {
+		sz = uint64(len(buf))
+	}
+	n, err := f.ReadAt(buf[:sz], offset)
+	return int64(n), err
 }
 
-func (d *Driver) Mount(volumeID string, mountpath string, options map[string]string) error {
-	volume, err := d.GetVol(volumeID)
+// Write writes to the attached EBS volume of volumeID.
+func (d *Driver) Write(volumeID string, buf []byte, sz uint64, offset int64) (int64, error) {
+	f, err := d.device(volumeID, os.O_WRONLY)
 	if err != nil {
-		return fmt.Errorf("Failed to locate volume %q", volumeID)
+		return 0, err
+	}
+	defer f.Close()
+	if uint64(len(buf)) < sz {
+		sz = uint64(len(buf))
 	}
-	awsVols, err := d.ops.Inspect([]*string{&volumeID})
+	n, err := f.WriteAt(buf[:sz], offset)
+	return int64(n), err
+}
+
+// Flush commits the writes to the EBS volume of volumeID.
+func (d *Driver) Flush(volumeID string) error {
+	f, err := d.device(volumeID, os.O_WRONLY)
 	if err != nil {
 		return err
 	}
-	if len(awsVols) != 1 {
-		return fmt.Errorf("Failed to inspect volume %v", volumeID)
-	}
+	defer f.Close()
+	return f.Sync()
+}
 
-	awsVol, ok := awsVols[0].(*ec2.Volume)
-	if !ok {
-		return storageops.NewStorageError(storageops.ErrVolInval,
-			"Invalid volume returned by inspect API",
-			fmt.Sprintf("volume to inspect: %s", volumeID))
+// device opens the device of the attached EBS volume of volumeID.
+func (d *Driver) device(volumeID string, flag int) (*os.File, error) {
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return nil, err
 	}
+	devicePath, err := d.ops.DevicePath(ebsID(v))
+	if err != nil {
+		return nil, err
+	}
+	return os.OpenFile(devicePath, flag, 0)
+}
 
-	devicePath, err := d.ops.DevicePath(*awsVol.VolumeId)
+func (d *Driver) fsFreeze(volumeID string, freeze bool) error {
+	v, err := d.GetVol(volumeID)
 	if err != nil {
 		return err
 	}
-	err = syscall.Mount(devicePath, mountpath, volume.Spec.Format.SimpleString(), 0, "")
-	if err != nil {
+	if len(v.AttachPath) == 0 {
+		if !freeze {
+			return nil
+		}
+		return fmt.Errorf("Volume not mounted")
+	}
+	freezeOpt := "-f"
+	if !freeze {
+		freezeOpt = "-u"
+	}
+	if out, err := exec.Command(freezebin, freezeOpt, v.AttachPath[0]).CombinedOutput(); err != nil {
+		return fmt.Errorf("Failed to run %v %v %v: %s (%v)",
+			freezebin, freezeOpt, v.AttachPath[0], out, err)
+	}
+	return nil
+}
+
+// Quiesce freezes the filesystem of volumeID, until Unquiesce or for
+// timeoutSec seconds if it is not zero.
+func (d *Driver) Quiesce(
+	volumeID string,
+	timeoutSec uint64,
+	quiesceID string,
+) error {
+	if err := d.fsFreeze(volumeID, true); err != nil {
 		return err
 	}
+	if timeoutSec > 0 {
+		go func() {
+			time.Sleep(time.Duration(timeoutSec) * time.Second)
+			d.Unquiesce(volumeID)
+		}()
+	}
 	return nil
 }
 
-func (d *Driver) Unmount(volumeID string, mountpath string, options map[string]string) error {
-	// XXX:  determine if valid mount path
-	err := syscall.Unmount(mountpath, 0)
-	return err
+// Unquiesce thaws the filesystem of volumeID.
+func (d *Driver) Unquiesce(volumeID string) error {
+	return d.fsFreeze(volumeID, false)
 }
 
+// Shutdown and cleanup.
 func (d *Driver) Shutdown() {
 	dlog.Printf("%s Shutting down", Name)
 }
-
-func (d *Driver) Set(volumeID string, locator *api.VolumeLocator, spec *api.VolumeSpec) error {
-	return volume.ErrNotSupported
-}
@@ -1,12 +1,21 @@
 package aws
 
 import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"syscall"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/opsworks"
 	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/osdconfig"
+	"github.com/libopenstorage/openstorage/pkg/storageops"
+	aws_ops "github.com/libopenstorage/openstorage/pkg/storageops/aws"
+	"github.com/libopenstorage/openstorage/pkg/storageops/fake"
 	"github.com/libopenstorage/openstorage/volume"
 	"github.com/libopenstorage/openstorage/volume/drivers/test"
 	"github.com/stretchr/testify/require"
@@ -42,9 +51,7 @@ func testRemoveTags(t *testing.T, driver volume.VolumeDriver) {
 	require.True(t, len(tags) == 0, "RemoveTags failed")
 }
 
-func testFreeDevices(t *testing.T, driver volume.VolumeDriver) {
-	d := driver.(*Driver)
-
+func testFreeDevices(t *testing.T, ops storageops.Ops) {
 	deviceNames := []string{"/dev/sda1", "/dev/sdb", "/dev/xvdf", "/dev/xvdg", "/dev/xvdcg"}
 	var blockDeviceMappings []interface{}
 	for i, _ := range deviceNames {
@@ -53,7 +60,7 @@ func testFreeDevices(t *testing.T, driver volume.VolumeDriver) {
 		}
 		blockDeviceMappings = append(blockDeviceMappings, b)
 	}
-	freeDeviceNames, err := d.ops.FreeDevices(blockDeviceMappings, "/dev/sda1")
+	freeDeviceNames, err := ops.FreeDevices(blockDeviceMappings, "/dev/sda1")
 	require.NoError(t, err, "Expected no error")
 	// Free devices : h -> p
 	require.Equal(t, len(freeDeviceNames), 9, "No. of free devices do not match")
@@ -63,7 +70,7 @@ func testFreeDevices(t *testing.T, driver volume.VolumeDriver) {
 	}
 
 	blockDeviceMappings = append(blockDeviceMappings, b)
-	freeDeviceNames, err = d.ops.FreeDevices(blockDeviceMappings, "/dev/sda1")
+	freeDeviceNames, err = ops.FreeDevices(blockDeviceMappings, "/dev/sda1")
 	require.Error(t, err, "Expected an error")
 }
 
@@ -76,9 +83,306 @@ func TestAll(t *testing.T) {
 		t.Fatalf("Failed to initialize Volume Driver: %v", err)
 	}
 
-	testFreeDevices(t, driver)
+	testFreeDevices(t, driver.(*Driver).ops)
 	ctx := test.NewContext(driver)
 	ctx.Filesystem = api.FSType_FS_TYPE_EXT4
 	test.RunShort(t, ctx)
 	testRemoveTags(t, driver)
 }
+
+// ec2Codec translates between EC2 volumes and the disks of the fake
+// provider.
+type ec2Codec struct {
+	zone string
+}
+
+func (c *ec2Codec) Template(template interface{}) (*fake.Disk, error) {
+	v, ok := template.(*ec2.Volume)
+	if !ok {
+		return nil, fmt.Errorf("invalid volume template %v", template)
+	}
+	d := &fake.Disk{Labels: make(map[string]string)}
+	if v.Size != nil {
+		d.Size = *v.Size
+	}
+	if v.VolumeType != nil {
+		d.Type = *v.VolumeType
+	}
+	if v.Iops != nil {
+		d.Iops = *v.Iops
+	}
+	if v.SnapshotId != nil {
+		d.SnapshotID = *v.SnapshotId
+	}
+	if v.Encrypted != nil {
+		d.Encrypted = *v.Encrypted
+	}
+	if v.KmsKeyId != nil {
+		d.KMSKey = *v.KmsKeyId
+	}
+	for _, tag := range v.Tags {
+		d.Labels[*tag.Key] = *tag.Value
+	}
+	return d, nil
+}
+
+func (c *ec2Codec) Disk(d *fake.Disk) interface{} {
+	state := ec2.VolumeStateAvailable
+	v := &ec2.Volume{
+		VolumeId:         &d.ID,
+		AvailabilityZone: &c.zone,
+		Size:             &d.Size,
+		VolumeType:       &d.Type,
+		Iops:             &d.Iops,
+		State:            &state,
+		Encrypted:        &d.Encrypted,
+	}
+	if d.SnapshotID != "" {
+		v.SnapshotId = &d.SnapshotID
+	}
+	if d.KMSKey != "" {
+		v.KmsKeyId = &d.KMSKey
+	}
+	if d.Instance != "" {
+		state = ec2.VolumeStateInUse
+		attached := ec2.VolumeAttachmentStateAttached
+		v.Attachments = []*ec2.VolumeAttachment{{
+			VolumeId:   &d.ID,
+			InstanceId: &d.Instance,
+			Device:     &d.DevicePath,
+			State:      &attached,
+		}}
+	}
+	for k := range d.Labels {
+		key, value := k, d.Labels[k]
+		v.Tags = append(v.Tags, &ec2.Tag{Key: &key, Value: &value})
+	}
+	return v
+}
+
+func (c *ec2Codec) Snapshot(s *fake.Snapshot) interface{} {
+	state := ec2.SnapshotStateCompleted
+	return &ec2.Snapshot{
+		SnapshotId: &s.ID,
+		VolumeId:   &s.DiskID,
+		VolumeSize: &s.Size,
+		State:      &state,
+	}
+}
+
+func (c *ec2Codec) ID(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case *ec2.Volume:
+		return *v.VolumeId, nil
+	case *ec2.Snapshot:
+		return *v.SnapshotId, nil
+	}
+	return "", fmt.Errorf("invalid type: %v given to GetDeviceID", v)
+}
+
+func TestFake(t *testing.T) {
+	dir, err := ioutil.TempDir("", "aws-disks")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	loop := fake.LoopAvailable()
+	cloud := fake.NewCloud(dir, loop)
+	defer cloud.Close()
+
+	md := &Metadata{zone: "fake-1a", instance: "i-fake"}
+	driver := newDriver(cloud.Ops(md.instance, &ec2Codec{zone: md.zone}), md)
+	testFreeDevices(t, aws_ops.NewEc2Storage(md.instance, nil))
+	testRemoveTags(t, driver)
+	testEncryption(t, driver, cloud)
+	testSetLabels(t, driver, cloud)
+	testModify(t, driver, cloud)
+	testRestore(t, driver, cloud)
+	if !loop {
+		t.Skip("loop devices are not available, skipping mount tests")
+	}
+	ctx := test.NewContext(driver)
+	ctx.Filesystem = api.FSType_FS_TYPE_EXT4
+	test.RunShort(t, ctx)
+	testGrow(t, driver)
+}
+
+func TestMapCos(t *testing.T) {
+	for _, tc := range []struct {
+		cos     api.CosType
+		profile api.IoProfile
+		size    int64
+		volType string
+		iops    int64
+	}{
+		{api.CosType_LOW, api.IoProfile_IO_PROFILE_RANDOM, 10, opsworks.VolumeTypeGp2, 0},
+		{api.CosType_LOW, api.IoProfile_IO_PROFILE_SEQUENTIAL, 10, opsworks.VolumeTypeGp2, 0},
+		{api.CosType_LOW, api.IoProfile_IO_PROFILE_SEQUENTIAL, 500, volumeTypeSt1, 0},
+		{api.CosType_HIGH, api.IoProfile_IO_PROFILE_SEQUENTIAL, 500, opsworks.VolumeTypeIo1, 20000},
+		{api.CosType_MEDIUM, api.IoProfile_IO_PROFILE_RANDOM, 100, opsworks.VolumeTypeIo1, 5000},
+		{api.CosType_HIGH, api.IoProfile_IO_PROFILE_RANDOM, 1, opsworks.VolumeTypeIo1, minIops},
+		{api.CosType_LOW, api.IoProfile_IO_PROFILE_DB, 1000, opsworks.VolumeTypeIo1, 10000},
+	} {
+		volType, iops := mapCos(tc.cos, tc.profile, tc.size)
+		require.Equal(t, tc.volType, volType, "%+v", tc)
+		require.Equal(t, tc.iops, iops, "%+v", tc)
+	}
+}
+
+func create(t *testing.T, d *Driver, labels map[string]string, spec *api.VolumeSpec) string {
+	id, err := d.Create(&api.VolumeLocator{Name: "vol", VolumeLabels: labels}, nil, spec)
+	require.NoError(t, err)
+	return id
+}
+
+func testEncryption(t *testing.T, d *Driver, cloud *fake.Cloud) {
+	require.NoError(t, d.configure(&osdconfig.ClusterConfig{
+		Secrets: &osdconfig.SecretsConfig{Aws: &osdconfig.AWSConfig{AwsCmk: "cmk-1"}},
+	}))
+	defer d.configure(nil)
+
+	id := create(t, d, nil, &api.VolumeSpec{Size: 1 << 30, Encrypted: true})
+	defer d.Delete(id)
+	disk, err := cloud.Disk(id)
+	require.NoError(t, err)
+	require.True(t, disk.Encrypted, "volume is not encrypted")
+	require.Equal(t, "cmk-1", disk.KMSKey)
+}
+
+func testSetLabels(t *testing.T, d *Driver, cloud *fake.Cloud) {
+	id := create(t, d, map[string]string{"a": "1", "b": "2"}, &api.VolumeSpec{Size: 1 << 30})
+	defer d.Delete(id)
+
+	labels := map[string]string{"b": "3", "c": "4"}
+	require.NoError(t, d.Set(id, &api.VolumeLocator{Name: "vol", VolumeLabels: labels}, nil))
+	disk, err := cloud.Disk(id)
+	require.NoError(t, err)
+	require.Equal(t, labels, disk.Labels)
+	v, err := d.GetVol(id)
+	require.NoError(t, err)
+	require.Equal(t, labels, v.Locator.VolumeLabels)
+}
+
+func testModify(t *testing.T, d *Driver, cloud *fake.Cloud) {
+	spec := &api.VolumeSpec{Size: 10 << 30, Cos: api.CosType_LOW}
+	id := create(t, d, nil, spec)
+	defer d.Delete(id)
+	disk, err := cloud.Disk(id)
+	require.NoError(t, err)
+	require.Equal(t, opsworks.VolumeTypeGp2, disk.Type)
+
+	spec.Cos = api.CosType_HIGH
+	require.NoError(t, d.Set(id, nil, spec))
+	disk, err = cloud.Disk(id)
+	require.NoError(t, err)
+	require.Equal(t, opsworks.VolumeTypeIo1, disk.Type)
+	require.Equal(t, int64(500), disk.Iops)
+
+	spec.Size = 20 << 30
+	require.NoError(t, d.Set(id, nil, spec))
+	disk, err = cloud.Disk(id)
+	require.NoError(t, err)
+	require.Equal(t, int64(20), disk.Size)
+	require.Equal(t, int64(1000), disk.Iops)
+	v, err := d.GetVol(id)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20<<30), v.Spec.Size)
+	require.Equal(t, api.CosType_HIGH, v.Spec.Cos)
+
+	// Resizing without a class of service keeps the current one.
+	require.NoError(t, d.Set(id, nil, &api.VolumeSpec{Size: 30 << 30}))
+	disk, err = cloud.Disk(id)
+	require.NoError(t, err)
+	require.Equal(t, int64(30), disk.Size)
+	require.Equal(t, opsworks.VolumeTypeIo1, disk.Type)
+	require.Equal(t, int64(1500), disk.Iops)
+	v, err = d.GetVol(id)
+	require.NoError(t, err)
+	require.Equal(t, api.CosType_HIGH, v.Spec.Cos)
+
+	spec.Size = 10 << 30
+	require.Error(t, d.Set(id, nil, spec), "volumes cannot shrink")
+}
+
+func testRestore(t *testing.T, d *Driver, cloud *fake.Cloud) {
+	id := create(t, d, map[string]string{"a": "1"}, &api.VolumeSpec{Size: 1 << 30})
+	defer d.Delete(id)
+	write := func(data string) {
+		_, err := d.Attach(id, nil)
+		require.NoError(t, err)
+		_, err = d.Write(id, []byte(data), uint64(len(data)), 4096)
+		require.NoError(t, err)
+		require.NoError(t, d.Flush(id))
+		require.NoError(t, d.Detach(id, nil))
+	}
+
+	write("before")
+	snapID, err := d.Snapshot(id, true, &api.VolumeLocator{Name: "snap"})
+	require.NoError(t, err)
+	defer d.Delete(snapID)
+	_, err = d.Attach(snapID, nil)
+	require.Error(t, err, "snapshots cannot be attached")
+	write("after!")
+
+	require.NoError(t, d.Restore(id, snapID))
+	_, err = cloud.Disk(id)
+	require.Error(t, err, "restored volume was not deleted")
+	v, err := d.GetVol(id)
+	require.NoError(t, err)
+	restored := ebsID(v)
+	require.NotEqual(t, id, restored)
+	disk, err := cloud.Disk(restored)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"a": "1"}, disk.Labels)
+
+	vols, err := d.Inspect([]string{id})
+	require.NoError(t, err)
+	require.Len(t, vols, 1)
+	require.Equal(t, api.VolumeStatus_VOLUME_STATUS_UP, vols[0].Status)
+
+	_, err = d.Attach(id, nil)
+	require.NoError(t, err)
+	buf := make([]byte, len("before"))
+	_, err = d.Read(id, buf, uint64(len(buf)), 4096)
+	require.NoError(t, err)
+	require.Equal(t, "before", string(buf))
+	require.NoError(t, d.Detach(id, nil))
+
+	require.NoError(t, d.Delete(id))
+	_, err = cloud.Disk(restored)
+	require.Error(t, err, "EBS volume of deleted volume still exists")
+}
+
+// testGrow resizes a mounted volume and checks its filesystem grows.
+func testGrow(t *testing.T, d *Driver) {
+	spec := &api.VolumeSpec{Size: 1 << 30, Format: api.FSType_FS_TYPE_EXT4}
+	id := create(t, d, nil, spec)
+	defer d.Delete(id)
+	mountpath, err := ioutil.TempDir("", "aws-mount")
+	require.NoError(t, err)
+	defer os.Remove(mountpath)
+	_, err = d.Attach(id, nil)
+	require.NoError(t, err)
+	defer d.Detach(id, nil)
+	require.NoError(t, d.Mount(id, mountpath, nil))
+	defer d.Unmount(id, mountpath, nil)
+
+	blocks := func() uint64 {
+		var st syscall.Statfs_t
+		require.NoError(t, syscall.Statfs(mountpath, &st))
+		return st.Blocks * uint64(st.Bsize)
+	}
+	before := blocks()
+	spec.Size = 2 << 30
+	require.NoError(t, d.Set(id, nil, spec))
+	v, err := d.GetVol(id)
+	require.NoError(t, err)
+	f, err := os.Open(v.DevicePath)
+	require.NoError(t, err)
+	size, err := f.Seek(0, io.SeekEnd)
+	f.Close()
+	require.NoError(t, err)
+	require.Equal(t, int64(2<<30), size)
+	if blocks() <= before {
+		t.Skip("online resize is not permitted, skipping filesystem grow check")
+	}
+	require.True(t, blocks() > before+(512<<20), "filesystem did not grow")
+}
@@ -0,0 +1,390 @@
+package azure
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/proto/time"
+	"github.com/libopenstorage/openstorage/pkg/storageops"
+	azure_ops "github.com/libopenstorage/openstorage/pkg/storageops/azure"
+	"github.com/libopenstorage/openstorage/volume"
+	"github.com/libopenstorage/openstorage/volume/drivers/common"
+	"github.com/pborman/uuid"
+	"github.com/portworx/kvdb"
+	"go.pedge.io/dlog"
+)
+
+const (
+	// Name of the driver
+	Name = "azure"
+	// Type of the driver
+	Type = api.DriverType_DRIVER_TYPE_BLOCK
+	// ResourceManagerEndpoint overrides the Azure resource manager endpoint.
+	ResourceManagerEndpoint = "AZURE_RESOURCE_MANAGER_ENDPOINT"
+	// ActiveDirectoryEndpoint overrides the Azure Active Directory endpoint.
+	ActiveDirectoryEndpoint = "AZURE_AD_ENDPOINT"
+	// MetadataEndpoint overrides the instance metadata service endpoint.
+	MetadataEndpoint = "AZURE_METADATA_ENDPOINT"
+	// DeviceDir overrides the directory with the data disk devices by LUN.
+	DeviceDir = "AZURE_DEVICE_DIR"
+)
+
+// Driver implements VolumeDriver interface
+type Driver struct {
+	volume.StatsDriver
+	volume.StoreEnumerator
+	volume.IODriver
+	volume.QuiesceDriver
+	volume.CredsDriver
+	volume.CloudBackupDriver
+	ops    storageops.Ops
+	states *common.StateMachine
+}
+
+// Init azure volume driver. The instance details and credentials are read
+// from params or the environment and the instance details default to those
+// of the instance metadata service.
+func Init(params map[string]string) (volume.VolumeDriver, error) {
+	cfg := azure_ops.Config{}
+	for key, val := range map[string]*string{
+		azure_ops.EnvSubscriptionID: &cfg.SubscriptionID,
+		azure_ops.EnvResourceGroup:  &cfg.ResourceGroup,
+		azure_ops.EnvInstanceName:   &cfg.InstanceName,
+		azure_ops.EnvLocation:       &cfg.Location,
+		azure_ops.EnvTenantID:       &cfg.TenantID,
+		azure_ops.EnvClientID:       &cfg.ClientID,
+		azure_ops.EnvClientSecret:   &cfg.ClientSecret,
+		ResourceManagerEndpoint:     &cfg.ResourceManagerEndpoint,
+		ActiveDirectoryEndpoint:     &cfg.ActiveDirectoryEndpoint,
+		MetadataEndpoint:            &cfg.MetadataEndpoint,
+		DeviceDir:                   &cfg.DeviceDir,
+	} {
+		*val = param(key, params)
+	}
+	ops, err := azure_ops.NewClientFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	store := common.NewDefaultStoreEnumerator(Name, kvdb.Instance())
+	d := &Driver{
+		StatsDriver:       volume.StatsNotSupported,
+		StoreEnumerator:   store,
+		IODriver:          volume.IONotSupported,
+		QuiesceDriver:     volume.QuiesceNotSupported,
+		CredsDriver:       volume.CredsNotSupported,
+		CloudBackupDriver: volume.CloudBackupNotSupported,
+		ops:               ops,
+		states:            common.NewStateMachine(store),
+	}
+	if err := common.NewReconciler(d.StoreEnumerator, common.StorageOpsProbe(d.ops), d.purge).Reconcile(); err != nil {
+		dlog.Warnf("Failed to recover volumes: %v", err)
+	}
+	return d, nil
+}
+
+// param retrieves key from params or env var.
+func param(key string, params map[string]string) string {
+	if val, ok := params[key]; ok {
+		return val
+	}
+	return os.Getenv(key)
+}
+
+// mapCos translates a CoS specified in spec to a disk SKU.
+func mapCos(cos uint32) string {
+	switch {
+	case cos < 2:
+		return azure_ops.SkuStandardLRS
+	case cos < 7:
+		return azure_ops.SkuStandardSSDLRS
+	default:
+		return azure_ops.SkuPremiumLRS
+	}
+}
+
+// sizeGB translates a spec size in bytes to whole GiB, rounding up.
+func sizeGB(size uint64) int32 {
+	const gb = 1024 * 1024 * 1024
+	sz := int32((size + gb - 1) / gb)
+	if sz == 0 {
+		sz = 1
+	}
+	return sz
+}
+
+// Name returns the name of the driver
+func (d *Driver) Name() string {
+	return Name
+}
+
+// Type returns the type of the driver
+func (d *Driver) Type() api.DriverType {
+	return Type
+}
+
+// Status returns the current status
+func (d *Driver) Status() [][2]string {
+	return [][2]string{}
+}
+
+// Create creates a new volume on a managed disk. Volumes with a parent are
+// copied from the snapshot or disk of the parent, others are formatted.
+func (d *Driver) Create(
+	locator *api.VolumeLocator,
+	source *api.Source,
+	spec *api.VolumeSpec,
+) (string, error) {
+	disk := &azure_ops.Disk{
+		Name: strings.TrimSuffix(uuid.New(), "\n"),
+		Sku:  &azure_ops.Sku{Name: mapCos(uint32(spec.Cos))},
+		Properties: &azure_ops.DiskProperties{
+			DiskSizeGB: sizeGB(spec.Size),
+		},
+	}
+	copied := source != nil && source.Parent != ""
+	if copied {
+		sourceID, err := d.sourceID(source.Parent)
+		if err != nil {
+			return "", err
+		}
+		disk.Properties.CreationData = &azure_ops.CreationData{
+			CreateOption:     azure_ops.CreateOptionCopy,
+			SourceResourceID: sourceID,
+		}
+	}
+	if spec.Format == api.FSType_FS_TYPE_NONE {
+		spec.Format = api.FSType_FS_TYPE_EXT4
+	}
+
+	v := common.NewVolume(disk.Name, spec.Format, locator, source, spec)
+	err := d.states.Create(v, func(v *api.Volume) error {
+		if _, err := d.ops.Create(disk, locator.VolumeLabels); err != nil {
+			return err
+		}
+		if copied {
+			return nil
+		}
+		if err := d.format(v); err != nil {
+			if delErr := d.ops.Delete(v.Id); delErr != nil {
+				dlog.Warnf("Failed to delete disk %v after failed format: %v",
+					v.Id, delErr)
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return v.Id, nil
+}
+
+// sourceID returns the source resource of a volume copied from parent.
+// Snapshots are passed by name, disks by resource ID.
+func (d *Driver) sourceID(parent string) (string, error) {
+	v, err := d.GetVol(parent)
+	if err != nil {
+		return "", err
+	}
+	if v.IsSnapshot() {
+		return parent, nil
+	}
+	disks, err := d.ops.Inspect([]*string{&parent})
+	if err != nil {
+		return "", err
+	}
+	if len(disks) != 1 {
+		return "", fmt.Errorf("Failed to inspect disk %v", parent)
+	}
+	disk, ok := disks[0].(*azure_ops.Disk)
+	if !ok {
+		return "", storageops.NewStorageError(storageops.ErrVolInval,
+			"Invalid disk returned by inspect API",
+			fmt.Sprintf("disk to inspect: %s", parent))
+	}
+	return disk.ID, nil
+}
+
+// format attaches the disk of v, makes a filesystem on it and detaches it.
+func (d *Driver) format(v *api.Volume) error {
+	devicePath, err := d.ops.Attach(v.Id)
+	if err != nil {
+		return err
+	}
+	dlog.Infof("azure preparing volume %s...", v.Id)
+	cmd := "/sbin/mkfs." + v.Spec.Format.SimpleString()
+	o, err := exec.Command(cmd, devicePath).CombinedOutput()
+	if err != nil {
+		dlog.Warnf("Failed to run command %v %v: %s", cmd, devicePath, o)
+	}
+	if detachErr := d.ops.Detach(v.Id); detachErr != nil && err == nil {
+		err = detachErr
+	}
+	return err
+}
+
+// Delete deletes the disk or snapshot of a volume.
+func (d *Driver) Delete(volumeID string) error {
+	return d.states.Transition(volumeID, common.OpDelete, d.purge)
+}
+
+// purge deletes the disk or snapshot backing v.
+func (d *Driver) purge(v *api.Volume) error {
+	if v.IsSnapshot() {
+		return d.ops.SnapshotDelete(v.Id)
+	}
+	return d.ops.Delete(v.Id)
+}
+
+// Snapshot takes a snapshot of the disk of volumeID. Snapshots cannot be
+// attached, new volumes are created from them instead.
+func (d *Driver) Snapshot(
+	volumeID string,
+	readonly bool,
+	locator *api.VolumeLocator,
+) (string, error) {
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return "", err
+	}
+	resp, err := d.ops.Snapshot(volumeID, readonly)
+	if err != nil {
+		return "", err
+	}
+	snapID, err := d.ops.GetDeviceID(resp)
+	if err != nil {
+		return "", err
+	}
+
+	v.Id = snapID
+	v.Source = &api.Source{Parent: volumeID}
+	v.Locator = locator
+	v.Ctime = prototime.Now()
+	v.Readonly = true
+	v.State = api.VolumeState_VOLUME_STATE_AVAILABLE
+	v.AttachedOn = ""
+	v.AttachPath = nil
+	v.DevicePath = ""
+	if err := d.CreateVol(v); err != nil {
+		if delErr := d.ops.SnapshotDelete(snapID); delErr != nil {
+			dlog.Warnf("Failed to delete snapshot %v: %v", snapID, delErr)
+		}
+		return "", err
+	}
+	return v.Id, nil
+}
+
+// Restore is not supported, new volumes can be created from a snapshot
+// instead.
+func (d *Driver) Restore(volumeID string, snapID string) error {
+	return volume.ErrNotSupported
+}
+
+// Attach attaches the disk of volumeID to this instance.
+func (d *Driver) Attach(
+	volumeID string,
+	attachOptions map[string]string,
+) (string, error) {
+	err := d.states.Transition(volumeID, common.OpAttach, func(v *api.Volume) error {
+		if v.IsSnapshot() {
+			return fmt.Errorf("Snapshot %v cannot be attached", volumeID)
+		}
+		devicePath, err := d.ops.Attach(volumeID)
+		if err != nil {
+			return err
+		}
+		v.DevicePath = devicePath
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return "", err
+	}
+	return v.DevicePath, nil
+}
+
+// Detach detaches the disk of volumeID from this instance.
+func (d *Driver) Detach(volumeID string, options map[string]string) error {
+	return d.states.Transition(volumeID, common.OpDetach, func(v *api.Volume) error {
+		if err := d.ops.Detach(volumeID); err != nil {
+			if se, ok := err.(*storageops.StorageError); !ok ||
+				se.Code != storageops.ErrVolDetached {
+				return err
+			}
+		}
+		v.DevicePath = ""
+		return nil
+	})
+}
+
+// MountedAt returns the volume mounted at mountpath.
+func (d *Driver) MountedAt(mountpath string) string {
+	return ""
+}
+
+// Mount mounts the attached disk of volumeID at mountpath.
+func (d *Driver) Mount(volumeID string, mountpath string, options map[string]string) error {
+	return d.states.Transition(volumeID, common.OpMount, func(v *api.Volume) error {
+		if len(v.AttachPath) > 0 && len(v.AttachPath[0]) > 0 {
+			return fmt.Errorf("Volume %q already mounted at %q", volumeID, v.AttachPath[0])
+		}
+		devicePath, err := d.ops.DevicePath(volumeID)
+		if err != nil {
+			return err
+		}
+		if err := syscall.Mount(devicePath, mountpath, v.Format.SimpleString(), 0, ""); err != nil {
+			return fmt.Errorf("Failed to mount %v at %v: %v", devicePath, mountpath, err)
+		}
+		v.AttachPath = []string{mountpath}
+		return nil
+	})
+}
+
+// Unmount unmounts volumeID.
+func (d *Driver) Unmount(volumeID string, mountpath string, options map[string]string) error {
+	return d.states.Transition(volumeID, common.OpUnmount, func(v *api.Volume) error {
+		if len(v.AttachPath) == 0 || len(v.AttachPath[0]) == 0 {
+			return fmt.Errorf("Device %v not mounted", volumeID)
+		}
+		if err := syscall.Unmount(v.AttachPath[0], 0); err != nil {
+			return err
+		}
+		v.AttachPath = nil
+		return nil
+	})
+}
+
+// Set updates the locator of volumeID and the tags of its disk. The spec
+// of a disk cannot be changed.
+func (d *Driver) Set(volumeID string, locator *api.VolumeLocator, spec *api.VolumeSpec) error {
+	if spec != nil {
+		return volume.ErrNotSupported
+	}
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return err
+	}
+	if locator == nil {
+		return nil
+	}
+	if !v.IsSnapshot() && len(locator.VolumeLabels) > 0 {
+		if err := d.ops.ApplyTags(volumeID, locator.VolumeLabels); err != nil {
+			return err
+		}
+	}
+	v.Locator = locator
+	return d.UpdateVol(v)
+}
+
+// Shutdown and cleanup.
+func (d *Driver) Shutdown() {
+	dlog.Printf("%s Shutting down", Name)
+}
@@ -0,0 +1,84 @@
+package azure
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/libopenstorage/openstorage/api"
+	azure_ops "github.com/libopenstorage/openstorage/pkg/storageops/azure"
+	"github.com/libopenstorage/openstorage/pkg/storageops/azure/azuretest"
+	_ "github.com/libopenstorage/openstorage/volume/drivers/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAll(t *testing.T) {
+	dir, err := ioutil.TempDir("", "azure-devices")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	s := azuretest.NewServer(dir)
+	defer s.Close()
+
+	cfg := s.Config()
+	d, err := Init(map[string]string{
+		azure_ops.EnvSubscriptionID: cfg.SubscriptionID,
+		azure_ops.EnvResourceGroup:  cfg.ResourceGroup,
+		azure_ops.EnvInstanceName:   cfg.InstanceName,
+		azure_ops.EnvLocation:       cfg.Location,
+		azure_ops.EnvTenantID:       cfg.TenantID,
+		azure_ops.EnvClientID:       cfg.ClientID,
+		azure_ops.EnvClientSecret:   cfg.ClientSecret,
+		ResourceManagerEndpoint:     cfg.ResourceManagerEndpoint,
+		ActiveDirectoryEndpoint:     cfg.ActiveDirectoryEndpoint,
+		MetadataEndpoint:            cfg.MetadataEndpoint,
+		DeviceDir:                   cfg.DeviceDir,
+	})
+	require.NoError(t, err)
+
+	volID, err := d.Create(
+		&api.VolumeLocator{Name: "foo", VolumeLabels: map[string]string{"oh": "create"}},
+		nil,
+		&api.VolumeSpec{Size: 1024 * 1024 * 1024, Cos: api.CosType_HIGH},
+	)
+	require.NoError(t, err)
+	vols, err := d.Inspect([]string{volID})
+	require.NoError(t, err)
+	require.Len(t, vols, 1)
+	require.Equal(t, api.VolumeState_VOLUME_STATE_AVAILABLE, vols[0].State)
+	require.Equal(t, api.FSType_FS_TYPE_EXT4, vols[0].Format)
+
+	require.NoError(t, d.Set(volID,
+		&api.VolumeLocator{Name: "foo", VolumeLabels: map[string]string{"oh": "set"}}, nil))
+	tags, err := d.(*Driver).ops.Tags(volID)
+	require.NoError(t, err)
+	require.Equal(t, "set", tags["oh"])
+
+	devicePath, err := d.Attach(volID, nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, devicePath)
+	require.Error(t, d.Delete(volID), "attached volumes cannot be deleted")
+	require.NoError(t, d.Detach(volID, nil))
+
+	snapID, err := d.Snapshot(volID, true, &api.VolumeLocator{Name: "snap"})
+	require.NoError(t, err)
+	_, err = d.Attach(snapID, nil)
+	require.Error(t, err, "snapshots cannot be attached")
+
+	// Volumes are copied from snapshots and other volumes.
+	for _, parent := range []string{snapID, volID} {
+		cloneID, err := d.Create(
+			&api.VolumeLocator{Name: "clone-" + parent},
+			&api.Source{Parent: parent},
+			&api.VolumeSpec{Size: 1024 * 1024 * 1024},
+		)
+		require.NoError(t, err)
+		require.NoError(t, d.Delete(cloneID))
+	}
+
+	require.NoError(t, d.Delete(snapID))
+	require.NoError(t, d.Delete(volID))
+	vols, err = d.Inspect([]string{volID})
+	require.NoError(t, err)
+	require.Len(t, vols, 0)
+	d.Shutdown()
+}
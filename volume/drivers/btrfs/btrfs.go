@@ -4,7 +4,9 @@ package btrfs
 
 import (
 	"fmt"
+	"os/exec"
 	"path/filepath"
+	"strconv"
 	"syscall"
 
 	"go.pedge.io/proto/time"
@@ -12,6 +14,7 @@ import (
 	"github.com/docker/docker/daemon/graphdriver"
 	"github.com/docker/docker/daemon/graphdriver/btrfs"
 	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/config"
 	"github.com/libopenstorage/openstorage/pkg/chaos"
 	"github.com/libopenstorage/openstorage/volume"
 	"github.com/libopenstorage/openstorage/volume/drivers/common"
@@ -24,6 +27,7 @@ const (
 	Type      = api.DriverType_DRIVER_TYPE_FILE
 	RootParam = "home"
 	Volumes   = "volumes"
+	btrfsCmd  = "btrfs"
 )
 
 var (
@@ -35,8 +39,11 @@ type driver struct {
 	volume.StoreEnumerator
 	volume.IODriver
 	volume.BlockDriver
-	btrfs graphdriver.Driver
-	root  string
+	volume.StatsDriver
+	*common.Trash
+	states *common.StateMachine
+	btrfs  graphdriver.Driver
+	root   string
 }
 
 func Init(params map[string]string) (volume.VolumeDriver, error) {
@@ -49,10 +56,22 @@ func Init(params map[string]string) (volume.VolumeDriver, error) {
 	if err != nil {
 		return nil, err
 	}
+	store := common.NewDefaultStoreEnumerator(Name, kvdb.Instance())
+	states := common.NewStateMachine(store)
+	trash, err := common.NewTrash(store, states, params,
+		func(v *api.Volume) error {
+			return d.Remove(v.Id)
+		})
+	if err != nil {
+		return nil, err
+	}
 	return &driver{
-		common.NewDefaultStoreEnumerator(Name, kvdb.Instance()),
+		trash.Visible(store),
 		common.IONotSupported,
 		common.BlockNotSupported,
+		common.NewStatsDriver(store, dataPath),
+		trash,
+		states,
 		d,
 		root,
 	}, nil
@@ -70,7 +89,8 @@ func (d *driver) Type() api.DriverType {
 	return Type
 }
 
-// Create a new subvolume. The volume spec is not taken into account.
+// Create a new subvolume. A non-zero size in the spec is enforced with a
+// qgroup limit.
 func (d *driver) Create(
 	locator *api.VolumeLocator,
 	source *api.Source,
@@ -86,67 +106,105 @@ func (d *driver) Create(
 		source,
 		spec,
 	)
-	if err := d.CreateVol(volume); err != nil {
-		return "", err
-	}
-	if err := d.btrfs.Create(volume.Id, "", "", nil); err != nil {
+	if err := d.states.Create(volume, func(v *api.Volume) error {
+		if err := d.btrfs.Create(v.Id, "", "", nil); err != nil {
+			return err
+		}
+		if err := d.populate(v, source, spec); err != nil {
+			d.btrfs.Remove(v.Id)
+			return err
+		}
+		return nil
+	}); err != nil {
 		return "", err
 	}
-	devicePath, err := d.btrfs.Get(volume.Id, "")
+	return volume.Id, nil
+}
+
+// populate sizes and seeds the new subvolume of v.
+func (d *driver) populate(v *api.Volume, source *api.Source, spec *api.VolumeSpec) error {
+	devicePath, err := d.btrfs.Get(v.Id, "")
 	if err != nil {
-		return volume.Id, err
+		return err
 	}
-	volume.DevicePath = devicePath
-	return volume.Id, d.UpdateVol(volume)
+	v.DevicePath = devicePath
+	if spec.Size != 0 {
+		if err := d.resize(v, spec.Size); err != nil {
+			return err
+		}
+	}
+	return common.SeedVolume(source, spec.VolumeLabels,
+		filepath.Join(devicePath, config.DataDir), devicePath)
 }
 
 func (d *driver) Delete(volumeID string) error {
-	if err := d.DeleteVol(volumeID); err != nil {
-		return err
+	if d.Trash.Enabled() {
+		return d.MoveToTrash(volumeID)
 	}
-	chaos.Now(koStrayDelete)
-	return d.btrfs.Remove(volumeID)
+	return d.states.Transition(volumeID, common.OpDelete, func(v *api.Volume) error {
+		chaos.Now(koStrayDelete)
+		return d.btrfs.Remove(v.Id)
+	})
 }
 
 func (d *driver) Mount(volumeID string, mountpath string) error {
-	v, err := d.GetVol(volumeID)
-	if err != nil {
-		return err
-	}
-	if err := syscall.Mount(v.DevicePath, mountpath, v.Format.SimpleString(), syscall.MS_BIND, ""); err != nil {
-		return fmt.Errorf("Failed to mount %v at %v: %v", v.DevicePath, mountpath, err)
-	}
-	v.AttachPath = mountpath
-	return d.UpdateVol(v)
+	return d.states.Transition(volumeID, common.OpMount, func(v *api.Volume) error {
+		if err := syscall.Mount(v.DevicePath, mountpath, v.Format.SimpleString(), syscall.MS_BIND, ""); err != nil {
+			return fmt.Errorf("Failed to mount %v at %v: %v", v.DevicePath, mountpath, err)
+		}
+		v.AttachPath = mountpath
+		return nil
+	})
 }
 
 func (d *driver) Unmount(volumeID string, mountpath string) error {
-	v, err := d.GetVol(volumeID)
-	if err != nil {
-		return err
-	}
-	if v.AttachPath == "" {
-		return fmt.Errorf("Device %v not mounted", volumeID)
-	}
-	if err := syscall.Unmount(v.AttachPath, 0); err != nil {
-		return err
-	}
-	v.AttachPath = ""
-	return d.UpdateVol(v)
+	return d.states.Transition(volumeID, common.OpUnmount, func(v *api.Volume) error {
+		if v.AttachPath == "" {
+			return fmt.Errorf("Device %v not mounted", volumeID)
+		}
+		if err := syscall.Unmount(v.AttachPath, 0); err != nil {
+			return err
+		}
+		v.AttachPath = ""
+		return nil
+	})
 }
 
 func (d *driver) Set(volumeID string, locator *api.VolumeLocator, spec *api.VolumeSpec) error {
-	if spec != nil {
-		return volume.ErrNotSupported
-	}
-	v, err := d.GetVol(volumeID)
-	if err != nil {
+	return d.states.Update(volumeID, func(v *api.Volume) error {
+		if spec != nil {
+			resize, err := common.NeedsResize(v, spec)
+			if err != nil {
+				return err
+			}
+			if resize {
+				if err := d.resize(v, spec.Size); err != nil {
+					return err
+				}
+			}
+		}
+		if locator != nil {
+			v.Locator = locator
+		}
+		return nil
+	})
+}
+
+// resize sets the qgroup limit of the volume subvolume to size bytes.
+func (d *driver) resize(v *api.Volume, size uint64) error {
+	if err := common.CheckCapacity(d.root, size-v.Spec.Size); err != nil {
 		return err
 	}
-	if locator != nil {
-		v.Locator = locator
+	// Enabling quotas on a filesystem that already has them is a no-op.
+	if out, err := exec.Command(btrfsCmd, "quota", "enable", d.root).CombinedOutput(); err != nil {
+		return fmt.Errorf("Failed to enable quotas on %s: %s (%v)", d.root, out, err)
 	}
-	return d.UpdateVol(v)
+	limit := strconv.FormatUint(size, 10)
+	if out, err := exec.Command(btrfsCmd, "qgroup", "limit", limit, v.DevicePath).CombinedOutput(); err != nil {
+		return fmt.Errorf("Failed to resize volume %s: %s (%v)", v.Id, out, err)
+	}
+	v.Spec.Size = size
+	return nil
 }
 
 // Snapshot create new subvolume from volume
@@ -164,23 +222,24 @@ func (d *driver) Snapshot(volumeID string, readonly bool, locator *api.VolumeLoc
 	vols[0].Locator = locator
 	vols[0].Ctime = prototime.Now()
 
-	if err := d.CreateVol(vols[0]); err != nil {
-		return "", err
-	}
-	chaos.Now(koStrayCreate)
-	err = d.btrfs.Create(snapID, volumeID, "", nil)
-	if err != nil {
+	if err := d.states.Create(vols[0], func(v *api.Volume) error {
+		chaos.Now(koStrayCreate)
+		return d.btrfs.Create(v.Id, volumeID, "", nil)
+	}); err != nil {
 		return "", err
 	}
 	return vols[0].Id, nil
 }
 
-func (d *driver) Stats(volumeID string) (*api.Stats, error) {
+func (d *driver) Alerts(volumeID string) (*api.Alerts, error) {
 	return nil, nil
 }
 
-func (d *driver) Alerts(volumeID string) (*api.Alerts, error) {
-	return nil, nil
+func (d *driver) Shutdown() {
+	d.Trash.Stop()
 }
 
-func (d *driver) Shutdown() {}
+// dataPath returns the subvolume of v.
+func dataPath(v *api.Volume) (string, error) {
+	return v.DevicePath, nil
+}
@@ -3,6 +3,7 @@ package buse
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"path"
@@ -38,8 +39,10 @@ type driver struct {
 	volume.QuiesceDriver
 	volume.CredsDriver
 	volume.CloudBackupDriver
+	*common.Trash
 	buseDevices map[string]*buseDev
 	cl          cluster.ClusterListener
+	states      *common.StateMachine
 }
 
 type clusterListener struct {
@@ -61,6 +64,22 @@ func (d *buseDev) WriteAt(b []byte, off int64) (n int, err error) {
 	return d.f.WriteAt(b, off)
 }
 
+// Flush commits the backing file to stable storage.
+func (d *buseDev) Flush() error {
+	return d.f.Sync()
+}
+
+// Trim punches a hole in the backing file so the range no longer
+// consumes space.
+func (d *buseDev) Trim(off int64, length int64) error {
+	return syscall.Fallocate(
+		int(d.f.Fd()),
+		FALLOC_FL_PUNCH_HOLE|FALLOC_FL_KEEP_SIZE,
+		off,
+		length,
+	)
+}
+
 func copyFile(source string, dest string) (err error) {
 	sourcefile, err := os.Open(source)
 	if err != nil {
@@ -102,6 +121,13 @@ func Init(params map[string]string) (volume.VolumeDriver, error) {
 		CloudBackupDriver: volume.CloudBackupNotSupported,
 	}
 	inst.buseDevices = make(map[string]*buseDev)
+	inst.states = common.NewStateMachine(inst.StoreEnumerator)
+	trash, err := common.NewTrash(inst.StoreEnumerator, inst.states, params,
+		inst.remove)
+	if err != nil {
+		return nil, err
+	}
+	inst.Trash = trash
 	if err := os.MkdirAll(BuseMountPath, 0744); err != nil {
 		return nil, err
 	}
@@ -111,14 +137,27 @@ func Init(params map[string]string) (volume.VolumeDriver, error) {
 	)
 	if err == nil {
 		for _, info := range volumeInfo {
-			if info.Status == api.VolumeStatus_VOLUME_STATUS_NONE {
+			if err := inst.reconnect(info); err != nil {
+				dlog.Warnf("Could not restore BUSE device for volume %v: %v",
+					info.Id, err)
+				info.Status = api.VolumeStatus_VOLUME_STATUS_DOWN
+			} else if info.Status == api.VolumeStatus_VOLUME_STATUS_NONE ||
+				info.Status == api.VolumeStatus_VOLUME_STATUS_DOWN {
 				info.Status = api.VolumeStatus_VOLUME_STATUS_UP
-				inst.UpdateVol(info)
+			}
+			if err := inst.UpdateVol(info); err != nil {
+				dlog.Warnf("Could not update volume %v: %v", info.Id, err)
 			}
 		}
 	} else {
 		dlog.Println("Could not enumerate Volumes, ", err)
 	}
+	if err := common.NewReconciler(inst.StoreEnumerator, inst.probe, inst.remove).Reconcile(); err != nil {
+		dlog.Warnf("Failed to recover volumes: %v", err)
+	}
+	// The devices of the volumes in the trash are restored above so they
+	// can be purged, but the volumes are no longer listed.
+	inst.StoreEnumerator = inst.Trash.Visible(inst.StoreEnumerator)
 
 	inst.cl = &clusterListener{}
 	c, err := cluster.Inst()
@@ -133,6 +172,67 @@ func Init(params map[string]string) (volume.VolumeDriver, error) {
 	return inst, nil
 }
 
+// reconnect reopens the backing file of an existing volume and maps it to
+// a new NBD device. The device path is updated in place on the volume.
+func (d *driver) reconnect(v *api.Volume) error {
+	buseFile := path.Join(BuseMountPath, v.Id)
+	if _, err := os.Stat(buseFile); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(buseFile, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	bd := &buseDev{
+		file: buseFile,
+		f:    f,
+	}
+	bd.nbd = Create(bd, v.Id, fi.Size())
+	if bd.nbd == nil {
+		f.Close()
+		return fmt.Errorf("Could not create NBD device for %s", v.Id)
+	}
+
+	dev, err := bd.nbd.Connect()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	dlog.Infof("BUSE remapped NBD device %s (size=%v) to block file %s", dev,
+		fi.Size(), buseFile)
+
+	// nbdInit detached all NBD mounts, so the old attach path is stale.
+	v.DevicePath = dev
+	v.AttachPath = nil
+	d.buseDevices[dev] = bd
+	return nil
+}
+
+// probe returns the state of the block file and NBD device backing v.
+func (d *driver) probe(v *api.Volume) (*common.Backing, error) {
+	if _, err := os.Stat(path.Join(BuseMountPath, v.Id)); err != nil {
+		if os.IsNotExist(err) {
+			return &common.Backing{}, nil
+		}
+		return nil, err
+	}
+	b := &common.Backing{Exists: true}
+	if bd, ok := d.buseDevices[v.DevicePath]; ok && bd.nbd.IsConnected() {
+		if _, err := os.Stat(v.DevicePath); err == nil {
+			b.DevicePath = v.DevicePath
+			b.AttachPath = common.MountedPaths(v.AttachPath)
+		}
+	}
+	return b, nil
+}
+
 //
 // These functions below implement the volume driver interface.
 //
@@ -167,44 +267,6 @@ func (d *driver) Create(
 	if spec.Format == api.FSType_FS_TYPE_NONE {
 		return "", fmt.Errorf("Missing volume format: buse")
 	}
-	// Create a file on the local buse path with this UUID.
-	buseFile := path.Join(BuseMountPath, volumeID)
-	f, err := os.Create(buseFile)
-	if err != nil {
-		dlog.Println(err)
-		return "", err
-	}
-
-	if err := f.Truncate(int64(spec.Size)); err != nil {
-		dlog.Println(err)
-		return "", err
-	}
-
-	bd := &buseDev{
-		file: buseFile,
-		f:    f,
-	}
-	nbd := Create(bd, volumeID, int64(spec.Size))
-	bd.nbd = nbd
-
-	dlog.Infof("Connecting to NBD...")
-	dev, err := bd.nbd.Connect()
-	if err != nil {
-		dlog.Println(err)
-		return "", err
-	}
-
-	dlog.Infof("Formatting %s with %v", dev, spec.Format)
-	cmd := "/sbin/mkfs." + spec.Format.SimpleString()
-	o, err := exec.Command(cmd, dev).Output()
-	if err != nil {
-		dlog.Warnf("Failed to run command %v %v: %v", cmd, dev, o)
-		return "", err
-	}
-
-	dlog.Infof("BUSE mapped NBD device %s (size=%v) to block file %s", dev,
-		spec.Size, buseFile)
-
 	v := common.NewVolume(
 		volumeID,
 		spec.Format,
@@ -212,29 +274,78 @@ func (d *driver) Create(
 		source,
 		spec,
 	)
-	v.DevicePath = dev
+	if err := d.states.Create(v, func(v *api.Volume) error {
+		// Create a file on the local buse path with this UUID.
+		buseFile := path.Join(BuseMountPath, volumeID)
+		f, err := os.Create(buseFile)
+		if err != nil {
+			dlog.Println(err)
+			return err
+		}
 
-	d.buseDevices[dev] = bd
+		if err := f.Truncate(int64(spec.Size)); err != nil {
+			dlog.Println(err)
+			return err
+		}
 
-	err = d.CreateVol(v)
-	if err != nil {
+		bd := &buseDev{
+			file: buseFile,
+			f:    f,
+		}
+		nbd := Create(bd, volumeID, int64(spec.Size))
+		bd.nbd = nbd
+
+		dlog.Infof("Connecting to NBD...")
+		dev, err := bd.nbd.Connect()
+		if err != nil {
+			dlog.Println(err)
+			return err
+		}
+
+		dlog.Infof("Formatting %s with %v", dev, spec.Format)
+		cmd := "/sbin/mkfs." + spec.Format.SimpleString()
+		o, err := exec.Command(cmd, dev).Output()
+		if err != nil {
+			dlog.Warnf("Failed to run command %v %v: %v", cmd, dev, o)
+			return err
+		}
+
+		dlog.Infof("BUSE mapped NBD device %s (size=%v) to block file %s", dev,
+			spec.Size, buseFile)
+
+		v.DevicePath = dev
+		d.buseDevices[dev] = bd
+		return nil
+	}); err != nil {
 		return "", err
 	}
-	return v.Id, err
+	return v.Id, nil
 }
 
 func (d *driver) Delete(volumeID string) error {
-	v, err := d.GetVol(volumeID)
+	var err error
+	if d.Trash.Enabled() {
+		err = d.MoveToTrash(volumeID)
+	} else {
+		err = d.states.Transition(volumeID, common.OpDelete, d.remove)
+	}
 	if err != nil {
 		dlog.Println(err)
 		return err
 	}
+	return nil
+}
 
+// remove deletes the block file of v and disconnects its NBD device.
+func (d *driver) remove(v *api.Volume) error {
 	bd, ok := d.buseDevices[v.DevicePath]
+	if !ok && v.Status == api.VolumeStatus_VOLUME_STATUS_DOWN {
+		// The backing file is gone, only the volume record is left.
+		dlog.Infof("BUSE deleting volume %v with no backing file", v.Id)
+		return nil
+	}
 	if !ok {
-		err = fmt.Errorf("Cannot locate a BUSE device for %s", v.DevicePath)
-		dlog.Println(err)
-		return err
+		return fmt.Errorf("Cannot locate a BUSE device for %s", v.DevicePath)
 	}
 
 	// Clean up buse block file and close the NBD connection.
@@ -242,14 +353,8 @@ func (d *driver) Delete(volumeID string) error {
 	bd.f.Close()
 	bd.nbd.Disconnect()
 
-	dlog.Infof("BUSE deleted volume %v at NBD device %s", volumeID,
+	dlog.Infof("BUSE deleted volume %v at NBD device %s", v.Id,
 		v.DevicePath)
-
-	if err := d.DeleteVol(volumeID); err != nil {
-		dlog.Println(err)
-		return err
-	}
-
 	return nil
 }
 
@@ -258,39 +363,35 @@ func (d *driver) MountedAt(mountpath string) string {
 }
 
 func (d *driver) Mount(volumeID string, mountpath string, options map[string]string) error {
-	v, err := d.GetVol(volumeID)
-	if err != nil {
-		return fmt.Errorf("Failed to locate volume %q", volumeID)
-	}
-	if len(v.AttachPath) > 0 && len(v.AttachPath) > 0 {
-		return fmt.Errorf("Volume %q already mounted at %q", volumeID, v.AttachPath[0])
-	}
-	if err := syscall.Mount(v.DevicePath, mountpath, v.Spec.Format.SimpleString(), 0, ""); err != nil {
-		return fmt.Errorf("Failed to mount %v at %v: %v", v.DevicePath, mountpath, err)
-	}
+	return d.states.Transition(volumeID, common.OpMount, func(v *api.Volume) error {
+		if len(v.AttachPath) > 0 && len(v.AttachPath) > 0 {
+			return fmt.Errorf("Volume %q already mounted at %q", volumeID, v.AttachPath[0])
+		}
+		if err := syscall.Mount(v.DevicePath, mountpath, v.Spec.Format.SimpleString(), 0, ""); err != nil {
+			return fmt.Errorf("Failed to mount %v at %v: %v", v.DevicePath, mountpath, err)
+		}
 
-	dlog.Infof("BUSE mounted NBD device %s at %s", v.DevicePath, mountpath)
+		dlog.Infof("BUSE mounted NBD device %s at %s", v.DevicePath, mountpath)
 
-	if v.AttachPath == nil {
-		v.AttachPath = make([]string, 1)
-	}
-	v.AttachPath[0] = mountpath
-	return d.UpdateVol(v)
+		if v.AttachPath == nil {
+			v.AttachPath = make([]string, 1)
+		}
+		v.AttachPath[0] = mountpath
+		return nil
+	})
 }
 
 func (d *driver) Unmount(volumeID string, mountpath string, options map[string]string) error {
-	v, err := d.GetVol(volumeID)
-	if err != nil {
-		return err
-	}
-	if len(v.AttachPath) == 0 || len(v.AttachPath[0]) == 0 {
-		return fmt.Errorf("Device %v not mounted", volumeID)
-	}
-	if err := syscall.Unmount(v.AttachPath[0], 0); err != nil {
-		return err
-	}
-	v.AttachPath = nil
-	return d.UpdateVol(v)
+	return d.states.Transition(volumeID, common.OpUnmount, func(v *api.Volume) error {
+		if len(v.AttachPath) == 0 || len(v.AttachPath[0]) == 0 {
+			return fmt.Errorf("Device %v not mounted", volumeID)
+		}
+		if err := syscall.Unmount(v.AttachPath[0], 0); err != nil {
+			return err
+		}
+		v.AttachPath = nil
+		return nil
+	})
 }
 
 func (d *driver) Snapshot(volumeID string, readonly bool, locator *api.VolumeLocator) (string, error) {
@@ -318,40 +419,154 @@ func (d *driver) Snapshot(volumeID string, readonly bool, locator *api.VolumeLoc
 }
 
 func (d *driver) Restore(volumeID string, snapID string) error {
-	if _, err := d.Inspect([]string{volumeID, snapID}); err != nil {
-		return err
-	}
+	return d.states.Transition(volumeID, common.OpRestore, func(v *api.Volume) error {
+		if _, err := d.GetVol(snapID); err != nil {
+			return err
+		}
 
-	// BUSE does not support restore, so just copy the block files.
-	return copyFile(BuseMountPath+snapID, BuseMountPath+volumeID)
+		// BUSE does not support restore, so just copy the block files.
+		return copyFile(BuseMountPath+snapID, BuseMountPath+volumeID)
+	})
 }
 
 func (d *driver) Set(volumeID string, locator *api.VolumeLocator, spec *api.VolumeSpec) error {
-	if spec != nil {
-		return volume.ErrNotSupported
-	}
 	v, err := d.GetVol(volumeID)
 	if err != nil {
 		return err
 	}
+	if spec != nil {
+		resize, err := common.NeedsResize(v, spec)
+		if err != nil {
+			return err
+		}
+		if resize {
+			if err := d.resize(v, spec.Size); err != nil {
+				return err
+			}
+		}
+	}
 	if locator != nil {
 		v.Locator = locator
 	}
 	return d.UpdateVol(v)
 }
 
+// resize grows the backing file and NBD device of v to size bytes, rounded
+// up to the NBD block size, and then grows the filesystem on it.
+func (d *driver) resize(v *api.Volume, size uint64) error {
+	bd, ok := d.buseDevices[v.DevicePath]
+	if !ok {
+		return fmt.Errorf("Cannot locate a BUSE device for %s", v.DevicePath)
+	}
+	size = (size + BlockSize - 1) / BlockSize * BlockSize
+	if err := common.CheckCapacity(BuseMountPath, size-v.Spec.Size); err != nil {
+		return err
+	}
+
+	if err := bd.f.Truncate(int64(size)); err != nil {
+		return err
+	}
+	err := bd.nbd.Size(int64(size))
+	if err == nil {
+		err = growFilesystem(v)
+	}
+	if err != nil {
+		// The filesystem still ends at the old size, so nothing is lost by
+		// shrinking the device back.
+		if rollbackErr := d.shrink(bd, v.Spec.Size); rollbackErr != nil {
+			dlog.Warnf("Failed to roll back resize of %s: %v", v.DevicePath, rollbackErr)
+		}
+		return err
+	}
+
+	dlog.Infof("BUSE resized NBD device %s to %v", v.DevicePath, size)
+	v.Spec.Size = size
+	return nil
+}
+
+// shrink sets the backing file and NBD device of bd back to size bytes.
+func (d *driver) shrink(bd *buseDev, size uint64) error {
+	if err := bd.nbd.Size(int64(size)); err != nil {
+		return err
+	}
+	return bd.f.Truncate(int64(size))
+}
+
+// growFilesystem grows the filesystem on the device of v to fill it. XFS
+// can only be grown while mounted, so it is mounted temporarily if needed.
+func growFilesystem(v *api.Volume) error {
+	var cmd *exec.Cmd
+	switch v.Spec.Format {
+	case api.FSType_FS_TYPE_EXT4:
+		cmd = exec.Command("/sbin/resize2fs", v.DevicePath)
+	case api.FSType_FS_TYPE_XFS:
+		mountpath := ""
+		if len(v.AttachPath) > 0 {
+			mountpath = v.AttachPath[0]
+		} else {
+			tmp, err := ioutil.TempDir("", "buse")
+			if err != nil {
+				return err
+			}
+			defer os.Remove(tmp)
+			if err := syscall.Mount(v.DevicePath, tmp, v.Spec.Format.SimpleString(), 0, ""); err != nil {
+				return fmt.Errorf("Failed to mount %v at %v: %v", v.DevicePath, tmp, err)
+			}
+			defer syscall.Unmount(tmp, 0)
+			mountpath = tmp
+		}
+		cmd = exec.Command("/usr/sbin/xfs_growfs", mountpath)
+	default:
+		return fmt.Errorf("Cannot grow %v filesystem on %s",
+			v.Spec.Format.SimpleString(), v.DevicePath)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("Failed to grow filesystem on %s: %s (%v)",
+			v.DevicePath, out, err)
+	}
+	return nil
+}
+
 func (d *driver) Attach(volumeID string, attachOptions map[string]string) (string, error) {
-	// Nothing to do on attach.
+	// Nothing to do on attach beyond tracking the volume state.
+	err := d.states.Transition(volumeID, common.OpAttach, func(v *api.Volume) error {
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
 	return path.Join(BuseMountPath, volumeID), nil
 }
 
 func (d *driver) Detach(volumeID string, options map[string]string) error {
-	// Nothing to do on detach.
-	return nil
+	// Nothing to do on detach beyond tracking the volume state.
+	return d.states.Transition(volumeID, common.OpDetach, func(v *api.Volume) error {
+		return nil
+	})
+}
+
+// Flush commits the NBD device and backing file of volumeID.
+func (d *driver) Flush(volumeID string) error {
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return err
+	}
+	bd, ok := d.buseDevices[v.DevicePath]
+	if !ok {
+		return fmt.Errorf("Cannot locate a BUSE device for %s", v.DevicePath)
+	}
+	if bd.nbd != nil && bd.nbd.IsConnected() {
+		// Push out anything the kernel still holds in the page cache.
+		if err := bd.nbd.deviceFile.Sync(); err != nil {
+			return err
+		}
+	}
+	return bd.Flush()
 }
 
 func (d *driver) Shutdown() {
 	dlog.Printf("%s Shutting down", Name)
+	d.Trash.Stop()
 	syscall.Unmount(BuseMountPath, 0)
 }
 
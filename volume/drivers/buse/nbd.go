@@ -8,6 +8,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -51,6 +52,25 @@ const (
 	NBD_REQUEST_MAGIC = 0x25609513
 	NBD_REPLY_MAGIC   = 0x67446698
 	// Do *not* use magics: 0x12560953 0x96744668.
+
+	// Defined in <linux/falloc.h>:
+	FALLOC_FL_KEEP_SIZE  = 0x01
+	FALLOC_FL_PUNCH_HOLE = 0x02
+)
+
+const (
+	// BlockSize is the block size of the NBD devices.
+	BlockSize = 4096
+	// defaultQueueDepth is the number of requests served concurrently.
+	defaultQueueDepth = 16
+	// requestSize is the size of a request header on the wire.
+	requestSize = 28
+	// replySize is the size of a reply header on the wire.
+	replySize = 16
+	// cmdMask masks out the command flags of the request type field.
+	cmdMask = 0xffff
+	// bufferSize is the size of the pooled request buffers.
+	bufferSize = 1 << 20
 )
 
 // ioctl() helper function
@@ -68,18 +88,34 @@ type Device interface {
 	WriteAt(b []byte, off int64) (n int, err error)
 }
 
+// Flusher is an optional interface implemented by a Device that can commit
+// written data to stable storage.
+type Flusher interface {
+	Flush() error
+}
+
+// Trimmer is an optional interface implemented by a Device that can discard
+// a range of blocks.
+type Trimmer interface {
+	Trim(off int64, length int64) error
+}
+
 type request struct {
 	magic  uint32
 	typus  uint32
 	handle uint64
 	from   uint64
 	len    uint32
+	// data is the payload of a write or the buffer for a read.
+	data []byte
 }
 
 type reply struct {
 	magic  uint32
 	error  uint32
 	handle uint64
+	// data is the payload of a successful read.
+	data []byte
 }
 
 // NBD type
@@ -89,13 +125,22 @@ type NBD struct {
 	deviceFile *os.File
 	size       int64
 	socket     int
+	queueDepth int
 	mutex      *sync.Mutex
 }
 
+// socket adapts a socket descriptor to io.ReadWriter.
+type socket int
+
 var (
 	nbdDevices   map[string]*NBD
 	globalMutex  *sync.Mutex
 	shuttingDown bool
+	bufferPool   = sync.Pool{
+		New: func() interface{} {
+			return make([]byte, bufferSize)
+		},
+	}
 )
 
 // Create creates a NBD type interface
@@ -114,6 +159,7 @@ func Create(device Device, id string, size int64) *NBD {
 			size:       size,
 			deviceFile: nil,
 			socket:     0,
+			queueDepth: defaultQueueDepth,
 			mutex:      &sync.Mutex{},
 		}
 
@@ -136,18 +182,20 @@ func (nbd *NBD) GetSize() int64 {
 
 // Size sets the size of the NBD.
 func (nbd *NBD) Size(size int64) (err error) {
-	if err = ioctl(nbd.deviceFile.Fd(), NBD_SET_BLKSIZE, 4096); err != nil {
+	if err = ioctl(nbd.deviceFile.Fd(), NBD_SET_BLKSIZE, BlockSize); err != nil {
 		err = &os.PathError{
 			Op:   nbd.deviceFile.Name(),
 			Path: "ioctl NBD_SET_BLKSIZE",
 			Err:  err,
 		}
-	} else if err = ioctl(nbd.deviceFile.Fd(), NBD_SET_SIZE_BLOCKS, uintptr(size/4096)); err != nil {
+	} else if err = ioctl(nbd.deviceFile.Fd(), NBD_SET_SIZE_BLOCKS, uintptr(size/BlockSize)); err != nil {
 		err = &os.PathError{
 			Op:   nbd.deviceFile.Name(),
 			Path: "ioctl NBD_SET_SIZE_BLOCKS",
 			Err:  err,
 		}
+	} else {
+		nbd.size = size
 	}
 
 	return err
@@ -185,7 +233,7 @@ func (nbd *NBD) Connect() (dev string, err error) {
 	// Setup.
 	if err = nbd.Size(nbd.size); err != nil {
 		// Already set by nbd.Size().
-	} else if err = ioctl(nbd.deviceFile.Fd(), NBD_SET_FLAGS, 1); err != nil {
+	} else if err = ioctl(nbd.deviceFile.Fd(), NBD_SET_FLAGS, nbd.flags()); err != nil {
 		err = &os.PathError{
 			Op:   nbd.deviceFile.Name(),
 			Path: "ioctl NBD_SET_FLAGS",
@@ -230,6 +278,18 @@ func (nbd *NBD) Disconnect() {
 	dlog.Infof("Disconnected device %v", nbd.devicePath)
 }
 
+// flags returns the NBD transmission flags supported by the device.
+func (nbd *NBD) flags() uintptr {
+	flags := uintptr(NBD_FLAG_HAS_FLAGS)
+	if _, ok := nbd.device.(Flusher); ok {
+		flags |= NBD_FLAG_SEND_FLUSH
+	}
+	if _, ok := nbd.device.(Trimmer); ok {
+		flags |= NBD_FLAG_SEND_TRIM
+	}
+	return flags
+}
+
 func (nbd *NBD) connect() {
 	runtime.LockOSThread()
 	defer runtime.UnlockOSThread()
@@ -242,68 +302,237 @@ func (nbd *NBD) connect() {
 
 // Handle block requests.
 func (nbd *NBD) handle() {
-	buf := make([]byte, 2<<19)
-	var x request
+	err := serve(nbd.device, socket(nbd.socket), nbd.queueDepth)
+	if nbd.deviceFile == nil {
+		dlog.Infof("Disconnecting device %s", nbd.devicePath)
+		return
+	}
+	if err != nil {
+		dlog.Errorf("Error serving device %s: %v", nbd.devicePath, err)
+	} else {
+		dlog.Infof("Disconnecting device %s", nbd.devicePath)
+	}
+	nbd.Disconnect()
+}
 
+// Read reads from the socket, retrying on EINTR.
+func (s socket) Read(b []byte) (int, error) {
 	for {
-		bytes, err := syscall.Read(nbd.socket, buf[0:28])
-		if nbd.deviceFile == nil {
-			dlog.Infof("Disconnecting device %s", nbd.devicePath)
-			return
+		n, err := syscall.Read(int(s), b)
+		if err == syscall.EINTR {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		if n == 0 && len(b) > 0 {
+			return 0, io.EOF
+		}
+		return n, nil
+	}
+}
+
+// Write writes all of b to the socket.
+func (s socket) Write(b []byte) (int, error) {
+	written := 0
+	for written < len(b) {
+		n, err := syscall.Write(int(s), b[written:])
+		if err == syscall.EINTR {
+			continue
+		}
+		if err != nil {
+			return written, err
 		}
+		written += n
+	}
+	return written, nil
+}
+
+// serve reads requests from conn and processes up to queueDepth of them
+// concurrently. Replies carry the handle of their request and are sent
+// in completion order. serve returns nil after NBD_CMD_DISC once all
+// outstanding requests have been answered.
+func serve(device Device, conn io.ReadWriter, queueDepth int) error {
+	if queueDepth < 1 {
+		queueDepth = 1
+	}
+	requests := make(chan *request, queueDepth)
+	replies := make(chan *reply, queueDepth)
+
+	var workers sync.WaitGroup
+	for i := 0; i < queueDepth; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for req := range requests {
+				replies <- process(device, req)
+			}
+		}()
+	}
+
+	writeErr := make(chan error, 1)
+	go func() {
+		writeErr <- writeReplies(conn, replies)
+	}()
+
+	err := readRequests(conn, requests)
+	close(requests)
+	workers.Wait()
+	close(replies)
+	if werr := <-writeErr; err == nil {
+		err = werr
+	}
+	return err
+}
 
-		if bytes < 0 || err != nil {
-			dlog.Errorf("Error reading from device %s", nbd.devicePath)
-			nbd.Disconnect()
-			return
+// readRequests reads requests and their write payloads from conn and
+// queues them on requests. It returns nil on NBD_CMD_DISC.
+func readRequests(conn io.Reader, requests chan<- *request) error {
+	hdr := make([]byte, requestSize)
+	for {
+		if _, err := io.ReadFull(conn, hdr); err != nil {
+			return err
+		}
+		req := &request{
+			magic:  binary.BigEndian.Uint32(hdr[0:4]),
+			typus:  binary.BigEndian.Uint32(hdr[4:8]) & cmdMask,
+			handle: binary.BigEndian.Uint64(hdr[8:16]),
+			from:   binary.BigEndian.Uint64(hdr[16:24]),
+			len:    binary.BigEndian.Uint32(hdr[24:28]),
+		}
+		if req.magic != NBD_REQUEST_MAGIC {
+			return fmt.Errorf("invalid request magic %#x", req.magic)
 		}
 
-		x.magic = binary.BigEndian.Uint32(buf)
-		x.typus = binary.BigEndian.Uint32(buf[4:8])
-		x.handle = binary.BigEndian.Uint64(buf[8:16])
-		x.from = binary.BigEndian.Uint64(buf[16:24])
-		x.len = binary.BigEndian.Uint32(buf[24:28])
-
-		switch x.magic {
-		case NBD_REPLY_MAGIC:
-			fallthrough
-		case NBD_REQUEST_MAGIC:
-			switch x.typus {
-			case NBD_CMD_READ:
-				nbd.device.ReadAt(buf[16:16+x.len], int64(x.from))
-				binary.BigEndian.PutUint32(buf[0:4], NBD_REPLY_MAGIC)
-				binary.BigEndian.PutUint32(buf[4:8], 0)
-				syscall.Write(nbd.socket, buf[0:16+x.len])
-			case NBD_CMD_WRITE:
-				n, _ := syscall.Read(nbd.socket, buf[28:28+x.len])
-				for uint32(n) < x.len {
-					m, _ := syscall.Read(nbd.socket, buf[28+n:28+x.len])
-					n += m
-				}
-				nbd.device.WriteAt(buf[28:28+x.len], int64(x.from))
-				binary.BigEndian.PutUint32(buf[0:4], NBD_REPLY_MAGIC)
-				binary.BigEndian.PutUint32(buf[4:8], 0)
-				syscall.Write(nbd.socket, buf[0:16])
-			case NBD_CMD_DISC:
-				dlog.Infof("Disconnecting device %s", nbd.devicePath)
-				nbd.Disconnect()
-				return
-			case NBD_CMD_FLUSH:
-				fallthrough
-			case NBD_CMD_TRIM:
-				binary.BigEndian.PutUint32(buf[0:4], NBD_REPLY_MAGIC)
-				binary.BigEndian.PutUint32(buf[4:8], 1)
-				syscall.Write(nbd.socket, buf[0:16])
-			default:
-				dlog.Errorf("Unknown command received on device %s", nbd.devicePath)
-				nbd.Disconnect()
-				return
+		switch req.typus {
+		case NBD_CMD_READ:
+			req.data = getBuffer(req.len)
+		case NBD_CMD_WRITE:
+			req.data = getBuffer(req.len)
+			if _, err := io.ReadFull(conn, req.data); err != nil {
+				putBuffer(req.data)
+				return err
 			}
+		case NBD_CMD_DISC:
+			return nil
+		case NBD_CMD_FLUSH, NBD_CMD_TRIM:
 		default:
-			dlog.Errorf("Invalid packet command received on device %s", nbd.devicePath)
-			nbd.Disconnect()
-			return
+			return fmt.Errorf("unknown command %d", req.typus)
+		}
+		requests <- req
+	}
+}
+
+// writeReplies sends replies on conn one at a time. After a write error
+// the remaining replies are drained so that workers do not block.
+func writeReplies(conn io.Writer, replies <-chan *reply) error {
+	var err error
+	hdr := make([]byte, replySize)
+	for rep := range replies {
+		if err == nil {
+			binary.BigEndian.PutUint32(hdr[0:4], rep.magic)
+			binary.BigEndian.PutUint32(hdr[4:8], rep.error)
+			binary.BigEndian.PutUint64(hdr[8:16], rep.handle)
+			if _, err = conn.Write(hdr); err == nil && rep.data != nil {
+				_, err = conn.Write(rep.data)
+			}
+		}
+		if rep.data != nil {
+			putBuffer(rep.data)
+		}
+	}
+	return err
+}
+
+// process serves a single request against the device.
+func process(device Device, req *request) *reply {
+	rep := &reply{
+		magic:  NBD_REPLY_MAGIC,
+		handle: req.handle,
+	}
+
+	var err error
+	switch req.typus {
+	case NBD_CMD_READ:
+		var n int
+		n, err = device.ReadAt(req.data, int64(req.from))
+		if err == io.EOF && n == len(req.data) {
+			err = nil
+		} else if err == nil && n < len(req.data) {
+			err = io.ErrUnexpectedEOF
+		}
+		if err == nil {
+			rep.data = req.data
+		} else {
+			putBuffer(req.data)
 		}
+	case NBD_CMD_WRITE:
+		var n int
+		n, err = device.WriteAt(req.data, int64(req.from))
+		if err == nil && n < len(req.data) {
+			err = io.ErrShortWrite
+		}
+		putBuffer(req.data)
+	case NBD_CMD_FLUSH:
+		err = flush(device)
+	case NBD_CMD_TRIM:
+		err = trim(device, int64(req.from), int64(req.len))
+	}
+
+	if err != nil {
+		dlog.Errorf("Request %d (type %d, offset %d, length %d) failed: %v",
+			req.handle, req.typus, req.from, req.len, err)
+		rep.error = errno(err)
+	}
+	return rep
+}
+
+// flush commits the device to stable storage.
+func flush(device Device) error {
+	f, ok := device.(Flusher)
+	if !ok {
+		return syscall.EOPNOTSUPP
+	}
+	return f.Flush()
+}
+
+// trim discards length bytes of the device starting at off.
+func trim(device Device, off int64, length int64) error {
+	t, ok := device.(Trimmer)
+	if !ok {
+		return syscall.EOPNOTSUPP
+	}
+	return t.Trim(off, length)
+}
+
+// getBuffer returns a buffer of length size, from the pool if it fits.
+func getBuffer(size uint32) []byte {
+	if size > bufferSize {
+		return make([]byte, size)
+	}
+	return bufferPool.Get().([]byte)[:size]
+}
+
+// putBuffer returns a buffer obtained from getBuffer to the pool.
+func putBuffer(b []byte) {
+	if cap(b) == bufferSize {
+		bufferPool.Put(b[:bufferSize])
+	}
+}
+
+// errno maps an error to the errno value sent in an NBD reply.
+func errno(err error) uint32 {
+	switch e := err.(type) {
+	case nil:
+		return 0
+	case syscall.Errno:
+		return uint32(e)
+	case *os.PathError:
+		return errno(e.Err)
+	case *os.SyscallError:
+		return errno(e.Err)
+	default:
+		return uint32(syscall.EIO)
 	}
 }
 
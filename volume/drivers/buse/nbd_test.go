@@ -0,0 +1,187 @@
+package buse
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testBlockSize  = 4096
+	testDeviceSize = 16 << 20
+)
+
+// memDevice is an in-memory Device that sleeps on every access to
+// simulate disk latency.
+type memDevice struct {
+	data    []byte
+	latency time.Duration
+	err     error
+}
+
+func (d *memDevice) ReadAt(b []byte, off int64) (int, error) {
+	time.Sleep(d.latency)
+	if d.err != nil {
+		return 0, d.err
+	}
+	return copy(b, d.data[off:]), nil
+}
+
+func (d *memDevice) WriteAt(b []byte, off int64) (int, error) {
+	time.Sleep(d.latency)
+	if d.err != nil {
+		return 0, d.err
+	}
+	return copy(d.data[off:], b), nil
+}
+
+func TestServeReadWrite(t *testing.T) {
+	dev := &memDevice{data: make([]byte, testDeviceSize)}
+	client, done := startServe(t, dev, 4)
+
+	data := make([]byte, testBlockSize)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	sendRequest(t, client, NBD_CMD_WRITE, 1, testBlockSize, data)
+	requireReply(t, client, 1, 0, nil)
+
+	sendRequest(t, client, NBD_CMD_READ, 2, testBlockSize, make([]byte, testBlockSize))
+	requireReply(t, client, 2, 0, data)
+
+	sendRequest(t, client, NBD_CMD_DISC, 3, 0, nil)
+	require.NoError(t, <-done)
+}
+
+func TestServeErrors(t *testing.T) {
+	dev := &memDevice{
+		data: make([]byte, testDeviceSize),
+		err:  errors.New("device failure"),
+	}
+	client, done := startServe(t, dev, 4)
+
+	sendRequest(t, client, NBD_CMD_READ, 1, 0, make([]byte, testBlockSize))
+	requireReply(t, client, 1, uint32(syscall.EIO), nil)
+
+	sendRequest(t, client, NBD_CMD_WRITE, 2, 0, make([]byte, testBlockSize))
+	requireReply(t, client, 2, uint32(syscall.EIO), nil)
+
+	// memDevice implements neither Flusher nor Trimmer.
+	sendRequest(t, client, NBD_CMD_FLUSH, 3, 0, nil)
+	requireReply(t, client, 3, uint32(syscall.EOPNOTSUPP), nil)
+
+	sendRequest(t, client, NBD_CMD_DISC, 4, 0, nil)
+	require.NoError(t, <-done)
+}
+
+func BenchmarkServeQueueDepth(b *testing.B) {
+	for _, depth := range []int{1, 4, 16, 64} {
+		b.Run(fmt.Sprintf("depth-%d", depth), func(b *testing.B) {
+			benchmarkServe(b, depth)
+		})
+	}
+}
+
+func benchmarkServe(b *testing.B, depth int) {
+	dev := &memDevice{
+		data:    make([]byte, testDeviceSize),
+		latency: 100 * time.Microsecond,
+	}
+	client, done := startServe(b, dev, depth)
+
+	// Keep depth requests outstanding at all times.
+	inflight := make(chan struct{}, depth)
+	replied := make(chan error, 1)
+	go func() {
+		buf := make([]byte, replySize+testBlockSize)
+		for i := 0; i < b.N; i++ {
+			if _, err := io.ReadFull(client, buf); err != nil {
+				replied <- err
+				return
+			}
+			<-inflight
+		}
+		replied <- nil
+	}()
+
+	b.SetBytes(testBlockSize)
+	b.ResetTimer()
+	blocks := testDeviceSize / testBlockSize
+	for i := 0; i < b.N; i++ {
+		inflight <- struct{}{}
+		sendRequest(b, client, NBD_CMD_READ, uint64(i),
+			uint64(i%blocks)*testBlockSize, make([]byte, testBlockSize))
+	}
+	require.NoError(b, <-replied)
+	b.StopTimer()
+
+	sendRequest(b, client, NBD_CMD_DISC, 0, 0, nil)
+	require.NoError(b, <-done)
+}
+
+// startServe serves dev on one end of a socket pair and returns the other.
+func startServe(t testing.TB, dev Device, depth int) (socket, <-chan error) {
+	pair, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM, 0)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		syscall.Close(pair[0])
+		syscall.Close(pair[1])
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- serve(dev, socket(pair[1]), depth)
+	}()
+	return socket(pair[0]), done
+}
+
+// sendRequest writes a request to conn. For reads only the length of data
+// is used, for writes data is sent as the payload.
+func sendRequest(
+	t testing.TB,
+	conn io.Writer,
+	cmd uint32,
+	handle uint64,
+	from uint64,
+	data []byte,
+) {
+	buf := make([]byte, requestSize)
+	binary.BigEndian.PutUint32(buf[0:4], NBD_REQUEST_MAGIC)
+	binary.BigEndian.PutUint32(buf[4:8], cmd)
+	binary.BigEndian.PutUint64(buf[8:16], handle)
+	binary.BigEndian.PutUint64(buf[16:24], from)
+	binary.BigEndian.PutUint32(buf[24:28], uint32(len(data)))
+	if cmd == NBD_CMD_WRITE {
+		buf = append(buf, data...)
+	}
+	_, err := conn.Write(buf)
+	require.NoError(t, err)
+}
+
+// requireReply reads a reply from conn and checks its contents.
+func requireReply(
+	t testing.TB,
+	conn io.Reader,
+	handle uint64,
+	errno uint32,
+	data []byte,
+) {
+	hdr := make([]byte, replySize)
+	_, err := io.ReadFull(conn, hdr)
+	require.NoError(t, err)
+	require.Equal(t, uint32(NBD_REPLY_MAGIC), binary.BigEndian.Uint32(hdr[0:4]))
+	require.Equal(t, errno, binary.BigEndian.Uint32(hdr[4:8]))
+	require.Equal(t, handle, binary.BigEndian.Uint64(hdr[8:16]))
+	if data != nil {
+		got := make([]byte, len(data))
+		_, err = io.ReadFull(conn, got)
+		require.NoError(t, err)
+		require.Equal(t, data, got)
+	}
+}
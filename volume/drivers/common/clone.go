@@ -0,0 +1,142 @@
+package common
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/clone"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+const (
+	// CloneMethodParam is the driver parameter that selects how snapshots
+	// and restores clone the files of a volume: auto, reflink, hardlink or
+	// copy. auto, the default, uses reflinks if the filesystem supports them
+	// and copies the files otherwise. Restores copy instead of hard linking.
+	CloneMethodParam = "clone_method"
+	// RuntimeCloneMethod is the runtime state with the clone method used to
+	// create or restore a volume.
+	RuntimeCloneMethod = "clone_method"
+	// RuntimeCloneProgress is the runtime state with the percentage of the
+	// data of a volume cloned so far.
+	RuntimeCloneProgress = "clone_progress"
+	// cloneProgressInterval is the time between two progress updates.
+	cloneProgressInterval = time.Second
+)
+
+// Cloner creates and restores the snapshots of file based volumes by
+// cloning their directories.
+type Cloner struct {
+	store  volume.StoreEnumerator
+	method clone.Method
+}
+
+// NewCloner returns a cloner for the volumes in store that uses the clone
+// method in params.
+func NewCloner(store volume.StoreEnumerator, params map[string]string) (*Cloner, error) {
+	method, err := clone.ParseMethod(params[CloneMethodParam])
+	if err != nil {
+		return nil, err
+	}
+	return &Cloner{store: store, method: method}, nil
+}
+
+// Clone clones the directory source into dest, the directory of v. The
+// method and progress are recorded in the runtime state of v, which is
+// stored as the clone progresses.
+func (c *Cloner) Clone(v *api.Volume, source, dest string) error {
+	return c.clone(v, source, dest, c.method)
+}
+
+func (c *Cloner) clone(v *api.Volume, source, dest string, method clone.Method) error {
+	SetRuntimeState(v, RuntimeCloneMethod, string(method))
+	last := time.Now()
+	method, err := clone.Tree(source, dest, method, func(done, total uint64) {
+		if time.Since(last) < cloneProgressInterval {
+			return
+		}
+		last = time.Now()
+		SetRuntimeState(v, RuntimeCloneProgress, percent(done, total))
+		if err := c.store.UpdateVol(v); err != nil {
+			dlog.Warnf("Failed to update clone progress of volume %v: %v",
+				v.Id, err)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to clone %s into volume %v: %v", source, v.Id, err)
+	}
+	dlog.Infof("Cloned %s into volume %v with %v", source, v.Id, method)
+	SetRuntimeState(v, RuntimeCloneMethod, string(method))
+	SetRuntimeState(v, RuntimeCloneProgress, percent(1, 1))
+	return nil
+}
+
+// Restore replaces the contents of dest, the directory of v, with a clone of
+// source. The clone is staged in dest, so that its files are in the quota of
+// the volume, and the old contents are only removed once it is complete: a
+// failed restore leaves the volume untouched. dest itself is kept along with
+// its quota. Files are copied rather than hard linked, since writes to the
+// volume would otherwise change the snapshot.
+func (c *Cloner) Restore(v *api.Volume, source, dest string) error {
+	staging := filepath.Join(dest, ".restore-"+v.Id)
+	if err := os.RemoveAll(staging); err != nil {
+		return err
+	}
+	defer os.RemoveAll(staging)
+	method := c.method
+	if method == clone.Hardlink {
+		method = clone.Copy
+	}
+	if err := c.clone(v, source, staging, method); err != nil {
+		return err
+	}
+
+	entries, err := ioutil.ReadDir(dest)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.Name() == filepath.Base(staging) {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(dest, entry.Name())); err != nil {
+			return err
+		}
+	}
+	if entries, err = ioutil.ReadDir(staging); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := os.Rename(
+			filepath.Join(staging, entry.Name()),
+			filepath.Join(dest, entry.Name()),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetRuntimeState sets the driver specific runtime state key of v.
+func SetRuntimeState(v *api.Volume, key, value string) {
+	if len(v.RuntimeState) == 0 {
+		v.RuntimeState = []*api.RuntimeStateMap{{}}
+	}
+	if v.RuntimeState[0].RuntimeState == nil {
+		v.RuntimeState[0].RuntimeState = make(map[string]string)
+	}
+	v.RuntimeState[0].RuntimeState[key] = value
+}
+
+func percent(done, total uint64) string {
+	if total == 0 {
+		return "100%"
+	}
+	return fmt.Sprintf("%d%%", done*100/total)
+}
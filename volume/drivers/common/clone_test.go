@@ -0,0 +1,88 @@
+package common
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+)
+
+func TestCloner(t *testing.T) {
+	_, err := NewCloner(testEnumerator, map[string]string{CloneMethodParam: "rsync"})
+	assert.Error(t, err)
+	cloner, err := NewCloner(testEnumerator, map[string]string{CloneMethodParam: "copy"})
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "cloner")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	source := filepath.Join(dir, "source")
+	require.NoError(t, os.MkdirAll(filepath.Join(source, "data"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(source, "data", "file"),
+		[]byte("snapshot"), 0644))
+
+	v := &api.Volume{Id: "CloneVolume"}
+	dest := filepath.Join(dir, "dest")
+	require.NoError(t, cloner.Clone(v, source, dest))
+	b, err := ioutil.ReadFile(filepath.Join(dest, "data", "file"))
+	require.NoError(t, err)
+	assert.Equal(t, "snapshot", string(b))
+	require.Len(t, v.RuntimeState, 1)
+	assert.Equal(t, map[string]string{
+		RuntimeCloneMethod:   "copy",
+		RuntimeCloneProgress: "100%",
+	}, v.RuntimeState[0].RuntimeState)
+
+	// Restores replace the contents of the volume directory.
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dest, "data", "file"),
+		[]byte("changed"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dest, "new"), nil, 0644))
+	before, err := os.Stat(dest)
+	require.NoError(t, err)
+	require.NoError(t, cloner.Restore(v, source, dest))
+	b, err = ioutil.ReadFile(filepath.Join(dest, "data", "file"))
+	require.NoError(t, err)
+	assert.Equal(t, "snapshot", string(b))
+	_, err = os.Stat(filepath.Join(dest, "new"))
+	assert.True(t, os.IsNotExist(err))
+	after, err := os.Stat(dest)
+	require.NoError(t, err)
+	assert.True(t, os.SameFile(before, after), "Volume directory replaced")
+	_, err = os.Stat(filepath.Join(dest, ".restore-"+v.Id))
+	assert.True(t, os.IsNotExist(err))
+
+	// A failed restore leaves the volume untouched.
+	assert.Error(t, cloner.Restore(v, filepath.Join(dir, "missing"), dest))
+	_, err = os.Stat(filepath.Join(dest, "data", "file"))
+	assert.NoError(t, err)
+}
+
+func TestClonerRestoreHardlink(t *testing.T) {
+	cloner, err := NewCloner(testEnumerator, map[string]string{CloneMethodParam: "hardlink"})
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "cloner-hardlink")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	source := filepath.Join(dir, "source")
+	require.NoError(t, os.MkdirAll(source, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(source, "file"),
+		[]byte("snapshot"), 0644))
+	dest := filepath.Join(dir, "dest")
+	require.NoError(t, os.MkdirAll(dest, 0755))
+
+	// Restored files do not share data with the snapshot.
+	v := &api.Volume{Id: "RestoreHardlink"}
+	require.NoError(t, cloner.Restore(v, source, dest))
+	assert.Equal(t, "copy", v.RuntimeState[0].RuntimeState[RuntimeCloneMethod])
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dest, "file"),
+		[]byte("changed"), 0644))
+	b, err := ioutil.ReadFile(filepath.Join(source, "file"))
+	require.NoError(t, err)
+	assert.Equal(t, "snapshot", string(b))
+}
@@ -1,8 +1,11 @@
 package common
 
 import (
+	"fmt"
+
 	"github.com/libopenstorage/openstorage/api"
 	"github.com/libopenstorage/openstorage/pkg/proto/time"
+	"github.com/libopenstorage/openstorage/pkg/seed"
 	"github.com/libopenstorage/openstorage/volume"
 	"github.com/portworx/kvdb"
 )
@@ -28,6 +31,22 @@ func NewVolume(
 	}
 }
 
+// SeedVolume loads the seed of source, if any, into dataDir and records
+// where the data came from in mdDir. labels are the seed options.
+func SeedVolume(source *api.Source, labels map[string]string, dataDir, mdDir string) error {
+	if source == nil || len(source.Seed) == 0 {
+		return nil
+	}
+	s, err := seed.New(source.Seed, labels)
+	if err != nil {
+		return fmt.Errorf("Failed to initialize seed from %q: %v", source.Seed, err)
+	}
+	if err := s.Load(dataDir); err != nil {
+		return fmt.Errorf("Failed to seed from %q to %q: %v", source.Seed, dataDir, err)
+	}
+	return s.MetadataWrite(mdDir)
+}
+
 // NewDefaultStoreEnumerator returns a default store enumerator
 func NewDefaultStoreEnumerator(driver string, kvdb kvdb.Kvdb) volume.StoreEnumerator {
 	return newDefaultStoreEnumerator(driver, kvdb)
@@ -1,14 +1,18 @@
 package common
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	// TODO(pedge): what is this for?
 	_ "sync"
 
 	"github.com/portworx/kvdb"
 
 	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
 )
 
 const (
@@ -94,6 +98,9 @@ func (e *defaultStoreEnumerator) Enumerate(
 	}
 	volumes := make([]*api.Volume, 0, len(kvp))
 	for _, v := range kvp {
+		if isLockKey(v.Key) {
+			continue
+		}
 		elem := &api.Volume{}
 		if err := json.Unmarshal(v.Value, elem); err != nil {
 			return nil, err
@@ -105,6 +112,82 @@ func (e *defaultStoreEnumerator) Enumerate(
 	return volumes, nil
 }
 
+// EnumeratePage returns up to pageSize volumes that match filter in volume ID
+// order, continuing after token. Only the keys of the volumes are listed;
+// volumes are fetched one at a time until the page is full.
+func (e *defaultStoreEnumerator) EnumeratePage(
+	filter *api.VolumeFilter,
+	pageSize int,
+	token string,
+) (*api.VolumeEnumerateResponse, error) {
+	after, err := decodePageToken(token)
+	if err != nil {
+		return nil, err
+	}
+	resp := &api.VolumeEnumerateResponse{Volumes: make([]*api.Volume, 0)}
+	if filter != nil && filter.Driver != "" && filter.Driver != e.driver {
+		return resp, nil
+	}
+
+	ids, values, err := e.pageIDs(after)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		if pageSize > 0 && len(resp.Volumes) == pageSize {
+			resp.NextToken = encodePageToken(resp.Volumes[pageSize-1].Id)
+			break
+		}
+		elem := &api.Volume{}
+		if values != nil {
+			if err := json.Unmarshal(values[id], elem); err != nil {
+				return nil, err
+			}
+		} else if _, err := e.kvdb.GetVal(e.volKey(id), elem); err == kvdb.ErrNotFound {
+			// Deleted since the keys were listed.
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if filter.Match(elem) {
+			resp.Volumes = append(resp.Volumes, elem)
+		}
+	}
+	return resp, nil
+}
+
+// pageIDs returns the sorted IDs of the volumes after the volume ID after.
+// If the kvdb cannot list keys alone, the volumes are enumerated and their
+// values are returned by ID.
+func (e *defaultStoreEnumerator) pageIDs(after string) ([]string, map[string][]byte, error) {
+	var values map[string][]byte
+	keys, err := e.kvdb.Keys(e.volKeyPrefix(), "/")
+	if err == kvdb.ErrNotSupported {
+		kvp, err := e.kvdb.Enumerate(e.volKeyPrefix())
+		if err != nil {
+			return nil, nil, err
+		}
+		keys = make([]string, 0, len(kvp))
+		values = make(map[string][]byte, len(kvp))
+		for _, v := range kvp {
+			keys = append(keys, v.Key)
+			values[v.Key[strings.LastIndex(v.Key, "/")+1:]] = v.Value
+		}
+	} else if err != nil {
+		return nil, nil, err
+	}
+	ids := make([]string, 0, len(keys))
+	for _, key := range keys {
+		id := key[strings.LastIndex(key, "/")+1:]
+		if isLockKey(id) || id <= after {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, values, nil
+}
+
 // SnapEnumerate for specified volume
 func (e *defaultStoreEnumerator) SnapEnumerate(
 	volumeIDs []string,
@@ -116,6 +199,9 @@ func (e *defaultStoreEnumerator) SnapEnumerate(
 	}
 	volumes := make([]*api.Volume, 0, len(kvp))
 	for _, v := range kvp {
+		if isLockKey(v.Key) {
+			continue
+		}
 		elem := &api.Volume{}
 		if err := json.Unmarshal(v.Value, elem); err != nil {
 			return nil, err
@@ -136,6 +222,11 @@ func (e *defaultStoreEnumerator) lockKey(volumeID string) string {
 	return e.volKeyPrefix() + volumeID + ".lock"
 }
 
+// isLockKey returns true for the volume locks stored among the volumes.
+func isLockKey(key string) bool {
+	return strings.HasSuffix(key, ".lock")
+}
+
 func (e *defaultStoreEnumerator) volKey(volumeID string) string {
 	return e.volKeyPrefix() + volumeID
 }
@@ -149,6 +240,18 @@ func (e *defaultStoreEnumerator) volKeyPrefix() string {
 	return fmt.Sprintf("%s/%s/volumes/", keyBase, e.driver)
 }
 
+func encodePageToken(volumeID string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(volumeID))
+}
+
+func decodePageToken(token string) (string, error) {
+	id, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", volume.ErrInvalidPageToken
+	}
+	return string(id), nil
+}
+
 func hasSubset(set map[string]string, subset map[string]string) bool {
 	if subset == nil || len(subset) == 0 {
 		return true
@@ -1,6 +1,8 @@
 package common
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"go.pedge.io/dlog"
@@ -71,6 +73,111 @@ func TestEnumerate(t *testing.T) {
 	assert.Equal(t, len(volumes), 0, "Number of volumes returned in enumerate should be 0")
 }
 
+func TestEnumeratePage(t *testing.T) {
+	ids := []string{"page-c", "page-a", "page-b", "other"}
+	for _, id := range ids {
+		v := newTestVolume(id)
+		if id == "page-b" {
+			v.State = api.VolumeState_VOLUME_STATE_ATTACHED
+			v.AttachedOn = "node1"
+		}
+		assert.NoError(t, testEnumerator.CreateVol(v), "Failed in CreateVol")
+		defer testEnumerator.DeleteVol(id)
+	}
+
+	filter := &api.VolumeFilter{NamePrefix: "page-"}
+	resp, err := testEnumerator.EnumeratePage(filter, 2, "")
+	assert.NoError(t, err, "Failed in EnumeratePage")
+	assert.Equal(t, 2, len(resp.Volumes))
+	if len(resp.Volumes) == 2 {
+		assert.Equal(t, "page-a", resp.Volumes[0].Id)
+		assert.Equal(t, "page-b", resp.Volumes[1].Id)
+	}
+	assert.NotEmpty(t, resp.NextToken, "Expected a token for the next page")
+
+	resp, err = testEnumerator.EnumeratePage(filter, 2, resp.NextToken)
+	assert.NoError(t, err, "Failed in EnumeratePage")
+	assert.Equal(t, 1, len(resp.Volumes))
+	if len(resp.Volumes) == 1 {
+		assert.Equal(t, "page-c", resp.Volumes[0].Id)
+	}
+	assert.Empty(t, resp.NextToken, "Expected the last page")
+
+	// All volumes in one page.
+	resp, err = testEnumerator.EnumeratePage(nil, 0, "")
+	assert.NoError(t, err, "Failed in EnumeratePage")
+	assert.Equal(t, len(ids), len(resp.Volumes))
+
+	resp, err = testEnumerator.EnumeratePage(&api.VolumeFilter{
+		State: api.VolumeState_VOLUME_STATE_ATTACHED,
+		Node:  "node1",
+	}, 0, "")
+	assert.NoError(t, err, "Failed in EnumeratePage")
+	assert.Equal(t, 1, len(resp.Volumes))
+
+	resp, err = testEnumerator.EnumeratePage(&api.VolumeFilter{
+		Labels: map[string]string{"Foo": "other"},
+	}, 0, "")
+	assert.NoError(t, err, "Failed in EnumeratePage")
+	assert.Equal(t, 0, len(resp.Volumes))
+
+	resp, err = testEnumerator.EnumeratePage(&api.VolumeFilter{Driver: "other"}, 0, "")
+	assert.NoError(t, err, "Failed in EnumeratePage")
+	assert.Equal(t, 0, len(resp.Volumes))
+
+	_, err = testEnumerator.EnumeratePage(nil, 0, "!")
+	assert.Equal(t, volume.ErrInvalidPageToken, err)
+}
+
+// keysKvdb lists keys without their values and counts the values fetched.
+type keysKvdb struct {
+	kvdb.Kvdb
+	gets int
+}
+
+func (kv *keysKvdb) Keys(prefix, sep string) ([]string, error) {
+	kvp, err := kv.Kvdb.Enumerate(prefix)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(kvp))
+	for _, v := range kvp {
+		keys = append(keys, strings.TrimPrefix(v.Key, prefix))
+	}
+	return keys, nil
+}
+
+func (kv *keysKvdb) Enumerate(prefix string) (kvdb.KVPairs, error) {
+	return nil, fmt.Errorf("Unexpected enumerate of %v", prefix)
+}
+
+func (kv *keysKvdb) GetVal(key string, v interface{}) (*kvdb.KVPair, error) {
+	kv.gets++
+	return kv.Kvdb.GetVal(key, v)
+}
+
+func TestEnumeratePageKeys(t *testing.T) {
+	kv := &keysKvdb{Kvdb: kvdb.Instance()}
+	e := NewDefaultStoreEnumerator("enumerator_keys_test", kv)
+	for _, id := range []string{"keys-d", "keys-b", "keys-a", "keys-c", "keys-e"} {
+		assert.NoError(t, e.CreateVol(newTestVolume(id)), "Failed in CreateVol")
+		defer e.DeleteVol(id)
+	}
+	lock, err := e.Lock("keys-a")
+	assert.NoError(t, err, "Failed in Lock")
+	defer e.Unlock(lock)
+
+	resp, err := e.EnumeratePage(nil, 2, encodePageToken("keys-a"))
+	assert.NoError(t, err, "Failed in EnumeratePage")
+	assert.Equal(t, 2, len(resp.Volumes))
+	if len(resp.Volumes) == 2 {
+		assert.Equal(t, "keys-b", resp.Volumes[0].Id)
+		assert.Equal(t, "keys-c", resp.Volumes[1].Id)
+	}
+	assert.Equal(t, encodePageToken("keys-c"), resp.NextToken)
+	assert.Equal(t, 2, kv.gets, "Only the volumes of the page should be fetched")
+}
+
 func TestSnapEnumerate(t *testing.T) {
 	vol := newTestVolume("TestVolume")
 	err := testEnumerator.CreateVol(vol)
@@ -0,0 +1,130 @@
+// +build linux
+
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"syscall"
+
+	"github.com/portworx/kvdb"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/quota"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+const (
+	// RuntimeProjectID is the runtime state with the project ID reserved
+	// for a volume whose size is enforced with a project quota.
+	RuntimeProjectID = "project_id"
+	// maxProjectProbes bounds the search for a free project ID.
+	maxProjectProbes = 1024
+)
+
+// SetSizeLimit limits the space used in dir, the directory of v, to size
+// bytes with a project quota and returns the limit enforced. The first
+// time, a project ID is reserved for v in kv so that no two volumes share
+// a quota; it is kept in the runtime state of v.
+func SetSizeLimit(kv kvdb.Kvdb, v *api.Volume, dir string, size uint64) (uint64, error) {
+	id, reserved, err := reserveProjectID(kv, v)
+	if err != nil {
+		return 0, err
+	}
+	limit, err := quota.SetLimit(dir, id, size)
+	if err != nil && reserved {
+		if err := ReleaseProjectID(kv, v); err != nil {
+			return 0, err
+		}
+	}
+	return limit, err
+}
+
+// ReleaseProjectID frees the project ID reserved for v, if any.
+func ReleaseProjectID(kv kvdb.Kvdb, v *api.Volume) error {
+	id, ok := projectIDOf(v)
+	if !ok {
+		return nil
+	}
+	kvp, err := kv.Get(projectKey(id))
+	if err == kvdb.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if string(kvp.Value) != v.Id {
+		return nil
+	}
+	if _, err := kv.Delete(projectKey(id)); err != nil && err != kvdb.ErrNotFound {
+		return err
+	}
+	delete(v.RuntimeState[0].RuntimeState, RuntimeProjectID)
+	return nil
+}
+
+// SizeLimited returns true if the size of v is enforced with a project
+// quota.
+func SizeLimited(v *api.Volume) bool {
+	_, ok := projectIDOf(v)
+	return ok
+}
+
+// CheckCapacity returns ErrVolSizeExceeded if the filesystem containing dir
+// does not have grow bytes available.
+func CheckCapacity(dir string, grow uint64) error {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(dir, &st); err != nil {
+		return err
+	}
+	if grow > st.Bavail*uint64(st.Bsize) {
+		return volume.ErrVolSizeExceeded
+	}
+	return nil
+}
+
+// reserveProjectID returns the project ID of v, reserving the first free
+// ID from the preferred ID of v if it has none. reserved is true if the ID
+// was reserved by this call.
+func reserveProjectID(kv kvdb.Kvdb, v *api.Volume) (uint32, bool, error) {
+	if id, ok := projectIDOf(v); ok {
+		return id, false, nil
+	}
+	id := quota.ProjectID(v.Id)
+	for i := 0; i < maxProjectProbes; i++ {
+		_, err := kv.Create(projectKey(id), v.Id, 0)
+		if err == nil {
+			SetRuntimeState(v, RuntimeProjectID, strconv.FormatUint(uint64(id), 10))
+			return id, true, nil
+		}
+		if err != kvdb.ErrExist {
+			return 0, false, err
+		}
+		// A reservation left behind by an interrupted update of v.
+		if kvp, err := kv.Get(projectKey(id)); err == nil && string(kvp.Value) == v.Id {
+			SetRuntimeState(v, RuntimeProjectID, strconv.FormatUint(uint64(id), 10))
+			return id, true, nil
+		}
+		id = quota.NextProjectID(id)
+	}
+	return 0, false, fmt.Errorf("No free project ID for volume %v", v.Id)
+}
+
+// projectIDOf returns the project ID reserved for v.
+func projectIDOf(v *api.Volume) (uint32, bool) {
+	if len(v.RuntimeState) == 0 {
+		return 0, false
+	}
+	s, ok := v.RuntimeState[0].RuntimeState[RuntimeProjectID]
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint32(id), true
+}
+
+func projectKey(id uint32) string {
+	return fmt.Sprintf("%s/quota/projects/%d", keyBase, id)
+}
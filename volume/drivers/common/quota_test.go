@@ -0,0 +1,54 @@
+// +build linux
+
+package common
+
+import (
+	"testing"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/quota"
+	"github.com/portworx/kvdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReserveProjectID(t *testing.T) {
+	kv := kvdb.Instance()
+	taken := &api.Volume{Id: "quota-taken"}
+	v := &api.Volume{Id: "quota-collides"}
+
+	// Make the preferred ID of v collide with another volume.
+	preferred := quota.ProjectID(v.Id)
+	_, err := kv.Create(projectKey(preferred), taken.Id, 0)
+	assert.NoError(t, err)
+	defer kv.Delete(projectKey(preferred))
+
+	id, reserved, err := reserveProjectID(kv, v)
+	assert.NoError(t, err)
+	assert.True(t, reserved)
+	assert.Equal(t, quota.NextProjectID(preferred), id, "Colliding ID should be skipped")
+	got, ok := projectIDOf(v)
+	assert.True(t, ok, "Project ID should be kept in the runtime state")
+	assert.Equal(t, id, got)
+
+	again, reserved, err := reserveProjectID(kv, v)
+	assert.NoError(t, err)
+	assert.False(t, reserved)
+	assert.Equal(t, id, again, "Project IDs should be stable")
+
+	assert.NoError(t, ReleaseProjectID(kv, v))
+	_, err = kv.Get(projectKey(id))
+	assert.Equal(t, kvdb.ErrNotFound, err, "Project ID should be released")
+	_, ok = projectIDOf(v)
+	assert.False(t, ok)
+}
+
+func TestSetSizeLimitNotSupported(t *testing.T) {
+	kv := kvdb.Instance()
+	v := &api.Volume{Id: "quota-unsupported"}
+	_, err := SetSizeLimit(kv, v, "/proc", 1024)
+	assert.Equal(t, quota.ErrNotSupported, err)
+	_, ok := projectIDOf(v)
+	assert.False(t, ok, "Failed limit should not keep a project ID")
+	_, err = kv.Get(projectKey(quota.ProjectID(v.Id)))
+	assert.Equal(t, kvdb.ErrNotFound, err, "Failed limit should release the project ID")
+}
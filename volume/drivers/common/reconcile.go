@@ -0,0 +1,257 @@
+package common
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/alert"
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/mount"
+	"github.com/libopenstorage/openstorage/pkg/storageops"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+// VolumeRecoveryAlert is the alert type raised for volumes that could not be
+// recovered from an operation interrupted by a restart.
+const VolumeRecoveryAlert int64 = 201
+
+func init() {
+	if err := alert.RegisterType(&api.AlertType{
+		Type:      VolumeRecoveryAlert,
+		Name:      "VolumeRecoveryFailed",
+		Severity:  api.SeverityType_SEVERITY_TYPE_ALARM,
+		Resource:  api.ResourceType_RESOURCE_TYPE_VOLUME,
+		Message:   "Volume {{.ResourceId}} could not be recovered after a restart",
+		AutoClear: true,
+	}); err != nil {
+		dlog.Warnf("Failed to register volume recovery alert type: %v", err)
+	}
+}
+
+// Backing is the actual state of the storage backing a volume.
+type Backing struct {
+	// Exists is true if the backing storage of the volume exists.
+	Exists bool
+	// Attached is true if the volume is attached to this node.
+	Attached bool
+	// DevicePath of the volume, if attached.
+	DevicePath string
+	// AttachPath lists the paths the volume is mounted at.
+	AttachPath []string
+}
+
+// ProbeFunc returns the actual state of the storage backing v.
+type ProbeFunc func(v *api.Volume) (*Backing, error)
+
+// Reconciler recovers the volumes a driver left in a transitional state when
+// it stopped in the middle of an operation. Drivers run it when they start.
+type Reconciler struct {
+	store volume.StoreEnumerator
+	probe ProbeFunc
+	purge PurgeFunc
+}
+
+// NewReconciler returns a reconciler for the volumes in store. probe reports
+// the actual state of a volume and purge removes its data, to finish an
+// interrupted delete. purge may be nil, in which case volumes whose delete
+// was interrupted are marked in error.
+func NewReconciler(
+	store volume.StoreEnumerator,
+	probe ProbeFunc,
+	purge PurgeFunc,
+) *Reconciler {
+	return &Reconciler{store: store, probe: probe, purge: purge}
+}
+
+// Reconcile compares the volumes in a transitional state with the state of
+// their backing storage and finishes or rolls back the interrupted operation.
+// The outcome is recorded in the Error of the volume. An alert is raised for
+// volumes that cannot be recovered. Volumes in the trash are left alone.
+func (r *Reconciler) Reconcile() error {
+	volumes, err := r.store.Enumerate(&api.VolumeLocator{}, nil)
+	if err != nil {
+		return err
+	}
+	for _, v := range volumes {
+		if !transitional(v.State) || InTrash(v) {
+			continue
+		}
+		if err := r.reconcile(v.Id); err != nil {
+			dlog.Warnf("Failed to recover volume %v: %v", v.Id, err)
+		}
+	}
+	return nil
+}
+
+func transitional(state api.VolumeState) bool {
+	switch state {
+	case api.VolumeState_VOLUME_STATE_PENDING,
+		api.VolumeState_VOLUME_STATE_DETATCHING,
+		api.VolumeState_VOLUME_STATE_TRY_DETACHING,
+		api.VolumeState_VOLUME_STATE_RESTORE,
+		api.VolumeState_VOLUME_STATE_DELETED:
+		return true
+	}
+	return false
+}
+
+func (r *Reconciler) reconcile(volumeID string) error {
+	token, err := r.store.Lock(volumeID)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := r.store.Unlock(token); err != nil {
+			dlog.Warnf("Failed to unlock volume %v: %v", volumeID, err)
+		}
+	}()
+
+	v, err := r.store.GetVol(volumeID)
+	if err != nil {
+		return err
+	}
+	if !transitional(v.State) || InTrash(v) {
+		return nil
+	}
+	interrupted := v.State
+
+	b, err := r.probe(v)
+	if err != nil {
+		v.State = api.VolumeState_VOLUME_STATE_ERROR
+		v.Error = fmt.Sprintf("Could not recover from interrupted operation "+
+			"in state %v: %v", interrupted, err)
+		r.raise(v)
+		return r.store.UpdateVol(v)
+	}
+
+	if !b.Exists && (interrupted == api.VolumeState_VOLUME_STATE_PENDING ||
+		interrupted == api.VolumeState_VOLUME_STATE_DELETED) {
+		// The volume was never created or was already deleted.
+		dlog.Infof("Removing volume %v left in state %v with no backing storage",
+			volumeID, interrupted)
+		return r.store.DeleteVol(volumeID)
+	}
+
+	v.DevicePath = b.DevicePath
+	v.AttachPath = b.AttachPath
+	if !b.Exists {
+		v.State = api.VolumeState_VOLUME_STATE_ERROR
+		v.Error = fmt.Sprintf("Backing storage missing after interrupted "+
+			"operation in state %v", interrupted)
+		r.raise(v)
+		return r.store.UpdateVol(v)
+	}
+	if interrupted == api.VolumeState_VOLUME_STATE_DELETED {
+		return r.finishDelete(v, b)
+	}
+
+	v.State = api.VolumeState_VOLUME_STATE_DETACHED
+	if b.Attached {
+		v.State = api.VolumeState_VOLUME_STATE_ATTACHED
+	}
+	switch interrupted {
+	case api.VolumeState_VOLUME_STATE_PENDING:
+		if !b.Attached {
+			v.State = api.VolumeState_VOLUME_STATE_AVAILABLE
+		}
+		v.Error = fmt.Sprintf("Recovered from interrupted create or attach, "+
+			"volume is %v", v.State)
+	case api.VolumeState_VOLUME_STATE_DETATCHING,
+		api.VolumeState_VOLUME_STATE_TRY_DETACHING:
+		v.Error = fmt.Sprintf("Recovered from interrupted detach, volume is %v",
+			v.State)
+	case api.VolumeState_VOLUME_STATE_RESTORE:
+		// A partial restore cannot be detected, so the data is suspect.
+		v.Error = "Restore was interrupted, volume data may be incomplete"
+		r.raise(v)
+		return r.store.UpdateVol(v)
+	}
+	dlog.Infof("Volume %v: %v", volumeID, v.Error)
+	return r.store.UpdateVol(v)
+}
+
+// finishDelete purges v, whose delete was interrupted. The driver may have
+// removed part of its data already, so a volume that cannot be purged is
+// marked in error rather than made available again.
+func (r *Reconciler) finishDelete(v *api.Volume, b *Backing) error {
+	var err error
+	switch {
+	case b.Attached || len(b.AttachPath) > 0:
+		err = fmt.Errorf("volume is still attached")
+	case r.purge == nil:
+		err = fmt.Errorf("driver cannot purge volumes")
+	default:
+		err = r.purge(v)
+	}
+	if err != nil {
+		v.State = api.VolumeState_VOLUME_STATE_ERROR
+		v.Error = fmt.Sprintf("Delete was interrupted, volume data may be "+
+			"incomplete: %v", err)
+		r.raise(v)
+		return r.store.UpdateVol(v)
+	}
+	dlog.Infof("Finished interrupted delete of volume %v", v.Id)
+	return r.store.DeleteVol(v.Id)
+}
+
+// raise raises a recovery alert for v if alerts are enabled.
+func (r *Reconciler) raise(v *api.Volume) {
+	dlog.Warnf("Volume %v: %v", v.Id, v.Error)
+	alerts, err := alert.Get(alert.Name)
+	if err != nil {
+		return
+	}
+	if err := alerts.RaiseIfNotExist(&api.Alert{
+		AlertType:  VolumeRecoveryAlert,
+		Resource:   api.ResourceType_RESOURCE_TYPE_VOLUME,
+		ResourceId: v.Id,
+		UniqueTag:  "recovery",
+		Message:    v.Error,
+	}); err != nil {
+		dlog.Warnf("Failed to raise recovery alert for volume %v: %v", v.Id, err)
+	}
+}
+
+// MountedPaths returns the paths in the mount table out of paths.
+func MountedPaths(paths []string) []string {
+	if len(paths) == 0 {
+		return nil
+	}
+	table, err := mount.New(mount.DeviceMount, nil, []string{""}, nil, nil, "")
+	if err != nil {
+		dlog.Warnf("Failed to load the mount table: %v", err)
+		return nil
+	}
+	var mounted []string
+	for _, p := range paths {
+		if _, ok := table.HasTarget(filepath.Clean(p)); ok {
+			mounted = append(mounted, p)
+		}
+	}
+	return mounted
+}
+
+// StorageOpsProbe returns a probe that finds the attachment of cloud volumes
+// through ops. Volumes attached to another node are not attached here.
+func StorageOpsProbe(ops storageops.Ops) ProbeFunc {
+	return func(v *api.Volume) (*Backing, error) {
+		devicePath, err := ops.DevicePath(v.Id)
+		if err == nil {
+			return &Backing{
+				Exists:     true,
+				Attached:   true,
+				DevicePath: devicePath,
+				AttachPath: MountedPaths(v.AttachPath),
+			}, nil
+		}
+		if se, ok := err.(*storageops.StorageError); ok {
+			switch se.Code {
+			case storageops.ErrVolDetached, storageops.ErrVolAttachedOnRemoteNode:
+				return &Backing{Exists: true}, nil
+			}
+		}
+		return nil, err
+	}
+}
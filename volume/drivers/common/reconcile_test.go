@@ -0,0 +1,183 @@
+package common
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/portworx/kvdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/alert"
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/storageops"
+)
+
+func TestReconcile(t *testing.T) {
+	store := NewDefaultStoreEnumerator("reconcile_test", kvdb.Instance())
+	alerts, err := alert.New(alert.Name, "reconcile_test", kvdb.Instance())
+	require.NoError(t, err)
+
+	backing := map[string]*Backing{
+		"created":   {Exists: true},
+		"attached":  {Exists: true, Attached: true, DevicePath: "/dev/attached"},
+		"detached":  {Exists: true},
+		"restored":  {Exists: true},
+		"deleting":  {Exists: true},
+		"halfgone":  {Exists: true},
+		"inuse":     {Exists: true, Attached: true},
+		"missing":   {},
+		"available": {},
+	}
+	states := map[string]api.VolumeState{
+		"created":   api.VolumeState_VOLUME_STATE_PENDING,
+		"attached":  api.VolumeState_VOLUME_STATE_PENDING,
+		"detached":  api.VolumeState_VOLUME_STATE_DETATCHING,
+		"restored":  api.VolumeState_VOLUME_STATE_RESTORE,
+		"deleting":  api.VolumeState_VOLUME_STATE_DELETED,
+		"halfgone":  api.VolumeState_VOLUME_STATE_DELETED,
+		"inuse":     api.VolumeState_VOLUME_STATE_DELETED,
+		"missing":   api.VolumeState_VOLUME_STATE_TRY_DETACHING,
+		"available": api.VolumeState_VOLUME_STATE_AVAILABLE,
+		"pending":   api.VolumeState_VOLUME_STATE_PENDING,
+		"unknown":   api.VolumeState_VOLUME_STATE_RESTORE,
+	}
+	for id, state := range states {
+		v := newTestVolume(id)
+		v.State = state
+		v.AttachPath = []string{"/mnt/" + id}
+		require.NoError(t, store.CreateVol(v))
+		defer store.DeleteVol(id)
+	}
+	probed := make(map[string]bool)
+	probe := func(v *api.Volume) (*Backing, error) {
+		probed[v.Id] = true
+		if v.Id == "unknown" {
+			return nil, errors.New("probe failed")
+		}
+		if b, ok := backing[v.Id]; ok {
+			return b, nil
+		}
+		return &Backing{}, nil
+	}
+	purged := make(map[string]bool)
+	purge := func(v *api.Volume) error {
+		if v.Id == "halfgone" {
+			return errors.New("purge failed")
+		}
+		purged[v.Id] = true
+		return nil
+	}
+	require.NoError(t, NewReconciler(store, probe, purge).Reconcile())
+	assert.False(t, probed["available"], "Stable volume probed")
+	assert.Equal(t, map[string]bool{"deleting": true}, purged,
+		"Only unattached volumes should be purged")
+
+	expected := map[string]api.VolumeState{
+		"created":   api.VolumeState_VOLUME_STATE_AVAILABLE,
+		"attached":  api.VolumeState_VOLUME_STATE_ATTACHED,
+		"detached":  api.VolumeState_VOLUME_STATE_DETACHED,
+		"restored":  api.VolumeState_VOLUME_STATE_DETACHED,
+		"halfgone":  api.VolumeState_VOLUME_STATE_ERROR,
+		"inuse":     api.VolumeState_VOLUME_STATE_ERROR,
+		"missing":   api.VolumeState_VOLUME_STATE_ERROR,
+		"available": api.VolumeState_VOLUME_STATE_AVAILABLE,
+		"unknown":   api.VolumeState_VOLUME_STATE_ERROR,
+	}
+	for id, state := range expected {
+		v, err := store.GetVol(id)
+		require.NoError(t, err, id)
+		assert.Equal(t, state, v.State, id)
+		switch id {
+		case "available":
+			assert.Empty(t, v.Error)
+			assert.Equal(t, []string{"/mnt/available"}, v.AttachPath)
+		case "unknown":
+			assert.NotEmpty(t, v.Error, id)
+			assert.Equal(t, []string{"/mnt/unknown"}, v.AttachPath)
+		default:
+			assert.NotEmpty(t, v.Error, id)
+			assert.Empty(t, v.AttachPath, id)
+		}
+	}
+	v, err := store.GetVol("attached")
+	require.NoError(t, err)
+	assert.Equal(t, "/dev/attached", v.DevicePath)
+
+	// A pending volume without backing storage was never created.
+	_, err = store.GetVol("pending")
+	assert.Error(t, err)
+	// An interrupted delete is finished.
+	_, err = store.GetVol("deleting")
+	assert.Error(t, err)
+
+	// Volumes that cannot be recovered raise an alert.
+	raised, err := alerts.Enumerate(&api.Alert{Resource: api.ResourceType_RESOURCE_TYPE_VOLUME})
+	require.NoError(t, err)
+	recovery := make(map[string]bool)
+	for _, a := range raised {
+		if a.AlertType == VolumeRecoveryAlert {
+			recovery[a.ResourceId] = true
+		}
+	}
+	assert.Equal(t, map[string]bool{
+		"halfgone": true,
+		"inuse":    true,
+		"missing":  true,
+		"restored": true,
+		"unknown":  true,
+	}, recovery)
+
+	// The next successful operation clears the recovery error.
+	require.NoError(t, NewStateMachine(store).Transition("detached", OpMount,
+		func(v *api.Volume) error { return nil }))
+	v, err = store.GetVol("detached")
+	require.NoError(t, err)
+	assert.Empty(t, v.Error)
+}
+
+func TestReconcileDeleteWithoutPurge(t *testing.T) {
+	store := NewDefaultStoreEnumerator("reconcile_nopurge_test", kvdb.Instance())
+	v := newTestVolume("nopurge")
+	v.State = api.VolumeState_VOLUME_STATE_DELETED
+	require.NoError(t, store.CreateVol(v))
+	defer store.DeleteVol(v.Id)
+
+	require.NoError(t, NewReconciler(store, func(v *api.Volume) (*Backing, error) {
+		return &Backing{Exists: true}, nil
+	}, nil).Reconcile())
+	v, err := store.GetVol(v.Id)
+	require.NoError(t, err)
+	assert.Equal(t, api.VolumeState_VOLUME_STATE_ERROR, v.State,
+		"Half deleted volume should not be available")
+	assert.NotEmpty(t, v.Error)
+}
+
+type devicePathOps struct {
+	storageops.Ops
+	err error
+}
+
+func (o *devicePathOps) DevicePath(volumeID string) (string, error) {
+	if o.err != nil {
+		return "", o.err
+	}
+	return "/dev/xvdf", nil
+}
+
+func TestStorageOpsProbe(t *testing.T) {
+	v := newTestVolume("cloud")
+	ops := &devicePathOps{}
+	b, err := StorageOpsProbe(ops)(v)
+	require.NoError(t, err)
+	assert.Equal(t, &Backing{Exists: true, Attached: true, DevicePath: "/dev/xvdf"}, b)
+
+	ops.err = storageops.NewStorageError(storageops.ErrVolAttachedOnRemoteNode, "remote", "i-1")
+	b, err = StorageOpsProbe(ops)(v)
+	require.NoError(t, err)
+	assert.Equal(t, &Backing{Exists: true}, b)
+
+	ops.err = errors.New("unreachable")
+	_, err = StorageOpsProbe(ops)(v)
+	assert.Error(t, err)
+}
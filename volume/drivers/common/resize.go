@@ -0,0 +1,32 @@
+package common
+
+import (
+	"github.com/golang/protobuf/proto"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+// NeedsResize validates a spec update for v. Only the size of a volume can
+// be changed, and only grown: a zero size or a change to any other field is
+// ErrNotSupported and a smaller size is ErrVolShrink. Fields of spec left at
+// their zero value are unchanged. It returns true if the backend has to be
+// grown.
+func NeedsResize(v *api.Volume, spec *api.VolumeSpec) (bool, error) {
+	if spec.Size == 0 {
+		return false, volume.ErrNotSupported
+	}
+	if v.Spec == nil {
+		v.Spec = &api.VolumeSpec{}
+	}
+	merged := proto.Clone(v.Spec).(*api.VolumeSpec)
+	proto.Merge(merged, spec)
+	merged.Size = v.Spec.Size
+	if !proto.Equal(merged, v.Spec) {
+		return false, volume.ErrNotSupported
+	}
+	if spec.Size < v.Spec.Size {
+		return false, volume.ErrVolShrink
+	}
+	return spec.Size > v.Spec.Size, nil
+}
@@ -0,0 +1,37 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNeedsResize(t *testing.T) {
+	v := &api.Volume{Spec: &api.VolumeSpec{Size: 1024}}
+
+	resize, err := NeedsResize(v, &api.VolumeSpec{Size: 2048})
+	assert.NoError(t, err, "Failed to grow volume")
+	assert.True(t, resize, "Growing a volume should need a resize")
+
+	resize, err = NeedsResize(v, &api.VolumeSpec{Size: 1024})
+	assert.NoError(t, err, "Failed with unchanged size")
+	assert.False(t, resize, "Unchanged size should not need a resize")
+
+	_, err = NeedsResize(v, &api.VolumeSpec{Size: 512})
+	assert.Equal(t, volume.ErrVolShrink, err, "Shrinking should be rejected")
+
+	_, err = NeedsResize(v, &api.VolumeSpec{})
+	assert.Equal(t, volume.ErrNotSupported, err, "Empty spec should be rejected")
+
+	_, err = NeedsResize(v, &api.VolumeSpec{Size: 1024, Shared: true})
+	assert.Equal(t, volume.ErrNotSupported, err, "Other changes should be rejected")
+	_, err = NeedsResize(v, &api.VolumeSpec{Size: 2048, HaLevel: 2})
+	assert.Equal(t, volume.ErrNotSupported, err, "Other changes should be rejected")
+
+	v.Spec.HaLevel = 2
+	resize, err = NeedsResize(v, &api.VolumeSpec{Size: 2048, HaLevel: 2})
+	assert.NoError(t, err, "Failed with unchanged fields")
+	assert.True(t, resize)
+}
@@ -0,0 +1,298 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+// Operation is a volume lifecycle operation.
+type Operation int
+
+const (
+	// OpCreate creates a volume.
+	OpCreate Operation = iota
+	// OpAttach attaches a volume.
+	OpAttach
+	// OpDetach detaches a volume.
+	OpDetach
+	// OpMount mounts a volume.
+	OpMount
+	// OpUnmount unmounts a volume.
+	OpUnmount
+	// OpRestore restores a volume from a snapshot.
+	OpRestore
+	// OpDelete deletes a volume.
+	OpDelete
+	// OpTrash moves a volume to the trash.
+	OpTrash
+	// OpUntrash moves a volume out of the trash.
+	OpUntrash
+)
+
+var operationNames = map[Operation]string{
+	OpCreate:  "create",
+	OpAttach:  "attach",
+	OpDetach:  "detach",
+	OpMount:   "mount",
+	OpUnmount: "unmount",
+	OpRestore: "restore",
+	OpDelete:  "delete",
+	OpTrash:   "trash",
+	OpUntrash: "untrash",
+}
+
+func (o Operation) String() string {
+	if name, ok := operationNames[o]; ok {
+		return name
+	}
+	return fmt.Sprintf("operation %d", int(o))
+}
+
+// ErrInvalidTransition is returned when an operation is not allowed in the
+// current state of a volume.
+type ErrInvalidTransition struct {
+	// ID of the volume.
+	ID string
+	// Op is the rejected operation.
+	Op Operation
+	// State of the volume.
+	State api.VolumeState
+}
+
+func (e *ErrInvalidTransition) Error() string {
+	return fmt.Sprintf("Cannot %v volume %v in state %v", e.Op, e.ID, e.State)
+}
+
+// transition lists the states an operation is allowed from, the state the
+// volume is in while the operation runs and the state it is left in.
+// VOLUME_STATE_NONE leaves the state unchanged.
+type transition struct {
+	from   []api.VolumeState
+	during api.VolumeState
+	to     api.VolumeState
+}
+
+var transitions = map[Operation]transition{
+	OpAttach: {
+		from: []api.VolumeState{
+			api.VolumeState_VOLUME_STATE_AVAILABLE,
+			api.VolumeState_VOLUME_STATE_ATTACHED,
+			api.VolumeState_VOLUME_STATE_DETACHED,
+		},
+		during: api.VolumeState_VOLUME_STATE_PENDING,
+		to:     api.VolumeState_VOLUME_STATE_ATTACHED,
+	},
+	OpDetach: {
+		from: []api.VolumeState{
+			api.VolumeState_VOLUME_STATE_AVAILABLE,
+			api.VolumeState_VOLUME_STATE_ATTACHED,
+			api.VolumeState_VOLUME_STATE_DETACHED,
+			api.VolumeState_VOLUME_STATE_ERROR,
+		},
+		during: api.VolumeState_VOLUME_STATE_DETATCHING,
+		to:     api.VolumeState_VOLUME_STATE_DETACHED,
+	},
+	OpMount: {
+		from: []api.VolumeState{
+			api.VolumeState_VOLUME_STATE_AVAILABLE,
+			api.VolumeState_VOLUME_STATE_ATTACHED,
+			api.VolumeState_VOLUME_STATE_DETACHED,
+		},
+	},
+	OpUnmount: {
+		from: []api.VolumeState{
+			api.VolumeState_VOLUME_STATE_AVAILABLE,
+			api.VolumeState_VOLUME_STATE_ATTACHED,
+			api.VolumeState_VOLUME_STATE_DETACHED,
+			api.VolumeState_VOLUME_STATE_ERROR,
+		},
+	},
+	OpRestore: {
+		from: []api.VolumeState{
+			api.VolumeState_VOLUME_STATE_AVAILABLE,
+			api.VolumeState_VOLUME_STATE_DETACHED,
+		},
+		during: api.VolumeState_VOLUME_STATE_RESTORE,
+	},
+	OpDelete: {
+		from: []api.VolumeState{
+			api.VolumeState_VOLUME_STATE_AVAILABLE,
+			api.VolumeState_VOLUME_STATE_DETACHED,
+			api.VolumeState_VOLUME_STATE_ERROR,
+			api.VolumeState_VOLUME_STATE_DELETED,
+		},
+		during: api.VolumeState_VOLUME_STATE_DELETED,
+	},
+	OpTrash: {
+		from: []api.VolumeState{
+			api.VolumeState_VOLUME_STATE_AVAILABLE,
+			api.VolumeState_VOLUME_STATE_DETACHED,
+			api.VolumeState_VOLUME_STATE_ERROR,
+		},
+		during: api.VolumeState_VOLUME_STATE_DETATCHING,
+		to:     api.VolumeState_VOLUME_STATE_DELETED,
+	},
+	OpUntrash: {
+		from: []api.VolumeState{
+			api.VolumeState_VOLUME_STATE_DELETED,
+		},
+		to: api.VolumeState_VOLUME_STATE_AVAILABLE,
+	},
+}
+
+// StateMachine serializes the lifecycle operations of the volumes in a store
+// and enforces the volume state transitions. Drivers route Create, Attach,
+// Detach, Mount, Unmount, Restore and Delete through it, and the trash
+// operations when the trash is enabled.
+type StateMachine struct {
+	store volume.StoreEnumerator
+}
+
+// NewStateMachine returns a state machine for the volumes in store.
+func NewStateMachine(store volume.StoreEnumerator) *StateMachine {
+	return &StateMachine{store: store}
+}
+
+// Create stores v in the pending state and runs create with the volume
+// locked. The volume becomes available if create succeeds and is removed
+// from the store if it fails.
+func (s *StateMachine) Create(v *api.Volume, create func(v *api.Volume) error) error {
+	token, err := s.store.Lock(v.Id)
+	if err != nil {
+		return err
+	}
+	defer s.unlock(v.Id, token)
+
+	v.State = api.VolumeState_VOLUME_STATE_PENDING
+	if err := s.store.CreateVol(v); err != nil {
+		return err
+	}
+	if err := create(v); err != nil {
+		if delErr := s.store.DeleteVol(v.Id); delErr != nil {
+			dlog.Warnf("Failed to remove volume %v after failed create: %v",
+				v.Id, delErr)
+		}
+		return err
+	}
+	v.State = api.VolumeState_VOLUME_STATE_AVAILABLE
+	return s.store.UpdateVol(v)
+}
+
+// Transition runs op on volumeID with the volume locked. The volume is
+// stored in the intermediate state of op while fn runs and in the final
+// state once it succeeds; changes fn makes to the volume are stored with it.
+// A successful delete removes the volume from the store and any other
+// successful operation clears the error recorded on the volume. If fn fails
+// the volume is returned to its previous state.
+//
+// ErrVolBusy is returned if another operation is in progress,
+// ErrVolAttached if op cannot run on an attached volume, and
+// ErrInvalidTransition if op is not allowed in the state of the volume.
+func (s *StateMachine) Transition(
+	volumeID string,
+	op Operation,
+	fn func(v *api.Volume) error,
+) error {
+	t, ok := transitions[op]
+	if !ok {
+		return fmt.Errorf("Unknown volume operation %v", op)
+	}
+
+	token, err := s.store.Lock(volumeID)
+	if err != nil {
+		return err
+	}
+	defer s.unlock(volumeID, token)
+
+	v, err := s.store.GetVol(volumeID)
+	if err != nil {
+		return err
+	}
+	previous := v.State
+	if previous == api.VolumeState_VOLUME_STATE_NONE {
+		// Volumes stored before states were tracked are available.
+		previous = api.VolumeState_VOLUME_STATE_AVAILABLE
+	}
+	if err := t.check(volumeID, op, previous); err != nil {
+		return err
+	}
+
+	original := proto.Clone(v).(*api.Volume)
+	original.State = previous
+	if t.during != api.VolumeState_VOLUME_STATE_NONE {
+		v.State = t.during
+		if err := s.store.UpdateVol(v); err != nil {
+			return err
+		}
+	}
+
+	if err := fn(v); err != nil {
+		if t.during != api.VolumeState_VOLUME_STATE_NONE {
+			if rbErr := s.store.UpdateVol(original); rbErr != nil {
+				dlog.Warnf("Failed to return volume %v to state %v after "+
+					"failed %v: %v", volumeID, previous, op, rbErr)
+			}
+		}
+		return err
+	}
+
+	if op == OpDelete {
+		return s.store.DeleteVol(volumeID)
+	}
+	v.Error = ""
+	v.State = previous
+	if t.to != api.VolumeState_VOLUME_STATE_NONE {
+		v.State = t.to
+	}
+	return s.store.UpdateVol(v)
+}
+
+// Update runs fn on volumeID with the volume locked and stores the changes
+// fn makes to the volume if it succeeds. The state of the volume is not
+// changed or checked.
+func (s *StateMachine) Update(volumeID string, fn func(v *api.Volume) error) error {
+	token, err := s.store.Lock(volumeID)
+	if err != nil {
+		return err
+	}
+	defer s.unlock(volumeID, token)
+
+	v, err := s.store.GetVol(volumeID)
+	if err != nil {
+		return err
+	}
+	if err := fn(v); err != nil {
+		return err
+	}
+	return s.store.UpdateVol(v)
+}
+
+func (s *StateMachine) unlock(volumeID string, token interface{}) {
+	if err := s.store.Unlock(token); err != nil {
+		dlog.Warnf("Failed to unlock volume %v: %v", volumeID, err)
+	}
+}
+
+// check returns the error for running op on a volume in state.
+func (t *transition) check(volumeID string, op Operation, state api.VolumeState) error {
+	for _, from := range t.from {
+		if from == state {
+			return nil
+		}
+	}
+	switch state {
+	case api.VolumeState_VOLUME_STATE_PENDING,
+		api.VolumeState_VOLUME_STATE_DETATCHING,
+		api.VolumeState_VOLUME_STATE_TRY_DETACHING,
+		api.VolumeState_VOLUME_STATE_RESTORE:
+		return volume.ErrVolBusy
+	case api.VolumeState_VOLUME_STATE_ATTACHED:
+		return volume.ErrVolAttached
+	}
+	return &ErrInvalidTransition{ID: volumeID, Op: op, State: state}
+}
@@ -0,0 +1,174 @@
+package common
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+func TestStateMachineLifecycle(t *testing.T) {
+	sm := NewStateMachine(testEnumerator)
+	v := newTestVolume("StateMachineVolume")
+	states := func() api.VolumeState {
+		stored, err := testEnumerator.GetVol(v.Id)
+		require.NoError(t, err)
+		return stored.State
+	}
+
+	require.NoError(t, sm.Create(v, func(v *api.Volume) error {
+		assert.Equal(t, api.VolumeState_VOLUME_STATE_PENDING, states())
+		v.DevicePath = "/dev/test"
+		return nil
+	}))
+	assert.Equal(t, api.VolumeState_VOLUME_STATE_AVAILABLE, states())
+
+	require.NoError(t, sm.Transition(v.Id, OpAttach, func(v *api.Volume) error {
+		assert.Equal(t, api.VolumeState_VOLUME_STATE_PENDING, states())
+		return nil
+	}))
+	assert.Equal(t, api.VolumeState_VOLUME_STATE_ATTACHED, states())
+
+	require.NoError(t, sm.Transition(v.Id, OpMount, func(v *api.Volume) error {
+		v.AttachPath = []string{"/mnt/test"}
+		return nil
+	}))
+	stored, err := testEnumerator.GetVol(v.Id)
+	require.NoError(t, err)
+	assert.Equal(t, api.VolumeState_VOLUME_STATE_ATTACHED, stored.State)
+	assert.Equal(t, []string{"/mnt/test"}, stored.AttachPath)
+	assert.Equal(t, "/dev/test", stored.DevicePath)
+
+	// Attached volumes cannot be deleted or restored.
+	assert.Equal(t, volume.ErrVolAttached, sm.Transition(v.Id, OpDelete, nil))
+	assert.Equal(t, volume.ErrVolAttached, sm.Transition(v.Id, OpRestore, nil))
+
+	require.NoError(t, sm.Transition(v.Id, OpDetach, func(v *api.Volume) error {
+		assert.Equal(t, api.VolumeState_VOLUME_STATE_DETATCHING, states())
+		return nil
+	}))
+	assert.Equal(t, api.VolumeState_VOLUME_STATE_DETACHED, states())
+
+	require.NoError(t, sm.Transition(v.Id, OpRestore, func(v *api.Volume) error {
+		assert.Equal(t, api.VolumeState_VOLUME_STATE_RESTORE, states())
+		return nil
+	}))
+	assert.Equal(t, api.VolumeState_VOLUME_STATE_DETACHED, states())
+
+	require.NoError(t, sm.Transition(v.Id, OpDelete, func(v *api.Volume) error {
+		assert.Equal(t, api.VolumeState_VOLUME_STATE_DELETED, states())
+		return nil
+	}))
+	_, err = testEnumerator.GetVol(v.Id)
+	assert.Error(t, err)
+}
+
+func TestStateMachineRollback(t *testing.T) {
+	sm := NewStateMachine(testEnumerator)
+	v := newTestVolume("StateMachineRollback")
+	failed := errors.New("failed")
+
+	assert.Equal(t, failed, sm.Create(v, func(v *api.Volume) error {
+		return failed
+	}))
+	_, err := testEnumerator.GetVol(v.Id)
+	assert.Error(t, err, "Volume kept after failed create")
+
+	require.NoError(t, sm.Create(v, func(v *api.Volume) error { return nil }))
+	defer testEnumerator.DeleteVol(v.Id)
+
+	assert.Equal(t, failed, sm.Transition(v.Id, OpDelete, func(v *api.Volume) error {
+		v.DevicePath = "/dev/changed"
+		return failed
+	}))
+	stored, err := testEnumerator.GetVol(v.Id)
+	require.NoError(t, err)
+	assert.Equal(t, api.VolumeState_VOLUME_STATE_AVAILABLE, stored.State)
+	assert.Empty(t, stored.DevicePath)
+}
+
+func TestStateMachineIllegalTransitions(t *testing.T) {
+	sm := NewStateMachine(testEnumerator)
+	v := newTestVolume("StateMachineIllegal")
+	for _, state := range []api.VolumeState{
+		api.VolumeState_VOLUME_STATE_PENDING,
+		api.VolumeState_VOLUME_STATE_DETATCHING,
+		api.VolumeState_VOLUME_STATE_TRY_DETACHING,
+		api.VolumeState_VOLUME_STATE_RESTORE,
+	} {
+		v.State = state
+		require.NoError(t, testEnumerator.UpdateVol(v))
+		for _, op := range []Operation{OpAttach, OpDetach, OpMount, OpRestore, OpDelete} {
+			assert.Equal(t, volume.ErrVolBusy, sm.Transition(v.Id, op, nil),
+				"%v in state %v", op, state)
+		}
+	}
+
+	v.State = api.VolumeState_VOLUME_STATE_DELETED
+	require.NoError(t, testEnumerator.UpdateVol(v))
+	err := sm.Transition(v.Id, OpMount, nil)
+	require.IsType(t, &ErrInvalidTransition{}, err)
+	assert.Equal(t, OpMount, err.(*ErrInvalidTransition).Op)
+	assert.Equal(t, api.VolumeState_VOLUME_STATE_DELETED, err.(*ErrInvalidTransition).State)
+
+	// Volumes stored without a state are available.
+	v.State = api.VolumeState_VOLUME_STATE_NONE
+	require.NoError(t, testEnumerator.UpdateVol(v))
+	assert.NoError(t, sm.Transition(v.Id, OpDelete, func(v *api.Volume) error { return nil }))
+}
+
+func TestEnumerateWhileLocked(t *testing.T) {
+	v := newTestVolume("LockedVolume")
+	require.NoError(t, testEnumerator.CreateVol(v))
+	defer testEnumerator.DeleteVol(v.Id)
+	token, err := testEnumerator.Lock(v.Id)
+	require.NoError(t, err)
+	defer testEnumerator.Unlock(token)
+
+	volumes, err := testEnumerator.Enumerate(&api.VolumeLocator{Name: v.Id}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, volumes, 1)
+}
+
+func TestStateMachineUpdate(t *testing.T) {
+	sm := NewStateMachine(testEnumerator)
+	v := newTestVolume("StateMachineUpdate")
+	require.NoError(t, sm.Create(v, func(v *api.Volume) error { return nil }))
+	defer testEnumerator.DeleteVol(v.Id)
+
+	// Updates wait for a transition in progress and keep its changes.
+	inTransition := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- sm.Transition(v.Id, OpMount, func(v *api.Volume) error {
+			close(inTransition)
+			time.Sleep(100 * time.Millisecond)
+			v.AttachPath = []string{"/mnt/update"}
+			return nil
+		})
+	}()
+	<-inTransition
+	require.NoError(t, sm.Update(v.Id, func(v *api.Volume) error {
+		v.Locator.Name = "renamed"
+		return nil
+	}))
+	require.NoError(t, <-done)
+	stored, err := testEnumerator.GetVol(v.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", stored.Locator.Name)
+	assert.Equal(t, []string{"/mnt/update"}, stored.AttachPath)
+
+	failed := errors.New("failed")
+	assert.Equal(t, failed, sm.Update(v.Id, func(v *api.Volume) error {
+		v.Locator.Name = "discarded"
+		return failed
+	}))
+	stored, err = testEnumerator.GetVol(v.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", stored.Locator.Name, "Failed update stored")
+}
@@ -0,0 +1,291 @@
+package common
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+const (
+	procDiskstats = "/proc/diskstats"
+	procUptime    = "/proc/uptime"
+	sectorSize    = 512
+	// pruneInterval is the minimum time between two sweeps for the samples
+	// of deleted volumes.
+	pruneInterval = time.Minute
+)
+
+// DataPathFunc returns the directory that holds the data of a volume.
+type DataPathFunc func(v *api.Volume) (string, error)
+
+type statsDriver struct {
+	store    volume.Store
+	dataPath DataPathFunc
+	sync.Mutex
+	// samples holds the last interval sample taken per volume.
+	samples map[string]*statsSample
+	// pruned is when samples was last swept.
+	pruned time.Time
+}
+
+type statsSample struct {
+	stats *api.Stats
+	at    time.Time
+}
+
+// NewStatsDriver returns a StatsDriver for file based volumes. Used size is
+// computed by walking the directory returned by dataPath. IO stats are read
+// from /proc/diskstats and are only available for volumes whose DevicePath
+// is a block device; the device of the filesystem holding the data is shared
+// with other volumes, so the IO counters of other volumes are zero.
+func NewStatsDriver(store volume.Store, dataPath DataPathFunc) volume.StatsDriver {
+	return &statsDriver{
+		store:    store,
+		dataPath: dataPath,
+		samples:  make(map[string]*statsSample),
+	}
+}
+
+// Stats returns cumulative stats since boot, or stats for the interval
+// since the previous non-cumulative call for the volume.
+func (s *statsDriver) Stats(volumeID string, cumulative bool) (*api.Stats, error) {
+	v, err := s.store.GetVol(volumeID)
+	if err != nil {
+		s.forget(volumeID)
+		return nil, err
+	}
+	dir, err := s.dataPath(v)
+	if err != nil {
+		return nil, err
+	}
+	used, err := dirUsage(dir)
+	if err != nil {
+		return nil, err
+	}
+	stats := &api.Stats{}
+	if major, minor, ok := blockDevice(v); ok {
+		if stats, err = diskstats(major, minor); err != nil {
+			return nil, err
+		}
+	}
+	stats.BytesUsed = used
+
+	now := time.Now()
+	if cumulative {
+		stats.IntervalMs, err = uptimeMs()
+		if err != nil {
+			return nil, err
+		}
+		return stats, nil
+	}
+
+	s.Lock()
+	prev, ok := s.samples[volumeID]
+	s.samples[volumeID] = &statsSample{stats: stats, at: now}
+	s.Unlock()
+	s.prune(now)
+
+	if !ok {
+		// Without a previous sample, report the average since boot.
+		stats.IntervalMs, err = uptimeMs()
+		if err != nil {
+			return nil, err
+		}
+		return stats, nil
+	}
+	return statsDelta(prev.stats, stats, now.Sub(prev.at)), nil
+}
+
+// forget drops the sample of volumeID.
+func (s *statsDriver) forget(volumeID string) {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.samples, volumeID)
+}
+
+// prune drops the samples of deleted volumes, at most once per
+// pruneInterval.
+func (s *statsDriver) prune(now time.Time) {
+	s.Lock()
+	if now.Sub(s.pruned) < pruneInterval {
+		s.Unlock()
+		return
+	}
+	s.pruned = now
+	ids := make([]string, 0, len(s.samples))
+	for id := range s.samples {
+		ids = append(ids, id)
+	}
+	s.Unlock()
+
+	for _, id := range ids {
+		if _, err := s.store.GetVol(id); err != nil {
+			s.forget(id)
+		}
+	}
+}
+
+// UsedSize returns the space allocated to the files of the volume.
+func (s *statsDriver) UsedSize(volumeID string) (uint64, error) {
+	v, err := s.store.GetVol(volumeID)
+	if err != nil {
+		return 0, err
+	}
+	dir, err := s.dataPath(v)
+	if err != nil {
+		return 0, err
+	}
+	return dirUsage(dir)
+}
+
+// GetActiveRequests is not supported for file based volumes.
+func (s *statsDriver) GetActiveRequests() (*api.ActiveRequests, error) {
+	return nil, volume.ErrNotSupported
+}
+
+// dirUsage returns the allocated size of all files under dir. Hard linked
+// files are only counted once.
+func dirUsage(dir string) (uint64, error) {
+	var used uint64
+	seen := make(map[uint64]bool)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				// Removed while walking.
+				return nil
+			}
+			return err
+		}
+		st, ok := info.Sys().(*syscall.Stat_t)
+		if !ok {
+			used += uint64(info.Size())
+			return nil
+		}
+		if st.Nlink > 1 && !info.IsDir() {
+			if seen[st.Ino] {
+				return nil
+			}
+			seen[st.Ino] = true
+		}
+		used += uint64(st.Blocks) * sectorSize
+		return nil
+	})
+	return used, err
+}
+
+// blockDevice returns the device numbers of the block device of v, if its
+// DevicePath is one.
+func blockDevice(v *api.Volume) (uint32, uint32, bool) {
+	var st syscall.Stat_t
+	if v.DevicePath == "" || syscall.Stat(v.DevicePath, &st) != nil ||
+		st.Mode&syscall.S_IFMT != syscall.S_IFBLK {
+		return 0, 0, false
+	}
+	return devMajor(uint64(st.Rdev)), devMinor(uint64(st.Rdev)), true
+}
+
+// diskstats returns the stats of the specified device since boot.
+func diskstats(major, minor uint32) (*api.Stats, error) {
+	f, err := os.Open(procDiskstats)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseDiskstats(f, major, minor)
+}
+
+// parseDiskstats parses /proc/diskstats formatted input and returns the
+// stats of the specified device. A device that is not listed has no stats.
+func parseDiskstats(r io.Reader, major, minor uint32) (*api.Stats, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 14 {
+			continue
+		}
+		if fields[0] != strconv.FormatUint(uint64(major), 10) ||
+			fields[1] != strconv.FormatUint(uint64(minor), 10) {
+			continue
+		}
+		var (
+			values [11]uint64
+			err    error
+		)
+		for i := range values {
+			if values[i], err = strconv.ParseUint(fields[i+3], 10, 64); err != nil {
+				return nil, fmt.Errorf("Invalid diskstats for %s: %v", fields[2], err)
+			}
+		}
+		return &api.Stats{
+			Reads:      values[0],
+			ReadBytes:  values[2] * sectorSize,
+			ReadMs:     values[3],
+			Writes:     values[4],
+			WriteBytes: values[6] * sectorSize,
+			WriteMs:    values[7],
+			IoProgress: values[8],
+			IoMs:       values[9],
+		}, nil
+	}
+	return &api.Stats{}, scanner.Err()
+}
+
+// statsDelta returns the stats accumulated between prev and cur.
+func statsDelta(prev, cur *api.Stats, interval time.Duration) *api.Stats {
+	return &api.Stats{
+		Reads:      sub(cur.Reads, prev.Reads),
+		ReadMs:     sub(cur.ReadMs, prev.ReadMs),
+		ReadBytes:  sub(cur.ReadBytes, prev.ReadBytes),
+		Writes:     sub(cur.Writes, prev.Writes),
+		WriteMs:    sub(cur.WriteMs, prev.WriteMs),
+		WriteBytes: sub(cur.WriteBytes, prev.WriteBytes),
+		IoProgress: cur.IoProgress,
+		IoMs:       sub(cur.IoMs, prev.IoMs),
+		BytesUsed:  cur.BytesUsed,
+		IntervalMs: uint64(interval / time.Millisecond),
+	}
+}
+
+// sub returns a - b, or 0 if the counter was reset.
+func sub(a, b uint64) uint64 {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
+
+// uptimeMs returns the time since boot in milliseconds.
+func uptimeMs() (uint64, error) {
+	b, err := ioutil.ReadFile(procUptime)
+	if err != nil {
+		return 0, err
+	}
+	fields := strings.Fields(string(b))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("Invalid %s", procUptime)
+	}
+	secs, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(secs * 1000), nil
+}
+
+func devMajor(dev uint64) uint32 {
+	return uint32(((dev >> 8) & 0xfff) | ((dev >> 32) & 0xfffff000))
+}
+
+func devMinor(dev uint64) uint32 {
+	return uint32((dev & 0xff) | ((dev >> 12) & 0xffffff00))
+}
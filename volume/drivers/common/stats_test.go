@@ -0,0 +1,116 @@
+package common
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDiskstats = `   8       0 sda 1000 10 20000 300 500 5 8000 700 2 900 1000 0 0 0 0
+   8       1 sda1 100 1 2000 30 50 0 800 70 0 90 100 0 0 0 0
+`
+
+func TestParseDiskstats(t *testing.T) {
+	stats, err := parseDiskstats(strings.NewReader(testDiskstats), 8, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), stats.Reads)
+	assert.Equal(t, uint64(2000*512), stats.ReadBytes)
+	assert.Equal(t, uint64(50), stats.Writes)
+	assert.Equal(t, uint64(800*512), stats.WriteBytes)
+	assert.Equal(t, uint64(90), stats.IoMs)
+
+	stats, err = parseDiskstats(strings.NewReader(testDiskstats), 9, 0)
+	require.NoError(t, err)
+	assert.Equal(t, &api.Stats{}, stats, "Unknown device should have no stats")
+}
+
+func TestStatsDelta(t *testing.T) {
+	prev := &api.Stats{Reads: 100, Writes: 100, ReadBytes: 4096}
+	cur := &api.Stats{Reads: 300, Writes: 500, ReadBytes: 8192, BytesUsed: 10}
+	delta := statsDelta(prev, cur, 2*time.Second)
+	assert.Equal(t, uint64(2000), delta.IntervalMs)
+	assert.Equal(t, uint64(300), delta.Iops())
+	assert.Equal(t, uint64(2048), delta.ReadThroughput())
+	assert.Equal(t, uint64(10), delta.BytesUsed)
+}
+
+func TestStatsDriver(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stats_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "data"), make([]byte, 64*1024), 0644))
+
+	vol := newTestVolume("StatsVolume")
+	require.NoError(t, testEnumerator.CreateVol(vol))
+	defer testEnumerator.DeleteVol(vol.Id)
+
+	d := NewStatsDriver(testEnumerator, func(*api.Volume) (string, error) {
+		return dir, nil
+	})
+	used, err := d.UsedSize(vol.Id)
+	require.NoError(t, err)
+	assert.True(t, used >= 64*1024, "Used size %v too small", used)
+
+	// Volumes without a block device do not report the IO of their
+	// filesystem.
+	stats, err := d.Stats(vol.Id, true)
+	require.NoError(t, err)
+	assert.Equal(t, used, stats.BytesUsed)
+	assert.NotZero(t, stats.IntervalMs)
+	assert.Zero(t, stats.Reads+stats.Writes+stats.ReadBytes+stats.WriteBytes)
+	stats, err = d.Stats(vol.Id, false)
+	require.NoError(t, err)
+	assert.Equal(t, used, stats.BytesUsed)
+	assert.Zero(t, stats.Reads+stats.Writes)
+
+	devicePath := testBlockDevice()
+	if devicePath == "" {
+		t.Skip("No block device found, skipping IO stats")
+	}
+	vol.DevicePath = devicePath
+	require.NoError(t, testEnumerator.UpdateVol(vol))
+
+	stats, err = d.Stats(vol.Id, true)
+	require.NoError(t, err)
+	assert.Equal(t, used, stats.BytesUsed)
+	assert.NotZero(t, stats.IntervalMs)
+
+	stats, err = d.Stats(vol.Id, false)
+	require.NoError(t, err)
+	assert.Equal(t, used, stats.BytesUsed)
+
+	// The samples of deleted volumes are dropped.
+	sd := d.(*statsDriver)
+	assert.Len(t, sd.samples, 1)
+	require.NoError(t, testEnumerator.DeleteVol(vol.Id))
+	sd.prune(time.Now().Add(pruneInterval))
+	assert.Empty(t, sd.samples)
+}
+
+// testBlockDevice returns the path of a block device listed in diskstats.
+func testBlockDevice() string {
+	b, err := ioutil.ReadFile(procDiskstats)
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(b), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		var st syscall.Stat_t
+		path := filepath.Join("/dev", fields[2])
+		if syscall.Stat(path, &st) == nil && st.Mode&syscall.S_IFMT == syscall.S_IFBLK {
+			return path
+		}
+	}
+	return ""
+}
@@ -0,0 +1,301 @@
+package common
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/portworx/kvdb"
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/mount"
+	"github.com/libopenstorage/openstorage/pkg/proto/time"
+	"github.com/libopenstorage/openstorage/pkg/sched"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+const (
+	// TrashRetentionParam is the driver parameter that enables the trash.
+	// Its value is how long deleted volumes are kept, e.g. "72h".
+	TrashRetentionParam = "trash_retention"
+	// maxPurgeInterval is the longest time between two purges of the trash.
+	maxPurgeInterval = time.Hour
+)
+
+// PurgeFunc removes the data of v permanently.
+type PurgeFunc func(v *api.Volume) error
+
+// Trash keeps the volumes deleted from a driver for a retention period
+// before purging them. A volume in the trash is in the DELETED state and has
+// its PurgeTime set. Trash implements volume.TrashDriver; the trash
+// operations return ErrNotSupported if the trash is not enabled.
+type Trash struct {
+	store     volume.StoreEnumerator
+	states    *StateMachine
+	purge     PurgeFunc
+	retention time.Duration
+	task      sched.TaskID
+}
+
+// NewTrash returns the trash of a driver started with params. purge removes
+// the data of a volume. The trash is enabled if params sets
+// TrashRetentionParam, in which case expired volumes are purged periodically
+// by the scheduler instance.
+func NewTrash(
+	store volume.StoreEnumerator,
+	states *StateMachine,
+	params map[string]string,
+	purge PurgeFunc,
+) (*Trash, error) {
+	t := &Trash{store: store, states: states, purge: purge}
+	retention, ok := params[TrashRetentionParam]
+	if !ok {
+		return t, nil
+	}
+	d, err := time.ParseDuration(retention)
+	if err != nil || d <= 0 {
+		return nil, fmt.Errorf("Invalid %v %q, must be a positive duration",
+			TrashRetentionParam, retention)
+	}
+	t.retention = d
+
+	s := sched.Instance()
+	if s == nil {
+		dlog.Warnf("No scheduler, expired volumes in the trash are not purged")
+		return t, nil
+	}
+	interval := d
+	if interval > maxPurgeInterval {
+		interval = maxPurgeInterval
+	} else if interval < time.Second {
+		interval = time.Second
+	}
+	t.task, err = s.Schedule(
+		func(sched.Interval) { t.PurgeExpired() },
+		sched.Periodic(interval),
+		time.Now(),
+		false,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Enabled returns true if deleted volumes go to the trash.
+func (t *Trash) Enabled() bool {
+	return t != nil && t.retention > 0
+}
+
+// Stop stops purging expired volumes.
+func (t *Trash) Stop() {
+	if !t.Enabled() || !sched.ValidTaskID(t.task) || sched.Instance() == nil {
+		return
+	}
+	if err := sched.Instance().Cancel(t.task); err != nil {
+		dlog.Warnf("Failed to cancel trash purge: %v", err)
+	}
+	t.task = sched.TaskNone
+}
+
+// InTrash returns true if v is in the trash.
+func InTrash(v *api.Volume) bool {
+	return v.State == api.VolumeState_VOLUME_STATE_DELETED && v.PurgeTime != nil
+}
+
+// Visible returns store with the volumes in the trash left out of its
+// listings. Drivers list their volumes through it so that deleted volumes
+// only show in TrashEnumerate. Inspect still returns a volume in the trash,
+// in the DELETED state with its PurgeTime set, so it can be restored or
+// purged by ID. store is returned unchanged if the trash is not enabled.
+func (t *Trash) Visible(store volume.StoreEnumerator) volume.StoreEnumerator {
+	if !t.Enabled() {
+		return store
+	}
+	return &visibleStore{store}
+}
+
+// visibleStore hides the volumes in the trash from the listings of a store.
+type visibleStore struct {
+	volume.StoreEnumerator
+}
+
+func (s *visibleStore) Enumerate(
+	locator *api.VolumeLocator,
+	labels map[string]string,
+) ([]*api.Volume, error) {
+	volumes, err := s.StoreEnumerator.Enumerate(locator, labels)
+	if err != nil {
+		return nil, err
+	}
+	return notInTrash(volumes), nil
+}
+
+// EnumeratePage fills the page with volumes that are not in the trash,
+// reading further pages of the store as needed.
+func (s *visibleStore) EnumeratePage(
+	filter *api.VolumeFilter,
+	pageSize int,
+	token string,
+) (*api.VolumeEnumerateResponse, error) {
+	resp := &api.VolumeEnumerateResponse{Volumes: make([]*api.Volume, 0)}
+	for {
+		size := 0
+		if pageSize > 0 {
+			size = pageSize - len(resp.Volumes)
+		}
+		page, err := s.StoreEnumerator.EnumeratePage(filter, size, token)
+		if err != nil {
+			return nil, err
+		}
+		resp.Volumes = append(resp.Volumes, notInTrash(page.Volumes)...)
+		resp.NextToken = page.NextToken
+		if page.NextToken == "" ||
+			(pageSize > 0 && len(resp.Volumes) == pageSize) {
+			return resp, nil
+		}
+		token = page.NextToken
+	}
+}
+
+func (s *visibleStore) SnapEnumerate(
+	volumeIDs []string,
+	labels map[string]string,
+) ([]*api.Volume, error) {
+	volumes, err := s.StoreEnumerator.SnapEnumerate(volumeIDs, labels)
+	if err != nil {
+		return nil, err
+	}
+	return notInTrash(volumes), nil
+}
+
+// notInTrash returns the volumes out of volumes that are not in the trash.
+func notInTrash(volumes []*api.Volume) []*api.Volume {
+	visible := make([]*api.Volume, 0, len(volumes))
+	for _, v := range volumes {
+		if !InTrash(v) {
+			visible = append(visible, v)
+		}
+	}
+	return visible
+}
+
+// MoveToTrash unmounts volumeID and moves it to the trash. Deleting a
+// volume that is already in the trash purges it.
+func (t *Trash) MoveToTrash(volumeID string) error {
+	if !t.Enabled() {
+		return volume.ErrNotSupported
+	}
+	v, err := t.get(volumeID)
+	if err != nil {
+		return err
+	}
+	if InTrash(v) {
+		return t.TrashPurge(volumeID)
+	}
+	return t.states.Transition(volumeID, OpTrash, func(v *api.Volume) error {
+		for _, p := range MountedPaths(v.AttachPath) {
+			if err := (&mount.DefaultMounter{}).Unmount(p, 0, 0); err != nil {
+				return fmt.Errorf("Failed to unmount %v: %v", p, err)
+			}
+		}
+		v.AttachPath = nil
+		v.AttachedOn = ""
+		v.PurgeTime = prototime.TimeToTimestamp(time.Now().Add(t.retention))
+		dlog.Infof("Volume %v moved to the trash until %v", volumeID,
+			prototime.TimestampToTime(v.PurgeTime))
+		return nil
+	})
+}
+
+// TrashEnumerate returns the volumes in the trash.
+func (t *Trash) TrashEnumerate() ([]*api.Volume, error) {
+	if !t.Enabled() {
+		return nil, volume.ErrNotSupported
+	}
+	volumes, err := t.store.Enumerate(&api.VolumeLocator{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	trashed := make([]*api.Volume, 0)
+	for _, v := range volumes {
+		if InTrash(v) {
+			trashed = append(trashed, v)
+		}
+	}
+	return trashed, nil
+}
+
+// TrashRestore moves volumeID out of the trash. The volume is available.
+func (t *Trash) TrashRestore(volumeID string) error {
+	if !t.Enabled() {
+		return volume.ErrNotSupported
+	}
+	if _, err := t.trashed(volumeID); err != nil {
+		return err
+	}
+	return t.states.Transition(volumeID, OpUntrash, func(v *api.Volume) error {
+		if !InTrash(v) {
+			return volume.ErrNotInTrash
+		}
+		v.PurgeTime = nil
+		return nil
+	})
+}
+
+// TrashPurge deletes volumeID from the trash.
+func (t *Trash) TrashPurge(volumeID string) error {
+	if !t.Enabled() {
+		return volume.ErrNotSupported
+	}
+	if _, err := t.trashed(volumeID); err != nil {
+		return err
+	}
+	return t.states.Transition(volumeID, OpDelete, func(v *api.Volume) error {
+		if v.PurgeTime == nil {
+			return volume.ErrNotInTrash
+		}
+		return t.purge(v)
+	})
+}
+
+// PurgeExpired deletes the volumes whose retention period has expired.
+func (t *Trash) PurgeExpired() {
+	volumes, err := t.TrashEnumerate()
+	if err != nil {
+		dlog.Warnf("Failed to enumerate the trash: %v", err)
+		return
+	}
+	now := time.Now()
+	for _, v := range volumes {
+		if prototime.TimestampToTime(v.PurgeTime).After(now) {
+			continue
+		}
+		if err := t.TrashPurge(v.Id); err != nil {
+			dlog.Warnf("Failed to purge volume %v from the trash: %v", v.Id, err)
+			continue
+		}
+		dlog.Infof("Purged volume %v from the trash", v.Id)
+	}
+}
+
+// get returns volumeID from the store.
+func (t *Trash) get(volumeID string) (*api.Volume, error) {
+	v, err := t.store.GetVol(volumeID)
+	if err == kvdb.ErrNotFound {
+		return nil, volume.ErrEnoEnt
+	}
+	return v, err
+}
+
+// trashed returns volumeID if it is in the trash.
+func (t *Trash) trashed(volumeID string) (*api.Volume, error) {
+	v, err := t.get(volumeID)
+	if err != nil {
+		return nil, err
+	}
+	if !InTrash(v) {
+		return nil, volume.ErrNotInTrash
+	}
+	return v, nil
+}
@@ -0,0 +1,118 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/proto/time"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+func TestTrashDisabled(t *testing.T) {
+	sm := NewStateMachine(testEnumerator)
+	_, err := NewTrash(testEnumerator, sm, map[string]string{TrashRetentionParam: "forever"}, nil)
+	assert.Error(t, err)
+
+	trash, err := NewTrash(testEnumerator, sm, nil, nil)
+	require.NoError(t, err)
+	assert.False(t, trash.Enabled())
+	assert.Equal(t, testEnumerator, trash.Visible(testEnumerator))
+	assert.Equal(t, volume.ErrNotSupported, trash.MoveToTrash("TrashDisabled"))
+	_, err = trash.TrashEnumerate()
+	assert.Equal(t, volume.ErrNotSupported, err)
+	assert.Equal(t, volume.ErrNotSupported, trash.TrashRestore("TrashDisabled"))
+	assert.Equal(t, volume.ErrNotSupported, trash.TrashPurge("TrashDisabled"))
+}
+
+func TestTrash(t *testing.T) {
+	sm := NewStateMachine(testEnumerator)
+	purged := make(map[string]bool)
+	trash, err := NewTrash(testEnumerator, sm,
+		map[string]string{TrashRetentionParam: "1h"},
+		func(v *api.Volume) error {
+			purged[v.Id] = true
+			return nil
+		})
+	require.NoError(t, err)
+	defer trash.Stop()
+	assert.True(t, trash.Enabled())
+
+	v := newTestVolume("TrashVolume")
+	require.NoError(t, sm.Create(v, func(v *api.Volume) error { return nil }))
+	defer testEnumerator.DeleteVol(v.Id)
+
+	assert.Equal(t, volume.ErrEnoEnt, trash.MoveToTrash("NoSuchVolume"))
+	assert.Equal(t, volume.ErrNotInTrash, trash.TrashRestore(v.Id))
+	assert.Equal(t, volume.ErrNotInTrash, trash.TrashPurge(v.Id))
+
+	// Deleted volumes are kept in the trash until their purge time.
+	require.NoError(t, trash.MoveToTrash(v.Id))
+	stored, err := testEnumerator.GetVol(v.Id)
+	require.NoError(t, err)
+	assert.Equal(t, api.VolumeState_VOLUME_STATE_DELETED, stored.State)
+	require.NotNil(t, stored.PurgeTime)
+	assert.WithinDuration(t, time.Now().Add(time.Hour),
+		prototime.TimestampToTime(stored.PurgeTime), time.Minute)
+	trashed, err := trash.TrashEnumerate()
+	require.NoError(t, err)
+	require.Len(t, trashed, 1)
+	assert.Equal(t, v.Id, trashed[0].Id)
+
+	// Volumes in the trash are left out of the listings but can be inspected.
+	other := newTestVolume("TrashVolumeVisible")
+	require.NoError(t, sm.Create(other, func(v *api.Volume) error { return nil }))
+	defer testEnumerator.DeleteVol(other.Id)
+	visible := trash.Visible(testEnumerator)
+	volumes, err := visible.Enumerate(&api.VolumeLocator{Name: v.Id}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, volumes)
+	page, err := visible.EnumeratePage(&api.VolumeFilter{NamePrefix: "TrashVolume"}, 1, "")
+	require.NoError(t, err)
+	require.Len(t, page.Volumes, 1)
+	assert.Equal(t, other.Id, page.Volumes[0].Id)
+	volumes, err = visible.Inspect([]string{v.Id})
+	require.NoError(t, err)
+	require.Len(t, volumes, 1)
+	assert.True(t, InTrash(volumes[0]))
+
+	// Volumes in the trash cannot be used and are not recovered at start.
+	assert.IsType(t, &ErrInvalidTransition{}, sm.Transition(v.Id, OpMount, nil))
+	require.NoError(t, NewReconciler(testEnumerator, func(v *api.Volume) (*Backing, error) {
+		return &Backing{}, nil
+	}, nil).Reconcile())
+	stored, err = testEnumerator.GetVol(v.Id)
+	require.NoError(t, err)
+	assert.True(t, InTrash(stored))
+	trash.PurgeExpired()
+	assert.Empty(t, purged)
+
+	require.NoError(t, trash.TrashRestore(v.Id))
+	stored, err = testEnumerator.GetVol(v.Id)
+	require.NoError(t, err)
+	assert.Equal(t, api.VolumeState_VOLUME_STATE_AVAILABLE, stored.State)
+	assert.Nil(t, stored.PurgeTime)
+
+	// Deleting a volume in the trash purges it.
+	require.NoError(t, trash.MoveToTrash(v.Id))
+	require.NoError(t, trash.MoveToTrash(v.Id))
+	assert.True(t, purged[v.Id])
+	_, err = testEnumerator.GetVol(v.Id)
+	assert.Error(t, err)
+
+	// Expired volumes are purged.
+	expired := newTestVolume("TrashExpired")
+	require.NoError(t, sm.Create(expired, func(v *api.Volume) error { return nil }))
+	require.NoError(t, trash.MoveToTrash(expired.Id))
+	expired, err = testEnumerator.GetVol(expired.Id)
+	require.NoError(t, err)
+	expired.PurgeTime = prototime.TimeToTimestamp(time.Now().Add(-time.Minute))
+	require.NoError(t, testEnumerator.UpdateVol(expired))
+	trash.PurgeExpired()
+	assert.True(t, purged[expired.Id])
+	_, err = testEnumerator.GetVol(expired.Id)
+	assert.Error(t, err)
+}
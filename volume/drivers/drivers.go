@@ -6,6 +6,7 @@ import (
 	"github.com/libopenstorage/openstorage/api"
 	"github.com/libopenstorage/openstorage/volume"
 	"github.com/libopenstorage/openstorage/volume/drivers/aws"
+	"github.com/libopenstorage/openstorage/volume/drivers/azure"
 	"github.com/libopenstorage/openstorage/volume/drivers/btrfs"
 	"github.com/libopenstorage/openstorage/volume/drivers/buse"
 	"github.com/libopenstorage/openstorage/volume/drivers/coprhd"
@@ -26,6 +27,8 @@ var (
 	AllDrivers = []Driver{
 		// AWS driver provisions storage from EBS.
 		{DriverType: aws.Type, Name: aws.Name},
+		// Azure driver provisions storage from Azure managed disks.
+		{DriverType: azure.Type, Name: azure.Name},
 		// BTRFS driver provisions storage from local btrfs.
 		{DriverType: btrfs.Type, Name: btrfs.Name},
 		// BUSE driver provisions storage from local volumes and implements block in user space.
@@ -43,6 +46,7 @@ var (
 	volumeDriverRegistry = volume.NewVolumeDriverRegistry(
 		map[string]func(map[string]string) (volume.VolumeDriver, error){
 			aws.Name:    aws.Init,
+			azure.Name:  azure.Init,
 			btrfs.Name:  btrfs.Init,
 			buse.Name:   buse.Init,
 			coprhd.Name: coprhd.Init,
@@ -35,15 +35,21 @@ func newVolumeDriver(
 	baseDirPath string,
 	provider Provider,
 ) *volumeDriver {
+	store := common.NewDefaultStoreEnumerator(
+		name,
+		kvdb.Instance(),
+	)
 	return &volumeDriver{
 		volume.IONotSupported,
 		volume.BlockNotSupported,
 		volume.SnapshotNotSupported,
-		common.NewDefaultStoreEnumerator(
-			name,
-			kvdb.Instance(),
+		store,
+		common.NewStatsDriver(
+			store,
+			func(v *api.Volume) (string, error) {
+				return filepath.Join(baseDirPath, v.Id), nil
+			},
 		),
-		volume.StatsNotSupported,
 		volume.QuiesceNotSupported,
 		volume.CredsNotSupported,
 		volume.CloudBackupNotSupported,
@@ -278,6 +278,19 @@ func (mr *MockVolumeDriverMockRecorder) Enumerate(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enumerate", reflect.TypeOf((*MockVolumeDriver)(nil).Enumerate), arg0, arg1)
 }
 
+// EnumeratePage mocks base method
+func (m *MockVolumeDriver) EnumeratePage(arg0 *api.VolumeFilter, arg1 int, arg2 string) (*api.VolumeEnumerateResponse, error) {
+	ret := m.ctrl.Call(m, "EnumeratePage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*api.VolumeEnumerateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnumeratePage indicates an expected call of EnumeratePage
+func (mr *MockVolumeDriverMockRecorder) EnumeratePage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnumeratePage", reflect.TypeOf((*MockVolumeDriver)(nil).EnumeratePage), arg0, arg1, arg2)
+}
+
 // Flush mocks base method
 func (m *MockVolumeDriver) Flush(arg0 string) error {
 	ret := m.ctrl.Call(m, "Flush", arg0)
@@ -0,0 +1,245 @@
+package nfs
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/units"
+)
+
+const (
+	// HealthIntervalParam is the driver parameter with the time between two
+	// health probes of the NFS servers, e.g. "30s". "0" disables the probe.
+	HealthIntervalParam = "health_interval"
+	// defaultHealthInterval is the time between two health probes.
+	defaultHealthInterval = 30 * time.Second
+)
+
+var (
+	// probeTimeout is the time after which an NFS server that does not
+	// answer a probe is considered down. Tests replace it.
+	probeTimeout = 5 * time.Second
+
+	// probing lists the servers with a probe that has not returned yet.
+	probing     = make(map[string]bool)
+	probingLock sync.Mutex
+)
+
+// statfs returns the filesystem statistics of path. Tests replace it.
+var statfs = func(path string, buf *syscall.Statfs_t) error {
+	return syscall.Statfs(path, buf)
+}
+
+// serverStatus is the health of an NFS server.
+type serverStatus struct {
+	// up is false if the server did not answer the last probe.
+	up bool
+	// err is the reason the server is down.
+	err error
+	// free is the free space on the server in bytes.
+	free uint64
+}
+
+// serverHealth tracks the health of the NFS servers of the driver.
+type serverHealth struct {
+	sync.RWMutex
+	status map[string]*serverStatus
+	stop   chan struct{}
+}
+
+func newServerHealth(servers []string) *serverHealth {
+	h := &serverHealth{status: make(map[string]*serverStatus)}
+	for _, server := range servers {
+		h.status[server] = &serverStatus{up: true}
+	}
+	return h
+}
+
+// up returns the servers that answered the last probe, in order.
+func (h *serverHealth) up() []string {
+	h.RLock()
+	defer h.RUnlock()
+	servers := make([]string, 0, len(h.status))
+	for server, status := range h.status {
+		if status.up {
+			servers = append(servers, server)
+		}
+	}
+	sort.Strings(servers)
+	return servers
+}
+
+// isUp returns true if server is known and answered the last probe.
+func (h *serverHealth) isUp(server string) bool {
+	h.RLock()
+	defer h.RUnlock()
+	status, ok := h.status[server]
+	return ok && status.up
+}
+
+// set records the result of a probe of server and returns true if the
+// server went up or down.
+func (h *serverHealth) set(server string, free uint64, err error) bool {
+	h.Lock()
+	defer h.Unlock()
+	status, ok := h.status[server]
+	if !ok {
+		return false
+	}
+	changed := status.up != (err == nil)
+	status.up = err == nil
+	status.err = err
+	if err == nil {
+		status.free = free
+	}
+	return changed
+}
+
+// statusRows returns the status of the servers for driver.Status.
+func (h *serverHealth) statusRows() [][2]string {
+	h.RLock()
+	defer h.RUnlock()
+	servers := make([]string, 0, len(h.status))
+	for server := range h.status {
+		servers = append(servers, server)
+	}
+	sort.Strings(servers)
+	rows := make([][2]string, 0, len(servers))
+	for _, server := range servers {
+		status := h.status[server]
+		name := server
+		if name == "" {
+			name = "local"
+		}
+		state := fmt.Sprintf("Up, %s free", units.String(status.free))
+		if !status.up {
+			state = fmt.Sprintf("Down: %v", status.err)
+		}
+		rows = append(rows, [2]string{"Server " + name, state})
+	}
+	return rows
+}
+
+// serverFree returns the free space on server. A server that does not
+// answer within probeTimeout is down. A server is not probed again until
+// its previous probe returns, since a hung mount blocks the probe for good.
+func serverFree(server string) (uint64, error) {
+	type result struct {
+		free uint64
+		err  error
+	}
+	probingLock.Lock()
+	if probing[server] {
+		probingLock.Unlock()
+		return 0, fmt.Errorf("No answer from NFS server to a previous probe")
+	}
+	probing[server] = true
+	probingLock.Unlock()
+
+	done := make(chan result, 1)
+	go func() {
+		defer func() {
+			probingLock.Lock()
+			delete(probing, server)
+			probingLock.Unlock()
+		}()
+		var st syscall.Statfs_t
+		if err := statfs(path.Join(nfsMountPath, server), &st); err != nil {
+			done <- result{err: err}
+			return
+		}
+		done <- result{free: st.Bavail * uint64(st.Bsize)}
+	}()
+	select {
+	case r := <-done:
+		return r.free, r.err
+	case <-time.After(probeTimeout):
+		return 0, fmt.Errorf("No answer from NFS server after %v", probeTimeout)
+	}
+}
+
+// startHealthProbe probes the servers every interval until stopHealthProbe.
+func (d *driver) startHealthProbe(interval time.Duration) {
+	d.health.stop = make(chan struct{})
+	go func(stop chan struct{}) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				d.checkServers()
+			case <-stop:
+				return
+			}
+		}
+	}(d.health.stop)
+}
+
+func (d *driver) stopHealthProbe() {
+	if d.health.stop != nil {
+		close(d.health.stop)
+		d.health.stop = nil
+	}
+}
+
+// checkServers probes the servers and updates the status of the volumes on
+// the servers that went up or down.
+func (d *driver) checkServers() {
+	for _, server := range d.nfsServers {
+		free, err := serverFree(server)
+		if !d.health.set(server, free, err) {
+			continue
+		}
+		status := api.VolumeStatus_VOLUME_STATUS_UP
+		if err != nil {
+			dlog.Warnf("NFS server %q is down: %v", server, err)
+			status = api.VolumeStatus_VOLUME_STATUS_DOWN
+		} else {
+			dlog.Infof("NFS server %q is up", server)
+		}
+		if err := d.setServerVolumesStatus(server, status); err != nil {
+			dlog.Warnf("Failed to update the volumes on NFS server %q: %v",
+				server, err)
+		}
+	}
+}
+
+// setServerVolumesStatus sets the status of the volumes on server.
+func (d *driver) setServerVolumesStatus(server string, status api.VolumeStatus) error {
+	volumes, err := d.Enumerate(&api.VolumeLocator{
+		VolumeLabels: map[string]string{serverLabel: server},
+	}, nil)
+	if err != nil {
+		return err
+	}
+	for _, v := range volumes {
+		if v.GetLocator().GetVolumeLabels()[serverLabel] != server {
+			continue
+		}
+		if err := d.setVolumeStatus(v.Id, status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *driver) setVolumeStatus(volumeID string, status api.VolumeStatus) error {
+	token, err := d.Lock(volumeID)
+	if err != nil {
+		return err
+	}
+	defer d.Unlock(token)
+	v, err := d.GetVol(volumeID)
+	if err != nil || v.Status == status {
+		return err
+	}
+	v.Status = status
+	return d.UpdateVol(v)
+}
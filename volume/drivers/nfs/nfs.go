@@ -3,7 +3,6 @@ package nfs
 import (
 	"errors"
 	"fmt"
-	"io"
 	"os"
 	"path"
 	"strconv"
@@ -15,11 +14,10 @@ import (
 	"github.com/libopenstorage/openstorage/api"
 	"github.com/libopenstorage/openstorage/config"
 	"github.com/libopenstorage/openstorage/pkg/mount"
-	"github.com/libopenstorage/openstorage/pkg/seed"
+	"github.com/libopenstorage/openstorage/pkg/quota"
 	"github.com/libopenstorage/openstorage/volume"
 	"github.com/libopenstorage/openstorage/volume/drivers/common"
 	"github.com/portworx/kvdb"
-	"math/rand"
 	"strings"
 )
 
@@ -29,6 +27,8 @@ const (
 	NfsDBKey     = "OpenStorageNFSKey"
 	nfsMountPath = "/var/lib/openstorage/nfs/"
 	nfsBlockFile = ".blockdevice"
+	// serverLabel is the volume label with the NFS server of the volume.
+	serverLabel = "server"
 )
 
 // Implements the open storage volume interface.
@@ -39,9 +39,14 @@ type driver struct {
 	volume.QuiesceDriver
 	volume.CredsDriver
 	volume.CloudBackupDriver
+	*common.Trash
 	nfsServers []string
 	nfsPath    string
 	mounter    mount.Manager
+	states     *common.StateMachine
+	cloner     *common.Cloner
+	placement  PlacementPolicy
+	health     *serverHealth
 }
 
 func Init(params map[string]string) (volume.VolumeDriver, error) {
@@ -69,7 +74,6 @@ func Init(params map[string]string) (volume.VolumeDriver, error) {
 	inst := &driver{
 		IODriver:          volume.IONotSupported,
 		StoreEnumerator:   common.NewDefaultStoreEnumerator(Name, kvdb.Instance()),
-		StatsDriver:       volume.StatsNotSupported,
 		QuiesceDriver:     volume.QuiesceNotSupported,
 		nfsServers:        servers,
 		CredsDriver:       volume.CredsNotSupported,
@@ -77,6 +81,29 @@ func Init(params map[string]string) (volume.VolumeDriver, error) {
 		mounter:           mounter,
 		CloudBackupDriver: volume.CloudBackupNotSupported,
 	}
+	inst.StatsDriver = common.NewStatsDriver(inst.StoreEnumerator, inst.getNFSVolumePath)
+	inst.health = newServerHealth(servers)
+	inst.placement, err = newPlacementPolicy(params[PlacementParam], serverFree,
+		inst.StoreEnumerator)
+	if err != nil {
+		return nil, err
+	}
+	healthInterval := defaultHealthInterval
+	if interval, ok := params[HealthIntervalParam]; ok {
+		if healthInterval, err = time.ParseDuration(interval); err != nil ||
+			healthInterval < 0 {
+			return nil, fmt.Errorf("Invalid %v %q, must be a duration of 0 "+
+				"or more", HealthIntervalParam, interval)
+		}
+	}
+	inst.states = common.NewStateMachine(inst.StoreEnumerator)
+	if inst.cloner, err = common.NewCloner(inst.StoreEnumerator, params); err != nil {
+		return nil, err
+	}
+	if inst.Trash, err = common.NewTrash(inst.StoreEnumerator, inst.states,
+		params, inst.remove); err != nil {
+		return nil, err
+	}
 
 	//make directory for each nfs server
 	for _, v := range servers {
@@ -125,6 +152,14 @@ func Init(params map[string]string) (volume.VolumeDriver, error) {
 			}
 		}
 	}
+	if err := common.NewReconciler(inst.StoreEnumerator, inst.probe, inst.remove).Reconcile(); err != nil {
+		dlog.Warnf("Failed to recover volumes: %v", err)
+	}
+	inst.StoreEnumerator = inst.Trash.Visible(inst.StoreEnumerator)
+	inst.checkServers()
+	if healthInterval > 0 {
+		inst.startHealthProbe(healthInterval)
+	}
 
 	dlog.Println("NFS initialized and driver mounted at: ", nfsMountPath)
 	return inst, nil
@@ -140,25 +175,17 @@ func (d *driver) Type() api.DriverType {
 
 // Status diagnostic information
 func (d *driver) Status() [][2]string {
-	return [][2]string{}
+	return d.health.statusRows()
 }
 
 //
 //Utility functions
 //
-func (d *driver) getNewVolumeServer() (string, error) {
-	//randomly select one
-	if d.nfsServers != nil && len(d.nfsServers) > 0 {
-		return d.nfsServers[rand.Intn(len(d.nfsServers))], nil
-	}
-
-	return "", errors.New("No NFS servers found")
-}
 
 //get nfsPath for specified volume
 func (d *driver) getNFSPath(v *api.Volume) (string, error) {
 	locator := v.GetLocator()
-	server, ok := locator.VolumeLabels["server"]
+	server, ok := locator.VolumeLabels[serverLabel]
 	if !ok {
 		dlog.Warnf("No server label found on volume")
 		return "", errors.New("No server label found on volume: " + v.Id)
@@ -167,16 +194,6 @@ func (d *driver) getNFSPath(v *api.Volume) (string, error) {
 	return path.Join(nfsMountPath, server), nil
 }
 
-//get nfsPath for specified volume
-func (d *driver) getNFSPathById(volumeID string) (string, error) {
-	v, err := d.GetVol(volumeID)
-	if err != nil {
-		return "", err
-	}
-
-	return d.getNFSPath(v)
-}
-
 //get nfsPath plus volume name for specified volume
 func (d *driver) getNFSVolumePath(v *api.Volume) (string, error) {
 	parentPath, err := d.getNFSPath(v)
@@ -187,6 +204,25 @@ func (d *driver) getNFSVolumePath(v *api.Volume) (string, error) {
 	return path.Join(parentPath, v.Id), nil
 }
 
+// probe returns the state of the directory backing v on its NFS server.
+func (d *driver) probe(v *api.Volume) (*common.Backing, error) {
+	nfsVolPath, err := d.getNFSVolumePath(v)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(nfsVolPath); err != nil {
+		if os.IsNotExist(err) {
+			return &common.Backing{}, nil
+		}
+		return nil, err
+	}
+	return &common.Backing{
+		Exists:     true,
+		DevicePath: v.DevicePath,
+		AttachPath: d.mounter.Mounts(nfsVolPath),
+	}, nil
+}
+
 //get nfsPath plus volume name for specified volume
 func (d *driver) getNFSVolumePathById(volumeID string) (string, error) {
 	v, err := d.GetVol(volumeID)
@@ -211,6 +247,27 @@ func (d *driver) Create(
 	source *api.Source,
 	spec *api.VolumeSpec) (string, error) {
 
+	return d.create(locator, source, spec, true, func(v *api.Volume, volPath string) error {
+		err := common.SeedVolume(source, spec.VolumeLabels,
+			path.Join(volPath, config.DataDir), volPath)
+		if err != nil {
+			dlog.Warnf("Failed to seed volume %v: %v", v.Id, err)
+		}
+		return err
+	})
+}
+
+// create creates a volume and calls populate to fill the directory of the
+// new volume on its NFS server. If limit is set, the size of the volume is
+// enforced with a project quota; volumes are created unlimited on servers
+// without project quotas.
+func (d *driver) create(
+	locator *api.VolumeLocator,
+	source *api.Source,
+	spec *api.VolumeSpec,
+	limit bool,
+	populate func(v *api.Volume, volPath string) error,
+) (string, error) {
 	volumeID := locator.Name
 	if volumeID == "" && source.Parent != "" {
 		volumeID = d.getNewSnapVolID(source.Parent)
@@ -228,54 +285,18 @@ func (d *driver) Create(
 
 	//check if user passed server as option
 	labels := locator.GetVolumeLabels()
-	_, ok := labels["server"]
-	if !ok {
-		server, err := d.getNewVolumeServer()
+	if server, ok := labels[serverLabel]; ok {
+		if !d.health.isUp(server) {
+			return "", fmt.Errorf("NFS server %q is not available", server)
+		}
+	} else {
+		server, err := d.getNewVolumeServer(locator)
 		if err != nil {
 			dlog.Infof("no nfs servers found...")
 			return "", err
-		} else {
-			dlog.Infof("Assigning random nfs server: %s to volume: %s", server, volumeID)
 		}
-
-		labels["server"] = server
-	}
-
-	// Create a directory on the NFS server with this UUID.
-	volPathParent := path.Join(nfsMountPath, labels["server"])
-	volPath := path.Join(volPathParent, volumeID)
-	err := os.MkdirAll(volPath, 0744)
-	if err != nil {
-		dlog.Println(err)
-		return "", err
-	}
-	if source != nil {
-		if len(source.Seed) != 0 {
-			seed, err := seed.New(source.Seed, spec.VolumeLabels)
-			if err != nil {
-				dlog.Warnf("Failed to initailize seed from %q : %v",
-					source.Seed, err)
-				return "", err
-			}
-			err = seed.Load(path.Join(volPath, config.DataDir))
-			if err != nil {
-				dlog.Warnf("Failed to  seed from %q to %q: %v",
-					source.Seed, volPathParent, err)
-				return "", err
-			}
-		}
-	}
-
-	f, err := os.Create(path.Join(volPathParent, volumeID+nfsBlockFile))
-	if err != nil {
-		dlog.Println(err)
-		return "", err
-	}
-	defer f.Close()
-
-	if err := f.Truncate(int64(spec.Size)); err != nil {
-		dlog.Println(err)
-		return "", err
+		dlog.Infof("Assigning nfs server: %s to volume: %s", server, volumeID)
+		labels[serverLabel] = server
 	}
 
 	v := common.NewVolume(
@@ -285,21 +306,68 @@ func (d *driver) Create(
 		source,
 		spec,
 	)
-	v.DevicePath = path.Join(volPathParent, volumeID+nfsBlockFile)
+	if err := d.states.Create(v, func(v *api.Volume) error {
+		// Create a directory on the NFS server with this UUID.
+		volPathParent := path.Join(nfsMountPath, labels[serverLabel])
+		volPath := path.Join(volPathParent, volumeID)
+		err := os.MkdirAll(volPath, 0744)
+		if err != nil {
+			dlog.Println(err)
+			return err
+		}
+		if limit && spec.Size != 0 {
+			size, err := common.SetSizeLimit(kvdb.Instance(), v, volPath, spec.Size)
+			switch {
+			case err == quota.ErrNotSupported:
+				dlog.Warnf("Volume %s is not limited to %d bytes: %v",
+					v.Id, spec.Size, err)
+			case err != nil:
+				os.RemoveAll(volPath)
+				return fmt.Errorf("Failed to limit volume %s to %d bytes: %v",
+					v.Id, spec.Size, err)
+			default:
+				spec.Size = size
+			}
+		}
+		if err := populate(v, volPath); err != nil {
+			return err
+		}
 
-	if err := d.CreateVol(v); err != nil {
+		f, err := os.Create(path.Join(volPathParent, volumeID+nfsBlockFile))
+		if err != nil {
+			dlog.Println(err)
+			return err
+		}
+		defer f.Close()
+
+		if err := f.Truncate(int64(spec.Size)); err != nil {
+			dlog.Println(err)
+			return err
+		}
+		v.DevicePath = path.Join(volPathParent, volumeID+nfsBlockFile)
+		return nil
+	}); err != nil {
 		return "", err
 	}
-	return v.Id, err
+	return v.Id, nil
 }
 
 func (d *driver) Delete(volumeID string) error {
-	v, err := d.GetVol(volumeID)
+	var err error
+	if d.Trash.Enabled() {
+		err = d.MoveToTrash(volumeID)
+	} else {
+		err = d.states.Transition(volumeID, common.OpDelete, d.remove)
+	}
 	if err != nil {
 		dlog.Println(err)
 		return err
 	}
+	return nil
+}
 
+// remove deletes the data of v.
+func (d *driver) remove(v *api.Volume) error {
 	// Delete the simulated block volume
 	os.Remove(v.DevicePath)
 
@@ -310,13 +378,9 @@ func (d *driver) Delete(volumeID string) error {
 
 	// Delete the directory on the nfs server.
 	os.RemoveAll(nfsVolPath)
-
-	err = d.DeleteVol(volumeID)
-	if err != nil {
-		dlog.Println(err)
-		return err
+	if err := common.ReleaseProjectID(kvdb.Instance(), v); err != nil {
+		dlog.Warnf("Failed to release the project ID of volume %v: %v", v.Id, err)
 	}
-
 	return nil
 }
 
@@ -325,221 +389,181 @@ func (d *driver) MountedAt(mountpath string) string {
 }
 
 func (d *driver) Mount(volumeID string, mountpath string, options map[string]string) error {
-	v, err := d.GetVol(volumeID)
-	if err != nil {
-		dlog.Println(err)
-		return err
-	}
-
-	nfsPath, err := d.getNFSPath(v)
-	if err != nil {
-		dlog.Printf("Could not find server for volume: %s", volumeID)
-		return err
-	}
-
-	srcPath := path.Join(":", nfsPath, volumeID)
-	mountExists, err := d.mounter.Exists(srcPath, mountpath)
-	if !mountExists {
-		d.mounter.Unmount(path.Join(nfsPath, volumeID), mountpath,
-			syscall.MNT_DETACH, 0, nil)
-		if err := d.mounter.Mount(
-			0, path.Join(nfsPath, volumeID),
-			mountpath,
-			string(v.Spec.Format),
-			syscall.MS_BIND,
-			"",
-			0,
-			nil,
-		); err != nil {
-			dlog.Printf("Cannot mount %s at %s because %+v",
-				path.Join(nfsPath, volumeID), mountpath, err)
+	err := d.states.Transition(volumeID, common.OpMount, func(v *api.Volume) error {
+		nfsPath, err := d.getNFSPath(v)
+		if err != nil {
+			dlog.Printf("Could not find server for volume: %s", volumeID)
 			return err
 		}
+
+		srcPath := path.Join(":", nfsPath, volumeID)
+		mountExists, err := d.mounter.Exists(srcPath, mountpath)
+		if !mountExists {
+			d.mounter.Unmount(path.Join(nfsPath, volumeID), mountpath,
+				syscall.MNT_DETACH, 0, nil)
+			if err := d.mounter.Mount(
+				0, path.Join(nfsPath, volumeID),
+				mountpath,
+				string(v.Spec.Format),
+				syscall.MS_BIND,
+				"",
+				0,
+				nil,
+			); err != nil {
+				dlog.Printf("Cannot mount %s at %s because %+v",
+					path.Join(nfsPath, volumeID), mountpath, err)
+				return err
+			}
+		}
+		if v.AttachPath == nil {
+			v.AttachPath = make([]string, 0)
+		}
+		v.AttachPath = append(v.AttachPath, mountpath)
+		return nil
+	})
+	if err != nil {
+		dlog.Println(err)
 	}
-	if v.AttachPath == nil {
-		v.AttachPath = make([]string, 0)
-	}
-	v.AttachPath = append(v.AttachPath, mountpath)
-	return d.UpdateVol(v)
+	return err
 }
 
 func (d *driver) Unmount(volumeID string, mountpath string, options map[string]string) error {
-	v, err := d.GetVol(volumeID)
-	if err != nil {
-		return err
-	}
-	if len(v.AttachPath) == 0 {
-		return fmt.Errorf("Device %v not mounted", volumeID)
-	}
+	return d.states.Transition(volumeID, common.OpUnmount, func(v *api.Volume) error {
+		if len(v.AttachPath) == 0 {
+			return fmt.Errorf("Device %v not mounted", volumeID)
+		}
 
-	nfsVolPath, err := d.getNFSVolumePath(v)
-	if err != nil {
-		return err
-	}
+		nfsVolPath, err := d.getNFSVolumePath(v)
+		if err != nil {
+			return err
+		}
 
-	err = d.mounter.Unmount(nfsVolPath, mountpath,
-		syscall.MNT_DETACH, 0, nil)
-	if err != nil {
-		return err
-	}
-	v.AttachPath = d.mounter.Mounts(nfsVolPath)
-	return d.UpdateVol(v)
+		err = d.mounter.Unmount(nfsVolPath, mountpath,
+			syscall.MNT_DETACH, 0, nil)
+		if err != nil {
+			return err
+		}
+		v.AttachPath = d.mounter.Mounts(nfsVolPath)
+		return nil
+	})
 }
 
+// Snapshot clones the directory of volumeID into a new volume on the same
+// NFS server. The clone method and progress are kept in the runtime state of
+// the snapshot.
 func (d *driver) Snapshot(volumeID string, readonly bool, locator *api.VolumeLocator) (string, error) {
-	volIDs := []string{volumeID}
-	vols, err := d.Inspect(volIDs)
-	if err != nil {
-		return "", nil
-	}
-	source := &api.Source{Parent: volumeID}
-	newVolumeID, err := d.Create(locator, source, vols[0].Spec)
-	if err != nil {
-		return "", nil
-	}
-
-	nfsVolPath, err := d.getNFSVolumePathById(volumeID)
+	parent, err := d.GetVol(volumeID)
 	if err != nil {
 		return "", err
 	}
-
-	newNfsVolPath, err := d.getNFSVolumePathById(newVolumeID)
+	nfsVolPath, err := d.getNFSVolumePath(parent)
 	if err != nil {
 		return "", err
 	}
 
-	// NFS does not support snapshots, so just copy the files.
-	if err := copyDir(nfsVolPath, newNfsVolPath); err != nil {
-		d.Delete(newVolumeID)
-		return "", nil
+	// Reflinks and hard links do not cross filesystems, so snapshots stay
+	// on the server of their parent.
+	if locator == nil {
+		locator = &api.VolumeLocator{}
+	}
+	if locator.VolumeLabels == nil {
+		locator.VolumeLabels = make(map[string]string)
 	}
-	return newVolumeID, nil
+	locator.VolumeLabels[serverLabel] = parent.Locator.VolumeLabels[serverLabel]
+
+	source := &api.Source{Parent: volumeID}
+	limited := common.SizeLimited(parent)
+	return d.create(locator, source, parent.Spec, limited, func(v *api.Volume, volPath string) error {
+		v.Readonly = readonly
+		return d.cloner.Clone(v, nfsVolPath, volPath)
+	})
 }
 
 func (d *driver) Restore(volumeID string, snapID string) error {
-	if _, err := d.Inspect([]string{volumeID, snapID}); err != nil {
-		return err
-	}
-
-	nfsVolPath, err := d.getNFSVolumePathById(volumeID)
-	if err != nil {
-		return err
-	}
+	return d.states.Transition(volumeID, common.OpRestore, func(v *api.Volume) error {
+		nfsVolPath, err := d.getNFSVolumePath(v)
+		if err != nil {
+			return err
+		}
 
-	snapNfsVolPath, err := d.getNFSVolumePathById(snapID)
-	if err != nil {
-		return err
-	}
+		snapNfsVolPath, err := d.getNFSVolumePathById(snapID)
+		if err != nil {
+			return err
+		}
 
-	// NFS does not support restore, so just copy the files.
-	if err := copyDir(snapNfsVolPath, nfsVolPath); err != nil {
-		return err
-	}
-	return nil
+		return d.cloner.Restore(v, snapNfsVolPath, nfsVolPath)
+	})
 }
 
 func (d *driver) Attach(volumeID string, attachOptions map[string]string) (string, error) {
-
-	nfsPath, err := d.getNFSPathById(volumeID)
-	if err != nil {
-		return "", err
-	}
-
-	return path.Join(nfsPath, volumeID+nfsBlockFile), nil
+	var devicePath string
+	err := d.states.Transition(volumeID, common.OpAttach, func(v *api.Volume) error {
+		nfsPath, err := d.getNFSPath(v)
+		if err != nil {
+			return err
+		}
+		devicePath = path.Join(nfsPath, volumeID+nfsBlockFile)
+		return nil
+	})
+	return devicePath, err
 }
 
 func (d *driver) Detach(volumeID string, options map[string]string) error {
-	return nil
+	return d.states.Transition(volumeID, common.OpDetach, func(v *api.Volume) error {
+		return nil
+	})
 }
 
 func (d *driver) Set(volumeID string, locator *api.VolumeLocator, spec *api.VolumeSpec) error {
-	if spec != nil {
-		return volume.ErrNotSupported
-	}
-	v, err := d.GetVol(volumeID)
-	if err != nil {
-		return err
-	}
-	if locator != nil {
-		v.Locator = locator
-	}
-	return d.UpdateVol(v)
-}
-
-func (d *driver) Shutdown() {
-	dlog.Printf("%s Shutting down", Name)
-
-	for _, v := range d.nfsServers {
-		dlog.Infof("Umounting: %s", nfsMountPath+v)
-		syscall.Unmount(path.Join(nfsMountPath, v), 0)
-	}
+	return d.states.Update(volumeID, func(v *api.Volume) error {
+		if spec != nil {
+			resize, err := common.NeedsResize(v, spec)
+			if err != nil {
+				return err
+			}
+			if resize {
+				if err := d.resize(v, spec.Size); err != nil {
+					return err
+				}
+			}
+		}
+		if locator != nil {
+			v.Locator = locator
+		}
+		return nil
+	})
 }
 
-func copyFile(source string, dest string) (err error) {
-	sourcefile, err := os.Open(source)
+// resize enforces a project quota of size bytes on the volume directory
+// and grows the simulated block device to match. Quotas are set through
+// the local mount, so this only works when the export is bind mounted.
+func (d *driver) resize(v *api.Volume, size uint64) error {
+	nfsVolPath, err := d.getNFSVolumePath(v)
 	if err != nil {
 		return err
 	}
-
-	defer sourcefile.Close()
-
-	destfile, err := os.Create(dest)
-	if err != nil {
+	if err := common.CheckCapacity(nfsVolPath, size-v.Spec.Size); err != nil {
 		return err
 	}
-
-	defer destfile.Close()
-
-	_, err = io.Copy(destfile, sourcefile)
-	if err == nil {
-		sourceinfo, err := os.Stat(source)
-		if err != nil {
-			err = os.Chmod(dest, sourceinfo.Mode())
-		}
-
-	}
-
-	return
-}
-
-func copyDir(source string, dest string) (err error) {
-	// get properties of source dir
-	sourceinfo, err := os.Stat(source)
+	limit, err := common.SetSizeLimit(kvdb.Instance(), v, nfsVolPath, size)
 	if err != nil {
-		return err
+		return fmt.Errorf("Failed to resize volume %s: %v", v.Id, err)
 	}
-
-	// create dest dir
-
-	err = os.MkdirAll(dest, sourceinfo.Mode())
-	if err != nil {
+	if err := os.Truncate(v.DevicePath, int64(limit)); err != nil {
 		return err
 	}
+	dlog.Infof("Volume %s resized to %d bytes", v.Id, limit)
+	v.Spec.Size = limit
+	return nil
+}
 
-	directory, _ := os.Open(source)
-
-	objects, err := directory.Readdir(-1)
-
-	for _, obj := range objects {
-
-		sourcefilepointer := source + "/" + obj.Name()
-
-		destinationfilepointer := dest + "/" + obj.Name()
-
-		if obj.IsDir() {
-			// create sub-directories - recursively
-			err = copyDir(sourcefilepointer, destinationfilepointer)
-			if err != nil {
-				fmt.Println(err)
-			}
-		} else {
-			// perform copy
-			err = copyFile(sourcefilepointer, destinationfilepointer)
-			if err != nil {
-				fmt.Println(err)
-			}
-		}
+func (d *driver) Shutdown() {
+	dlog.Printf("%s Shutting down", Name)
+	d.Trash.Stop()
+	d.stopHealthProbe()
 
+	for _, v := range d.nfsServers {
+		dlog.Infof("Umounting: %s", nfsMountPath+v)
+		syscall.Unmount(path.Join(nfsMountPath, v), 0)
 	}
-	return
 }
@@ -25,5 +25,5 @@ func TestAll(t *testing.T) {
 	ctx := test.NewContext(d)
 	ctx.Filesystem = api.FSType_FS_TYPE_NFS
 
-	test.RunShort(t, ctx)
+	test.Run(t, ctx)
 }
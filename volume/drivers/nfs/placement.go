@@ -0,0 +1,187 @@
+package nfs
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+const (
+	// PlacementParam is the driver parameter that selects the placement
+	// policy of new volumes.
+	PlacementParam = "placement"
+	// PlacementRandom places volumes on a random server.
+	PlacementRandom = "random"
+	// PlacementFreeSpace places volumes on the server with the most free
+	// space.
+	PlacementFreeSpace = "free_space"
+	// PlacementRoundRobin places volumes on the servers in turn.
+	PlacementRoundRobin = "round_robin"
+	// PlacementLabelAffinity places volumes on the server that holds the
+	// most volumes sharing a label with them, or on the server with the
+	// most free space if there is none.
+	PlacementLabelAffinity = "label_affinity"
+)
+
+// PlacementPolicy chooses the server a new volume is created on.
+type PlacementPolicy interface {
+	// Place returns one of servers for a volume created with locator.
+	// servers are reachable and never empty.
+	Place(servers []string, locator *api.VolumeLocator) (string, error)
+}
+
+// getNewVolumeServer returns the server to create a volume with locator
+// on. Only servers that are up and satisfy the LocationConstraint label of
+// the volume are considered.
+func (d *driver) getNewVolumeServer(locator *api.VolumeLocator) (string, error) {
+	servers := d.health.up()
+	if c, ok := locator.GetVolumeLabels()[volume.LocationConstraint]; ok {
+		allowed := make(map[string]bool)
+		for _, server := range strings.Split(c, ",") {
+			allowed[strings.TrimSpace(server)] = true
+		}
+		constrained := servers[:0]
+		for _, server := range servers {
+			if allowed[server] {
+				constrained = append(constrained, server)
+			}
+		}
+		servers = constrained
+	}
+	if len(servers) == 0 {
+		return "", errors.New("No NFS servers available")
+	}
+	return d.placement.Place(servers, locator)
+}
+
+// newPlacementPolicy returns the placement policy called name. free returns
+// the free space of a server and enumerator the volumes of the driver.
+func newPlacementPolicy(
+	name string,
+	free func(server string) (uint64, error),
+	enumerator volume.Enumerator,
+) (PlacementPolicy, error) {
+	switch name {
+	case "", PlacementRandom:
+		return randomPolicy{}, nil
+	case PlacementFreeSpace:
+		return &freeSpacePolicy{free: free}, nil
+	case PlacementRoundRobin:
+		return &roundRobinPolicy{}, nil
+	case PlacementLabelAffinity:
+		return &labelAffinityPolicy{
+			enumerator: enumerator,
+			fallback:   &freeSpacePolicy{free: free},
+		}, nil
+	}
+	return nil, fmt.Errorf("Unknown NFS placement policy %q", name)
+}
+
+type randomPolicy struct{}
+
+func (randomPolicy) Place(servers []string, locator *api.VolumeLocator) (string, error) {
+	return servers[rand.Intn(len(servers))], nil
+}
+
+type roundRobinPolicy struct {
+	sync.Mutex
+	next int
+}
+
+func (p *roundRobinPolicy) Place(servers []string, locator *api.VolumeLocator) (string, error) {
+	p.Lock()
+	defer p.Unlock()
+	server := servers[p.next%len(servers)]
+	p.next++
+	return server, nil
+}
+
+type freeSpacePolicy struct {
+	free func(server string) (uint64, error)
+}
+
+func (p *freeSpacePolicy) Place(servers []string, locator *api.VolumeLocator) (string, error) {
+	best := ""
+	var bestFree uint64
+	var lastErr error
+	for _, server := range servers {
+		free, err := p.free(server)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if best == "" || free > bestFree {
+			best, bestFree = server, free
+		}
+	}
+	if best == "" {
+		return "", fmt.Errorf("Failed to get the free space of the NFS servers: %v",
+			lastErr)
+	}
+	return best, nil
+}
+
+type labelAffinityPolicy struct {
+	enumerator volume.Enumerator
+	fallback   PlacementPolicy
+}
+
+func (p *labelAffinityPolicy) Place(servers []string, locator *api.VolumeLocator) (string, error) {
+	labels := affinityLabels(locator.GetVolumeLabels())
+	if len(labels) == 0 {
+		return p.fallback.Place(servers, locator)
+	}
+	volumes, err := p.enumerator.Enumerate(&api.VolumeLocator{}, nil)
+	if err != nil {
+		return "", err
+	}
+	candidates := make(map[string]bool)
+	for _, server := range servers {
+		candidates[server] = true
+	}
+	matches := make(map[string]int)
+	for _, v := range volumes {
+		server, ok := v.GetLocator().GetVolumeLabels()[serverLabel]
+		if !ok || !candidates[server] {
+			continue
+		}
+		for k, val := range affinityLabels(v.GetLocator().GetVolumeLabels()) {
+			if labels[k] == val {
+				matches[server]++
+			}
+		}
+	}
+
+	var best []string
+	most := 0
+	for _, server := range servers {
+		switch n := matches[server]; {
+		case n == 0 || n < most:
+		case n > most:
+			best, most = []string{server}, n
+		default:
+			best = append(best, server)
+		}
+	}
+	if len(best) == 0 {
+		return p.fallback.Place(servers, locator)
+	}
+	return p.fallback.Place(best, locator)
+}
+
+// affinityLabels returns the labels of a volume that place it next to other
+// volumes, leaving out the labels that select a server.
+func affinityLabels(labels map[string]string) map[string]string {
+	affinity := make(map[string]string)
+	for k, v := range labels {
+		if k != serverLabel && k != volume.LocationConstraint {
+			affinity[k] = v
+		}
+	}
+	return affinity
+}
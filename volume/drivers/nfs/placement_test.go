@@ -0,0 +1,196 @@
+package nfs
+
+import (
+	"errors"
+	"path"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/portworx/kvdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
+	"github.com/libopenstorage/openstorage/volume/drivers/common"
+)
+
+type volumesEnumerator struct {
+	volume.Enumerator
+	volumes []*api.Volume
+}
+
+func (e *volumesEnumerator) Enumerate(
+	locator *api.VolumeLocator,
+	labels map[string]string,
+) ([]*api.Volume, error) {
+	return e.volumes, nil
+}
+
+func labeledVolume(labels map[string]string) *api.Volume {
+	return &api.Volume{
+		Locator: &api.VolumeLocator{VolumeLabels: labels},
+		Spec:    &api.VolumeSpec{},
+	}
+}
+
+func TestPlacementPolicies(t *testing.T) {
+	servers := []string{"a", "b", "c"}
+	free := func(server string) (uint64, error) {
+		switch server {
+		case "a":
+			return 10, nil
+		case "b":
+			return 30, nil
+		case "c":
+			return 20, nil
+		}
+		return 0, errors.New("unreachable")
+	}
+	enumerator := &volumesEnumerator{volumes: []*api.Volume{
+		labeledVolume(map[string]string{serverLabel: "a", "app": "db"}),
+		labeledVolume(map[string]string{serverLabel: "c", "app": "db"}),
+		labeledVolume(map[string]string{serverLabel: "c", "app": "db", "tier": "gold"}),
+		labeledVolume(map[string]string{serverLabel: "b", "app": "web"}),
+	}}
+	place := func(name string, servers []string, labels map[string]string) string {
+		p, err := newPlacementPolicy(name, free, enumerator)
+		require.NoError(t, err)
+		server, err := p.Place(servers, &api.VolumeLocator{VolumeLabels: labels})
+		require.NoError(t, err, name)
+		return server
+	}
+
+	_, err := newPlacementPolicy("fastest", free, enumerator)
+	assert.Error(t, err)
+
+	assert.Contains(t, servers, place(PlacementRandom, servers, nil))
+	assert.Contains(t, servers, place("", servers, nil))
+
+	p, err := newPlacementPolicy(PlacementRoundRobin, free, enumerator)
+	require.NoError(t, err)
+	for i := 0; i < 2*len(servers); i++ {
+		server, err := p.Place(servers, nil)
+		require.NoError(t, err)
+		assert.Equal(t, servers[i%len(servers)], server)
+	}
+
+	// Servers whose free space is unknown are skipped.
+	assert.Equal(t, "b", place(PlacementFreeSpace, append(servers, "d"), nil))
+	p, err = newPlacementPolicy(PlacementFreeSpace, free, enumerator)
+	require.NoError(t, err)
+	_, err = p.Place([]string{"d"}, nil)
+	assert.Error(t, err)
+
+	// Volumes go to the server with the most volumes sharing their labels,
+	// or to the server with the most free space.
+	assert.Equal(t, "c", place(PlacementLabelAffinity, servers,
+		map[string]string{"app": "db", "tier": "gold"}))
+	assert.Equal(t, "a", place(PlacementLabelAffinity, []string{"a", "b"},
+		map[string]string{"app": "db"}))
+	assert.Equal(t, "b", place(PlacementLabelAffinity, servers,
+		map[string]string{"app": "web"}))
+	assert.Equal(t, "b", place(PlacementLabelAffinity, servers,
+		map[string]string{"app": "cache"}))
+	assert.Equal(t, "b", place(PlacementLabelAffinity, servers, nil))
+}
+
+func TestServerHealth(t *testing.T) {
+	down := map[string]bool{}
+	defer func(f func(string, *syscall.Statfs_t) error) { statfs = f }(statfs)
+	statfs = func(p string, buf *syscall.Statfs_t) error {
+		if down[path.Base(p)] {
+			return errors.New("stale file handle")
+		}
+		buf.Bavail = 1024
+		buf.Bsize = 4096
+		return nil
+	}
+
+	servers := []string{"a", "b"}
+	d := &driver{
+		StoreEnumerator: common.NewDefaultStoreEnumerator("nfs_health_test",
+			kvdb.Instance()),
+		nfsServers: servers,
+		placement:  &roundRobinPolicy{},
+		health:     newServerHealth(servers),
+	}
+	for _, server := range servers {
+		v := labeledVolume(map[string]string{serverLabel: server})
+		v.Id = "vol-" + server
+		v.Status = api.VolumeStatus_VOLUME_STATUS_UP
+		require.NoError(t, d.CreateVol(v))
+		defer d.DeleteVol(v.Id)
+	}
+	status := func(volumeID string) api.VolumeStatus {
+		v, err := d.GetVol(volumeID)
+		require.NoError(t, err)
+		return v.Status
+	}
+
+	d.checkServers()
+	assert.Equal(t, [][2]string{
+		{"Server a", "Up, 4 MiB free"},
+		{"Server b", "Up, 4 MiB free"},
+	}, d.Status())
+
+	// Volumes on unreachable servers are down and no volume is placed there.
+	down["b"] = true
+	d.checkServers()
+	assert.Equal(t, api.VolumeStatus_VOLUME_STATUS_UP, status("vol-a"))
+	assert.Equal(t, api.VolumeStatus_VOLUME_STATUS_DOWN, status("vol-b"))
+	assert.Equal(t, [2]string{"Server b", "Down: stale file handle"}, d.Status()[1])
+	for i := 0; i < 2; i++ {
+		server, err := d.getNewVolumeServer(&api.VolumeLocator{})
+		require.NoError(t, err)
+		assert.Equal(t, "a", server)
+	}
+	_, err := d.getNewVolumeServer(&api.VolumeLocator{
+		VolumeLabels: map[string]string{volume.LocationConstraint: "b"},
+	})
+	assert.Error(t, err)
+
+	down["b"] = false
+	d.checkServers()
+	assert.Equal(t, api.VolumeStatus_VOLUME_STATUS_UP, status("vol-b"))
+	server, err := d.getNewVolumeServer(&api.VolumeLocator{
+		VolumeLabels: map[string]string{volume.LocationConstraint: "b"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "b", server)
+}
+
+func TestServerProbe(t *testing.T) {
+	_, err := Init(map[string]string{"path": "/tmp", HealthIntervalParam: "-1s"})
+	assert.Error(t, err, "Negative health interval accepted")
+
+	// A server is not probed again while its previous probe hangs.
+	release := make(chan struct{})
+	var probes int32
+	defer func(f func(string, *syscall.Statfs_t) error) { statfs = f }(statfs)
+	statfs = func(p string, buf *syscall.Statfs_t) error {
+		atomic.AddInt32(&probes, 1)
+		<-release
+		return nil
+	}
+	defer func(d time.Duration) { probeTimeout = d }(probeTimeout)
+	probeTimeout = 10 * time.Millisecond
+
+	_, err = serverFree("hung")
+	assert.Error(t, err)
+	_, err = serverFree("hung")
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&probes))
+
+	close(release)
+	for i := 0; i < 50; i++ {
+		if _, err = serverFree("hung"); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&probes))
+}
@@ -2,6 +2,7 @@ package test
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"path"
@@ -91,6 +92,7 @@ func RunSnap(t *testing.T, ctx *Context) {
 	snapInspect(t, ctx)
 	snapEnumerate(t, ctx)
 	snapDiff(t, ctx)
+	snapRestore(t, ctx)
 	snapDelete(t, ctx)
 	detach(t, ctx)
 	delete(t, ctx)
@@ -358,6 +360,37 @@ func snapDiff(t *testing.T, ctx *Context) {
 	fmt.Println("snapDiff")
 }
 
+func snapRestore(t *testing.T, ctx *Context) {
+	fmt.Println("snapRestore")
+
+	mount(t, ctx)
+	written := path.Join(ctx.mountPath, "restore")
+	err := ioutil.WriteFile(written, []byte("after snap"), 0644)
+	require.NoError(t, err, "Failed to write to the volume")
+	unmount(t, ctx)
+	detach(t, ctx)
+
+	err = ctx.Restore(ctx.volID, ctx.snapID)
+	if err == volume.ErrNotSupported {
+		return
+	}
+	require.NoError(t, err, "Failed in restore")
+	vols, err := ctx.Inspect([]string{ctx.volID})
+	require.NoError(t, err, "Failed in Inspect")
+	require.Equal(t, 1, len(vols), "Expect 1 volume actual %v volumes", len(vols))
+	require.NotEqual(t, api.VolumeState_VOLUME_STATE_RESTORE, vols[0].State,
+		"Volume still restoring")
+
+	attach(t, ctx)
+	mount(t, ctx)
+	_, err = os.Stat(written)
+	require.True(t, os.IsNotExist(err), "Data written after the snapshot was not reverted")
+	unmount(t, ctx)
+}
+
 func snapDelete(t *testing.T, ctx *Context) {
 	fmt.Println("snapDelete")
+	err := ctx.Delete(ctx.snapID)
+	require.NoError(t, err, "Failed in deleting the snapshot")
+	ctx.snapID = ""
 }
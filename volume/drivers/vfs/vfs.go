@@ -12,6 +12,8 @@ import (
 	"go.pedge.io/dlog"
 
 	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/config"
+	"github.com/libopenstorage/openstorage/pkg/quota"
 	"github.com/libopenstorage/openstorage/volume"
 	"github.com/libopenstorage/openstorage/volume/drivers/common"
 	"github.com/pborman/uuid"
@@ -30,24 +32,42 @@ const (
 type driver struct {
 	volume.IODriver
 	volume.BlockDriver
-	volume.SnapshotDriver
 	volume.StoreEnumerator
 	volume.StatsDriver
 	volume.CredsDriver
 	volume.CloudBackupDriver
+	*common.Trash
+	states *common.StateMachine
+	cloner *common.Cloner
 }
 
 // Init Driver intialization.
 func Init(params map[string]string) (volume.VolumeDriver, error) {
-	return &driver{
+	store := common.NewDefaultStoreEnumerator(Name, kvdb.Instance())
+	states := common.NewStateMachine(store)
+	trash, err := common.NewTrash(store, states, params, purge)
+	if err != nil {
+		return nil, err
+	}
+	cloner, err := common.NewCloner(store, params)
+	if err != nil {
+		return nil, err
+	}
+	d := &driver{
 		volume.IONotSupported,
 		volume.BlockNotSupported,
-		volume.SnapshotNotSupported,
-		common.NewDefaultStoreEnumerator(Name, kvdb.Instance()),
-		volume.StatsNotSupported,
+		trash.Visible(store),
+		common.NewStatsDriver(store, dataPath),
 		volume.CredsNotSupported,
 		volume.CloudBackupNotSupported,
-	}, nil
+		trash,
+		states,
+		cloner,
+	}
+	if err := common.NewReconciler(store, probe, purge).Reconcile(); err != nil {
+		dlog.Warnf("Failed to recover volumes: %v", err)
+	}
+	return d, nil
 }
 
 func (d *driver) Name() string {
@@ -59,11 +79,23 @@ func (d *driver) Type() api.DriverType {
 }
 
 func (d *driver) Create(locator *api.VolumeLocator, source *api.Source, spec *api.VolumeSpec) (string, error) {
+	return d.create(locator, source, spec, true, func(v *api.Volume) error {
+		return common.SeedVolume(source, spec.VolumeLabels,
+			filepath.Join(v.DevicePath, config.DataDir), v.DevicePath)
+	})
+}
+
+// create creates a volume and calls populate to fill its directory. If limit
+// is set, the size of the volume is enforced with a project quota if the
+// filesystem supports them; otherwise the volume is created unlimited.
+func (d *driver) create(
+	locator *api.VolumeLocator,
+	source *api.Source,
+	spec *api.VolumeSpec,
+	limit bool,
+	populate func(v *api.Volume) error,
+) (string, error) {
 	volumeID := strings.TrimSuffix(uuid.New(), "\n")
-	// Create a directory on the Local machine with this UUID.
-	if err := os.MkdirAll(filepath.Join(volume.VolumeBase, string(volumeID)), 0744); err != nil {
-		return "", err
-	}
 	v := common.NewVolume(
 		volumeID,
 		api.FSType_FS_TYPE_VFS,
@@ -71,23 +103,68 @@ func (d *driver) Create(locator *api.VolumeLocator, source *api.Source, spec *ap
 		source,
 		spec,
 	)
-	v.DevicePath = filepath.Join(volume.VolumeBase, volumeID)
-	if err := d.CreateVol(v); err != nil {
+	if err := d.states.Create(v, func(v *api.Volume) error {
+		// Create a directory on the Local machine with this UUID.
+		v.DevicePath = filepath.Join(volume.VolumeBase, volumeID)
+		if err := os.MkdirAll(v.DevicePath, 0744); err != nil {
+			return err
+		}
+		if limit && spec.Size != 0 {
+			size, err := common.SetSizeLimit(kvdb.Instance(), v, v.DevicePath, spec.Size)
+			switch {
+			case err == quota.ErrNotSupported:
+				dlog.Warnf("Volume %s is not limited to %d bytes: %v",
+					v.Id, spec.Size, err)
+			case err != nil:
+				os.RemoveAll(v.DevicePath)
+				return fmt.Errorf("Failed to limit volume %s to %d bytes: %v",
+					v.Id, spec.Size, err)
+			default:
+				v.Spec.Size = size
+			}
+		}
+		return populate(v)
+	}); err != nil {
 		return "", err
 	}
-	return v.Id, d.UpdateVol(v)
+	return v.Id, nil
 }
 
 func (d *driver) Delete(volumeID string) error {
-	if _, err := d.GetVol(volumeID); err != nil {
-		return err
+	if d.Trash.Enabled() {
+		return d.MoveToTrash(volumeID)
 	}
-	os.RemoveAll(filepath.Join(volume.VolumeBase, string(volumeID)))
-	if err := d.DeleteVol(volumeID); err != nil {
-		return err
+	return d.states.Transition(volumeID, common.OpDelete, purge)
+}
+
+// Snapshot clones the directory of volumeID into a new volume. The clone
+// method and progress are kept in the runtime state of the snapshot.
+func (d *driver) Snapshot(volumeID string, readonly bool, locator *api.VolumeLocator) (string, error) {
+	parent, err := d.GetVol(volumeID)
+	if err != nil {
+		return "", err
 	}
-	return nil
+	if locator == nil {
+		locator = &api.VolumeLocator{}
+	}
+	source := &api.Source{Parent: volumeID}
+	limited := common.SizeLimited(parent)
+	return d.create(locator, source, parent.Spec, limited, func(v *api.Volume) error {
+		v.Readonly = readonly
+		return d.cloner.Clone(v, filepath.Join(volume.VolumeBase, parent.Id), v.DevicePath)
+	})
+}
 
+// Restore replaces the contents of volumeID with those of snapID.
+func (d *driver) Restore(volumeID string, snapID string) error {
+	snap, err := d.GetVol(snapID)
+	if err != nil {
+		return err
+	}
+	return d.states.Transition(volumeID, common.OpRestore, func(v *api.Volume) error {
+		return d.cloner.Restore(v, filepath.Join(volume.VolumeBase, snap.Id),
+			filepath.Join(volume.VolumeBase, v.Id))
+	})
 }
 
 func (d *driver) MountedAt(mountpath string) string {
@@ -97,71 +174,120 @@ func (d *driver) MountedAt(mountpath string) string {
 // Mount volume at specified path
 // Errors ErrEnoEnt, ErrVolDetached may be returned.
 func (d *driver) Mount(volumeID string, mountpath string, options map[string]string) error {
-	v, err := d.GetVol(volumeID)
-	if err != nil {
-		dlog.Println(err)
-		return err
-	}
-	if len(v.AttachPath) > 0 && len(v.AttachPath) > 0 {
-		return fmt.Errorf("Volume %q already mounted at %q", volumeID, v.AttachPath[0])
-	}
-	syscall.Unmount(mountpath, 0)
-	if err := syscall.Mount(
-		filepath.Join(volume.VolumeBase, string(volumeID)),
-		mountpath,
-		string(v.Spec.Format),
-		syscall.MS_BIND, "",
-	); err != nil {
-		dlog.Printf("Cannot mount %s at %s because %+v",
+	return d.states.Transition(volumeID, common.OpMount, func(v *api.Volume) error {
+		if len(v.AttachPath) > 0 && len(v.AttachPath[0]) > 0 {
+			return fmt.Errorf("Volume %q already mounted at %q", volumeID, v.AttachPath[0])
+		}
+		syscall.Unmount(mountpath, 0)
+		if err := syscall.Mount(
 			filepath.Join(volume.VolumeBase, string(volumeID)),
 			mountpath,
-			err,
-		)
-		return err
-	}
-	if v.AttachPath == nil {
-		v.AttachPath = make([]string, 1)
-	}
-	v.AttachPath[0] = mountpath
-	return d.UpdateVol(v)
+			"",
+			syscall.MS_BIND, "",
+		); err != nil {
+			dlog.Printf("Cannot mount %s at %s because %+v",
+				filepath.Join(volume.VolumeBase, string(volumeID)),
+				mountpath,
+				err,
+			)
+			return err
+		}
+		if v.AttachPath == nil {
+			v.AttachPath = make([]string, 1)
+		}
+		v.AttachPath[0] = mountpath
+		return nil
+	})
 }
 
 // Unmount volume at specified path
 // Errors ErrEnoEnt, ErrVolDetached may be returned.
 func (d *driver) Unmount(volumeID string, mountpath string, options map[string]string) error {
-	v, err := d.GetVol(volumeID)
-	if err != nil {
-		return err
-	}
-	if len(v.AttachPath) == 0 || len(v.AttachPath[0]) == 0 {
-		return fmt.Errorf("Device %v not mounted", volumeID)
-	}
-	if err := syscall.Unmount(v.AttachPath[0], 0); err != nil {
-		return err
-	}
-	v.AttachPath = nil
-	return d.UpdateVol(v)
+	return d.states.Transition(volumeID, common.OpUnmount, func(v *api.Volume) error {
+		if len(v.AttachPath) == 0 || len(v.AttachPath[0]) == 0 {
+			return fmt.Errorf("Device %v not mounted", volumeID)
+		}
+		if err := syscall.Unmount(v.AttachPath[0], 0); err != nil {
+			return err
+		}
+		v.AttachPath = nil
+		return nil
+	})
 }
 
 func (d *driver) Set(volumeID string, locator *api.VolumeLocator, spec *api.VolumeSpec) error {
-	if spec != nil {
-		return volume.ErrNotSupported
-	}
-	v, err := d.GetVol(volumeID)
-	if err != nil {
+	return d.states.Update(volumeID, func(v *api.Volume) error {
+		if spec != nil {
+			resize, err := common.NeedsResize(v, spec)
+			if err != nil {
+				return err
+			}
+			if resize {
+				if err := d.resize(v, spec.Size); err != nil {
+					return err
+				}
+			}
+		}
+		if locator != nil {
+			v.Locator = locator
+		}
+		return nil
+	})
+}
+
+// resize enforces a project quota of size bytes on the volume directory.
+func (d *driver) resize(v *api.Volume, size uint64) error {
+	volPath := filepath.Join(volume.VolumeBase, v.Id)
+	if err := common.CheckCapacity(volPath, size-v.Spec.Size); err != nil {
 		return err
 	}
-	if locator != nil {
-		v.Locator = locator
+	limit, err := common.SetSizeLimit(kvdb.Instance(), v, volPath, size)
+	if err != nil {
+		return fmt.Errorf("Failed to resize volume %s: %v", v.Id, err)
 	}
-	return d.UpdateVol(v)
+	dlog.Infof("Volume %s resized to %d bytes", v.Id, limit)
+	v.Spec.Size = limit
+	return nil
 }
 
 func (d *driver) Status() [][2]string {
 	return [][2]string{}
 }
 
-func (d *driver) Shutdown() {}
+func (d *driver) Shutdown() {
+	d.Trash.Stop()
+}
+
+// dataPath returns the directory of v.
+func dataPath(v *api.Volume) (string, error) {
+	return filepath.Join(volume.VolumeBase, v.Id), nil
+}
+
+// purge deletes the directory of v and releases its project ID.
+func purge(v *api.Volume) error {
+	if err := os.RemoveAll(filepath.Join(volume.VolumeBase, v.Id)); err != nil {
+		return err
+	}
+	if err := common.ReleaseProjectID(kvdb.Instance(), v); err != nil {
+		dlog.Warnf("Failed to release the project ID of volume %v: %v", v.Id, err)
+	}
+	return nil
+}
+
+// probe returns the state of the directory backing v.
+func probe(v *api.Volume) (*common.Backing, error) {
+	if _, err := os.Stat(filepath.Join(volume.VolumeBase, v.Id)); err != nil {
+		if os.IsNotExist(err) {
+			return &common.Backing{}, nil
+		}
+		return nil, err
+	}
+	return &common.Backing{
+		Exists:     true,
+		DevicePath: v.DevicePath,
+		AttachPath: common.MountedPaths(v.AttachPath),
+	}, nil
+}
 
 func (d *driver) fsFreeze(volumeID string, freeze bool) error {
 	v, err := d.GetVol(volumeID)
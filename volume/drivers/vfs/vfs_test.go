@@ -0,0 +1,17 @@
+package vfs
+
+import (
+	"testing"
+
+	"github.com/libopenstorage/openstorage/volume/drivers/test"
+)
+
+func TestAll(t *testing.T) {
+	d, err := Init(map[string]string{})
+	if err != nil {
+		t.Fatalf("Failed to initialize Volume Driver: %v", err)
+	}
+	ctx := test.NewContext(d)
+
+	test.Run(t, ctx)
+}
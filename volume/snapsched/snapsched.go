@@ -0,0 +1,480 @@
+// Package snapsched takes scheduled snapshots of volumes.
+//
+// The schedule of a volume comes from VolumeSpec.SnapshotSchedule, or from
+// the legacy VolumeSpec.SnapshotInterval. Each schedule is stored in kvdb
+// together with the ID of the node that owns it and the time each of its
+// intervals last ran. Only the owner runs a schedule. When the owner leaves
+// the cluster another node claims the schedule and catches up on snapshots
+// that were missed while nobody owned it.
+package snapsched
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/proto/time"
+	"github.com/libopenstorage/openstorage/pkg/sched"
+	"github.com/libopenstorage/openstorage/volume"
+	"github.com/portworx/kvdb"
+)
+
+const (
+	// LabelInterval is the snapshot label that records the interval type
+	// of the schedule that created the snapshot.
+	LabelInterval = "snapshot-interval"
+
+	keyBase           = "snapsched/"
+	snapNameFormat    = "20060102-150405"
+	casRetries        = 3
+	reconcileInterval = 30 * time.Second
+)
+
+var (
+	// ErrNotOwner is returned when this node does not own a schedule.
+	ErrNotOwner = errors.New("Snapshot schedule is owned by another node")
+)
+
+// Policy is the snapshot schedule of a volume as stored in kvdb.
+type Policy struct {
+	// VolumeID is the volume to snapshot.
+	VolumeID string
+	// Schedule in the format accepted by sched.ParseScheduleAndPolicies.
+	Schedule string
+	// Owner is the ID of the node that runs the schedule.
+	Owner string
+	// LastRun is the time of the last snapshot of each interval.
+	LastRun map[string]time.Time
+}
+
+// Nodes lists the nodes of the cluster. It is implemented by cluster.Cluster.
+type Nodes interface {
+	// Enumerate lists all the nodes in the cluster.
+	Enumerate() (api.Cluster, error)
+}
+
+// Manager runs the snapshot schedules of the volumes of a driver.
+type Manager interface {
+	// Start reconciles schedules periodically until Stop is called.
+	Start() error
+	// Stop cancels all schedules run by this node.
+	Stop()
+	// Reconcile syncs the stored schedules with the volumes of the driver,
+	// claims schedules whose owner is gone and runs the ones owned by this
+	// node.
+	Reconcile() error
+	// Enumerate returns all stored schedules of the driver.
+	Enumerate() ([]*Policy, error)
+}
+
+type manager struct {
+	sync.Mutex
+	driver    volume.VolumeDriver
+	kv        kvdb.Kvdb
+	scheduler sched.Scheduler
+	nodes     Nodes
+	nodeID    string
+	// running maps volume IDs to the schedules run by this node.
+	running map[string]*running
+	stop    chan struct{}
+}
+
+type running struct {
+	schedule string
+	tasks    []sched.TaskID
+}
+
+// New returns a Manager for driver. nodes may be nil when running outside of
+// a cluster, in which case this node owns all schedules.
+func New(
+	driver volume.VolumeDriver,
+	kv kvdb.Kvdb,
+	scheduler sched.Scheduler,
+	nodes Nodes,
+	nodeID string,
+) Manager {
+	return &manager{
+		driver:    driver,
+		kv:        kv,
+		scheduler: scheduler,
+		nodes:     nodes,
+		nodeID:    nodeID,
+		running:   make(map[string]*running),
+	}
+}
+
+func (m *manager) Start() error {
+	m.Lock()
+	if m.stop != nil {
+		m.Unlock()
+		return fmt.Errorf("Snapshot scheduler for %s already started", m.driver.Name())
+	}
+	m.stop = make(chan struct{})
+	stop := m.stop
+	m.Unlock()
+
+	if err := m.Reconcile(); err != nil {
+		dlog.Warnf("Failed to reconcile snapshot schedules of %s: %v",
+			m.driver.Name(), err)
+	}
+	go func() {
+		ticker := time.NewTicker(reconcileInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := m.Reconcile(); err != nil {
+					dlog.Warnf("Failed to reconcile snapshot schedules of %s: %v",
+						m.driver.Name(), err)
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (m *manager) Stop() {
+	m.Lock()
+	defer m.Unlock()
+	if m.stop != nil {
+		close(m.stop)
+		m.stop = nil
+	}
+	for volumeID := range m.running {
+		m.cancelLocked(volumeID)
+	}
+}
+
+func (m *manager) Reconcile() error {
+	vols, err := m.driver.Enumerate(&api.VolumeLocator{}, nil)
+	if err != nil {
+		return err
+	}
+	schedules := make(map[string]string)
+	for _, v := range vols {
+		if s := volumeSchedule(v); s != "" {
+			schedules[v.Id] = s
+		}
+	}
+	kvps, err := m.kv.Enumerate(m.keyPrefix())
+	if err != nil {
+		return err
+	}
+	alive, err := m.liveNodes()
+	if err != nil {
+		return err
+	}
+
+	stored := make(map[string]*kvdb.KVPair)
+	for _, kvp := range kvps {
+		p := &Policy{}
+		if err := json.Unmarshal(kvp.Value, p); err != nil {
+			return err
+		}
+		if _, ok := schedules[p.VolumeID]; !ok {
+			// The volume is gone or no longer scheduled.
+			m.cancel(p.VolumeID)
+			if _, err := m.kv.Delete(m.key(p.VolumeID)); err != nil &&
+				err != kvdb.ErrNotFound {
+				dlog.Warnf("Failed to remove snapshot schedule of %s: %v",
+					p.VolumeID, err)
+			}
+			continue
+		}
+		stored[p.VolumeID] = kvp
+	}
+
+	for volumeID, schedule := range schedules {
+		var p *Policy
+		if kvp, ok := stored[volumeID]; ok {
+			p = &Policy{}
+			if err := json.Unmarshal(kvp.Value, p); err != nil {
+				return err
+			}
+			changed := false
+			if p.Schedule != schedule {
+				p.Schedule = schedule
+				changed = true
+			}
+			if p.Owner != m.nodeID && !alive[p.Owner] {
+				dlog.Infof("Node %s claiming snapshot schedule of %s from %s",
+					m.nodeID, volumeID, p.Owner)
+				p.Owner = m.nodeID
+				changed = true
+			}
+			if changed {
+				if err := m.compareAndSet(kvp, p); err != nil {
+					// Someone else got there first, retry on the next pass.
+					continue
+				}
+			}
+		} else {
+			p = &Policy{
+				VolumeID: volumeID,
+				Schedule: schedule,
+				Owner:    m.nodeID,
+				LastRun:  make(map[string]time.Time),
+			}
+			if _, err := m.kv.Create(m.key(volumeID), p, 0); err != nil {
+				if err != kvdb.ErrExist {
+					dlog.Warnf("Failed to store snapshot schedule of %s: %v",
+						volumeID, err)
+				}
+				continue
+			}
+		}
+
+		if p.Owner != m.nodeID {
+			m.cancel(volumeID)
+			continue
+		}
+		if err := m.run(p); err != nil {
+			dlog.Warnf("Failed to run snapshot schedule %q of %s: %v",
+				p.Schedule, volumeID, err)
+		}
+	}
+	return nil
+}
+
+func (m *manager) Enumerate() ([]*Policy, error) {
+	kvps, err := m.kv.Enumerate(m.keyPrefix())
+	if err != nil {
+		return nil, err
+	}
+	policies := make([]*Policy, 0, len(kvps))
+	for _, kvp := range kvps {
+		p := &Policy{}
+		if err := json.Unmarshal(kvp.Value, p); err != nil {
+			return nil, err
+		}
+		policies = append(policies, p)
+	}
+	return policies, nil
+}
+
+// run registers the schedule of p with the scheduler, replacing any
+// previous schedule of the volume. Intervals that were due while nobody
+// ran the schedule are caught up once.
+func (m *manager) run(p *Policy) error {
+	m.Lock()
+	defer m.Unlock()
+
+	if r, ok := m.running[p.VolumeID]; ok && r.schedule == p.Schedule {
+		return nil
+	}
+	m.cancelLocked(p.VolumeID)
+
+	intervals, _, err := sched.ParseScheduleAndPolicies(p.Schedule)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	r := &running{schedule: p.Schedule}
+	for _, iv := range sched.SetupIntvWithDefaults(intervals) {
+		iv := iv
+		taskID, err := m.scheduler.Schedule(
+			func(sched.Interval) {
+				m.snapshot(p.VolumeID, iv)
+			},
+			iv,
+			now,
+			false,
+		)
+		if err != nil {
+			m.running[p.VolumeID] = r
+			m.cancelLocked(p.VolumeID)
+			return err
+		}
+		r.tasks = append(r.tasks, taskID)
+
+		if last, ok := p.LastRun[iv.String()]; ok &&
+			!sched.NextAfter(iv, last).After(now) {
+			dlog.Infof("Catching up on missed %v snapshot of %s", iv, p.VolumeID)
+			go m.snapshot(p.VolumeID, iv)
+		}
+	}
+	m.running[p.VolumeID] = r
+	return nil
+}
+
+// cancel stops running the schedule of volumeID on this node.
+func (m *manager) cancel(volumeID string) {
+	m.Lock()
+	defer m.Unlock()
+	m.cancelLocked(volumeID)
+}
+
+func (m *manager) cancelLocked(volumeID string) {
+	r, ok := m.running[volumeID]
+	if !ok {
+		return
+	}
+	for _, taskID := range r.tasks {
+		if err := m.scheduler.Cancel(taskID); err != nil {
+			dlog.Warnf("Failed to cancel snapshot task of %s: %v", volumeID, err)
+		}
+	}
+	delete(m.running, volumeID)
+}
+
+// snapshot takes a snapshot of volumeID for iv, records when it ran and
+// deletes the snapshots of iv that are beyond its retain count.
+func (m *manager) snapshot(volumeID string, iv sched.RetainInterval) {
+	now := time.Now()
+	// Ownership may have moved since the task was scheduled.
+	if _, _, err := m.owned(volumeID); err != nil {
+		dlog.Infof("Skipping %v snapshot of %s: %v", iv, volumeID, err)
+		return
+	}
+
+	locator := &api.VolumeLocator{
+		Name: fmt.Sprintf("%s_%s_%s", volumeID, iv.IntervalType(),
+			now.UTC().Format(snapNameFormat)),
+		VolumeLabels: map[string]string{
+			LabelInterval: iv.IntervalType(),
+		},
+	}
+	snapID, err := m.driver.Snapshot(volumeID, true, locator)
+	if err != nil {
+		dlog.Errorf("Failed to take %v snapshot of %s: %v", iv, volumeID, err)
+		return
+	}
+	dlog.Infof("Took %v snapshot %s of %s", iv, snapID, volumeID)
+
+	if err := m.recordRun(volumeID, iv, now); err != nil {
+		dlog.Warnf("Failed to record %v snapshot of %s: %v", iv, volumeID, err)
+	}
+	if err := m.enforceRetention(volumeID, iv); err != nil {
+		dlog.Warnf("Failed to delete old %v snapshots of %s: %v", iv, volumeID, err)
+	}
+}
+
+// recordRun stores the time at which iv last ran for volumeID.
+func (m *manager) recordRun(volumeID string, iv sched.RetainInterval, at time.Time) error {
+	var err error
+	for i := 0; i < casRetries; i++ {
+		var (
+			kvp *kvdb.KVPair
+			p   *Policy
+		)
+		if kvp, p, err = m.owned(volumeID); err != nil {
+			return err
+		}
+		if p.LastRun == nil {
+			p.LastRun = make(map[string]time.Time)
+		}
+		p.LastRun[iv.String()] = at
+		if err = m.compareAndSet(kvp, p); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// enforceRetention deletes the oldest snapshots taken for iv beyond its
+// retain count.
+func (m *manager) enforceRetention(volumeID string, iv sched.RetainInterval) error {
+	snaps, err := m.driver.SnapEnumerate(
+		[]string{volumeID},
+		map[string]string{LabelInterval: iv.IntervalType()},
+	)
+	if err != nil {
+		return err
+	}
+	scheduled := make([]*api.Volume, 0, len(snaps))
+	for _, snap := range snaps {
+		if snap.Locator != nil &&
+			snap.Locator.VolumeLabels[LabelInterval] == iv.IntervalType() {
+			scheduled = append(scheduled, snap)
+		}
+	}
+	retain := int(iv.RetainNumber())
+	if len(scheduled) <= retain {
+		return nil
+	}
+	sort.Slice(scheduled, func(i, j int) bool {
+		return prototime.TimestampLess(scheduled[i].Ctime, scheduled[j].Ctime)
+	})
+	for _, snap := range scheduled[:len(scheduled)-retain] {
+		if err := m.driver.Delete(snap.Id); err != nil {
+			return err
+		}
+		dlog.Infof("Deleted %v snapshot %s of %s", iv, snap.Id, volumeID)
+	}
+	return nil
+}
+
+// owned returns the stored schedule of volumeID if this node owns it.
+func (m *manager) owned(volumeID string) (*kvdb.KVPair, *Policy, error) {
+	p := &Policy{}
+	kvp, err := m.kv.GetVal(m.key(volumeID), p)
+	if err != nil {
+		return nil, nil, err
+	}
+	if p.Owner != m.nodeID {
+		return nil, nil, ErrNotOwner
+	}
+	return kvp, p, nil
+}
+
+func (m *manager) compareAndSet(kvp *kvdb.KVPair, p *Policy) error {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	update := *kvp
+	update.Value = b
+	_, err = m.kv.CompareAndSet(&update, kvdb.KVModifiedIndex, nil)
+	return err
+}
+
+// liveNodes returns the set of node IDs that can own schedules.
+func (m *manager) liveNodes() (map[string]bool, error) {
+	alive := map[string]bool{m.nodeID: true}
+	if m.nodes == nil {
+		return alive, nil
+	}
+	cluster, err := m.nodes.Enumerate()
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range cluster.Nodes {
+		if n.Status == api.Status_STATUS_OK {
+			alive[n.Id] = true
+		}
+	}
+	return alive, nil
+}
+
+func (m *manager) keyPrefix() string {
+	return keyBase + m.driver.Name() + "/"
+}
+
+func (m *manager) key(volumeID string) string {
+	return m.keyPrefix() + volumeID
+}
+
+// volumeSchedule returns the snapshot schedule of v. Snapshots themselves
+// are never scheduled, writable clones are.
+func volumeSchedule(v *api.Volume) string {
+	if v.Spec == nil || v.IsSnapshot() {
+		return ""
+	}
+	if v.Spec.SnapshotSchedule != "" {
+		return v.Spec.SnapshotSchedule
+	}
+	if v.Spec.SnapshotInterval > 0 {
+		return sched.PeriodicType + "=" +
+			strconv.FormatUint(uint64(v.Spec.SnapshotInterval), 10)
+	}
+	return ""
+}
@@ -0,0 +1,209 @@
+package snapsched
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+	mockcluster "github.com/libopenstorage/openstorage/cluster/mock"
+	"github.com/libopenstorage/openstorage/pkg/proto/time"
+	"github.com/libopenstorage/openstorage/pkg/sched"
+	mockdriver "github.com/libopenstorage/openstorage/volume/drivers/mock"
+	"github.com/portworx/kvdb"
+	"github.com/portworx/kvdb/mem"
+)
+
+const (
+	testSchedule = "periodic=60,2"
+)
+
+func newTestKvdb(t *testing.T) kvdb.Kvdb {
+	kv, err := kvdb.New(mem.Name, "snapsched_test", []string{}, nil, nil)
+	require.NoError(t, err)
+	return kv
+}
+
+func newTestVolume(id string, schedule string) *api.Volume {
+	return &api.Volume{
+		Id:      id,
+		Locator: &api.VolumeLocator{Name: id},
+		Spec:    &api.VolumeSpec{SnapshotSchedule: schedule},
+	}
+}
+
+func testNodes(status map[string]api.Status) api.Cluster {
+	c := api.Cluster{}
+	for id, s := range status {
+		c.Nodes = append(c.Nodes, api.Node{Id: id, Status: s})
+	}
+	return c
+}
+
+func TestReconcileOwnership(t *testing.T) {
+	mc := gomock.NewController(t)
+	defer mc.Finish()
+	d := mockdriver.NewMockVolumeDriver(mc)
+	c := mockcluster.NewMockCluster(mc)
+	kv := newTestKvdb(t)
+	s := sched.New(time.Second)
+	defer s.Stop()
+
+	d.EXPECT().Name().Return("mock").AnyTimes()
+	d.EXPECT().
+		Enumerate(gomock.Any(), gomock.Any()).
+		Return([]*api.Volume{newTestVolume("vol1", testSchedule)}, nil).
+		AnyTimes()
+
+	a := New(d, kv, s, c, "nodeA").(*manager)
+	b := New(d, kv, s, c, "nodeB").(*manager)
+
+	// Both nodes are up, the first one to reconcile owns the schedule.
+	c.EXPECT().Enumerate().Return(testNodes(map[string]api.Status{
+		"nodeA": api.Status_STATUS_OK,
+		"nodeB": api.Status_STATUS_OK,
+	}), nil).Times(2)
+	require.NoError(t, a.Reconcile())
+	require.NoError(t, b.Reconcile())
+
+	policies, err := a.Enumerate()
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	require.Equal(t, "nodeA", policies[0].Owner)
+	require.Equal(t, testSchedule, policies[0].Schedule)
+	require.Len(t, a.running, 1)
+	require.Len(t, b.running, 0)
+
+	// nodeA goes down and nodeB takes over.
+	c.EXPECT().Enumerate().Return(testNodes(map[string]api.Status{
+		"nodeA": api.Status_STATUS_OFFLINE,
+		"nodeB": api.Status_STATUS_OK,
+	}), nil)
+	require.NoError(t, b.Reconcile())
+	policies, err = b.Enumerate()
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	require.Equal(t, "nodeB", policies[0].Owner)
+	require.Len(t, b.running, 1)
+
+	// nodeA comes back and stops running the schedule.
+	c.EXPECT().Enumerate().Return(testNodes(map[string]api.Status{
+		"nodeA": api.Status_STATUS_OK,
+		"nodeB": api.Status_STATUS_OK,
+	}), nil)
+	require.NoError(t, a.Reconcile())
+	require.Len(t, a.running, 0)
+
+	// A snapshot task left on nodeA does not run.
+	intervals, err := sched.ParseSchedule(testSchedule)
+	require.NoError(t, err)
+	a.snapshot("vol1", intervals[0])
+}
+
+func TestReconcileRemovesSchedule(t *testing.T) {
+	mc := gomock.NewController(t)
+	defer mc.Finish()
+	d := mockdriver.NewMockVolumeDriver(mc)
+	kv := newTestKvdb(t)
+	s := sched.New(time.Second)
+	defer s.Stop()
+
+	m := New(d, kv, s, nil, "node").(*manager)
+	d.EXPECT().Name().Return("mock").AnyTimes()
+	gomock.InOrder(
+		d.EXPECT().
+			Enumerate(gomock.Any(), gomock.Any()).
+			Return([]*api.Volume{newTestVolume("vol1", testSchedule)}, nil),
+		d.EXPECT().
+			Enumerate(gomock.Any(), gomock.Any()).
+			Return([]*api.Volume{newTestVolume("vol1", "")}, nil),
+	)
+
+	require.NoError(t, m.Reconcile())
+	policies, err := m.Enumerate()
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+
+	require.NoError(t, m.Reconcile())
+	policies, err = m.Enumerate()
+	require.NoError(t, err)
+	require.Len(t, policies, 0)
+	require.Len(t, m.running, 0)
+}
+
+func TestSnapshotRetention(t *testing.T) {
+	mc := gomock.NewController(t)
+	defer mc.Finish()
+	d := mockdriver.NewMockVolumeDriver(mc)
+	kv := newTestKvdb(t)
+	s := sched.New(time.Second)
+	defer s.Stop()
+
+	m := New(d, kv, s, nil, "node").(*manager)
+	d.EXPECT().Name().Return("mock").AnyTimes()
+	d.EXPECT().
+		Enumerate(gomock.Any(), gomock.Any()).
+		Return([]*api.Volume{newTestVolume("vol1", testSchedule)}, nil)
+	require.NoError(t, m.Reconcile())
+
+	intervals, err := sched.ParseSchedule(testSchedule)
+	require.NoError(t, err)
+	iv := intervals[0]
+
+	now := time.Now()
+	snap := func(id string, age time.Duration, label string) *api.Volume {
+		return &api.Volume{
+			Id:    id,
+			Ctime: prototime.TimeToTimestamp(now.Add(-age)),
+			Locator: &api.VolumeLocator{
+				VolumeLabels: map[string]string{LabelInterval: label},
+			},
+			Source: &api.Source{Parent: "vol1"},
+		}
+	}
+
+	d.EXPECT().
+		Snapshot("vol1", true, gomock.Any()).
+		Do(func(volumeID string, readonly bool, locator *api.VolumeLocator) {
+			require.Equal(t, sched.PeriodicType, locator.VolumeLabels[LabelInterval])
+		}).
+		Return("snap3", nil)
+	d.EXPECT().
+		SnapEnumerate([]string{"vol1"}, gomock.Any()).
+		Return([]*api.Volume{
+			snap("snap2", time.Hour, sched.PeriodicType),
+			snap("snap1", 2*time.Hour, sched.PeriodicType),
+			snap("manual", 3*time.Hour, ""),
+			snap("snap3", 0, sched.PeriodicType),
+		}, nil)
+	d.EXPECT().Delete("snap1").Return(nil)
+
+	m.snapshot("vol1", iv)
+
+	policies, err := m.Enumerate()
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	require.False(t, policies[0].LastRun[iv.String()].IsZero(),
+		"Snapshot time not recorded")
+}
+
+func TestVolumeSchedule(t *testing.T) {
+	v := newTestVolume("vol1", "")
+	require.Equal(t, "", volumeSchedule(v))
+
+	v.Spec.SnapshotInterval = 30
+	require.Equal(t, "periodic=30", volumeSchedule(v))
+	_, err := sched.ParseSchedule(volumeSchedule(v))
+	require.NoError(t, err)
+
+	v.Spec.SnapshotSchedule = testSchedule
+	require.Equal(t, testSchedule, volumeSchedule(v))
+
+	v.Source = &api.Source{Parent: "vol0"}
+	require.Equal(t, testSchedule, volumeSchedule(v), "Clones are scheduled")
+
+	v.Readonly = true
+	require.Equal(t, "", volumeSchedule(v), "Snapshots are not scheduled")
+}
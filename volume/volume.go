@@ -36,6 +36,19 @@ var (
 	ErrNotSupported = errors.New("Operation not supported")
 	// ErrVolBusy returned when volume is in busy state
 	ErrVolBusy = errors.New("Volume is busy")
+	// ErrVolShrink returned when a volume size update would shrink the volume
+	ErrVolShrink = errors.New("Volume size cannot be reduced")
+	// ErrVolSizeExceeded returned when a volume cannot grow to the requested size
+	ErrVolSizeExceeded = errors.New("Requested volume size exceeds backend capacity")
+	// ErrInvalidPageToken returned when an enumeration token cannot be decoded
+	ErrInvalidPageToken = errors.New("Invalid page token")
+	// ErrVolSticky returned when deleting a volume with the sticky flag set
+	ErrVolSticky = errors.New("Volume is sticky, remove the sticky flag to delete it")
+	// ErrVolHasClones returned when deleting a volume that clones depend on
+	ErrVolHasClones = errors.New("Volume has dependent clones")
+	// ErrNotInTrash returned when a trash operation names a volume that is
+	// not in the trash
+	ErrNotInTrash = errors.New("Volume is not in the trash")
 )
 
 // Constants used by the VolumeDriver
@@ -200,6 +213,11 @@ type Enumerator interface {
 	// Enumerate volumes that map to the volumeLocator. Locator fields may be regexp.
 	// If locator fields are left blank, this will return all volumes.
 	Enumerate(locator *api.VolumeLocator, labels map[string]string) ([]*api.Volume, error)
+	// EnumeratePage returns up to pageSize volumes that match filter in
+	// volume ID order, continuing after token. An empty token starts with
+	// the first volume and a pageSize of 0 returns all remaining volumes.
+	// Errors ErrInvalidPageToken may be returned.
+	EnumeratePage(filter *api.VolumeFilter, pageSize int, token string) (*api.VolumeEnumerateResponse, error)
 	// Enumerate snaps for specified volumes
 	SnapEnumerate(volID []string, snapLabels map[string]string) ([]*api.Volume, error)
 }
@@ -210,6 +228,20 @@ type StoreEnumerator interface {
 	Enumerator
 }
 
+// TrashDriver is implemented by drivers that keep deleted volumes in a trash
+// for a retention period before removing them.
+type TrashDriver interface {
+	// TrashEnumerate returns the volumes in the trash.
+	TrashEnumerate() ([]*api.Volume, error)
+	// TrashRestore moves a volume out of the trash.
+	// Errors ErrEnoEnt, ErrNotInTrash may be returned.
+	TrashRestore(volumeID string) error
+	// TrashPurge deletes a volume in the trash without waiting for its
+	// retention period to expire.
+	// Errors ErrEnoEnt, ErrNotInTrash may be returned.
+	TrashPurge(volumeID string) error
+}
+
 // BlockDriver needs to be implemented by block volume drivers.  Filesystem volume
 // drivers can ignore this interface and include the builtin DefaultBlockDriver.
 type BlockDriver interface {